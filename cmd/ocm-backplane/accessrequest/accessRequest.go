@@ -18,6 +18,8 @@ func NewAccessRequestCmd() *cobra.Command {
 	cmd.AddCommand(newCreateAccessRequestCmd())
 	cmd.AddCommand(newGetAccessRequestCmd())
 	cmd.AddCommand(newExpireAccessRequestCmd())
+	cmd.AddCommand(newListAccessRequestsCmd())
+	cmd.AddCommand(newWaitAccessRequestCmd())
 
 	return cmd
 }
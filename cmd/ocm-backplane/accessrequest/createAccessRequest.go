@@ -22,6 +22,8 @@ var (
 		notificationIssueID string
 		pendingDuration     time.Duration
 		approvalDuration    time.Duration
+		wait                bool
+		waitOptions         waitOptions
 	}
 )
 
@@ -57,9 +59,18 @@ func newCreateAccessRequestCmd() *cobra.Command {
 		&options.approvalDuration,
 		"approval-duration",
 		"d",
-		8*time.Hour,
+		accessrequest.DefaultApprovalDuration,
 		"The maximal period of time during which the access request can stay approved")
 
+	cmd.Flags().BoolVarP(
+		&options.wait,
+		"wait",
+		"w",
+		false,
+		"Wait for the approval of the access request, and log into the cluster once approved")
+
+	addWaitFlags(cmd, &options.waitOptions)
+
 	return cmd
 }
 
@@ -111,7 +122,7 @@ func runCreateAccessRequest(cmd *cobra.Command, args []string) error {
 	if accessRequest != nil {
 		accessrequest.PrintAccessRequest(clusterID, accessRequest)
 
-		return fmt.Errorf("there is already an active access request for cluster '%s', eventually consider expiring it running 'ocm-backplane accessrequest expire'", clusterID)
+		return fmt.Errorf("there is already an active access request for cluster '%s', eventually consider waiting for its approval running 'ocm-backplane accessrequest wait' or expiring it running 'ocm-backplane accessrequest expire'", clusterID)
 	}
 
 	reason := options.reason
@@ -130,5 +141,9 @@ func runCreateAccessRequest(cmd *cobra.Command, args []string) error {
 
 	accessrequest.PrintAccessRequest(clusterID, accessRequest)
 
+	if options.wait {
+		return waitAndLogin(cmd, clusterID, accessRequest.ID(), options.waitOptions)
+	}
+
 	return nil
 }
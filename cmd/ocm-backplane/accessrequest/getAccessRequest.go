@@ -44,7 +44,7 @@ func runGetAccessRequest(cmd *cobra.Command, args []string) error {
 
 	if accessRequest == nil {
 		logger.Warnf("no pending or approved access request for cluster '%s'", clusterID)
-		fmt.Printf("To get denied or expired access requests, run: ocm get /api/access_transparency/v1/access_requests -p search=\"cluster_id='%s'\"\n", clusterID)
+		fmt.Printf("To get denied or expired access requests, run: ocm-backplane accessrequest list --cluster-id %s\n", clusterID)
 	} else {
 		accessrequest.PrintAccessRequest(clusterID, accessRequest)
 	}
@@ -0,0 +1,70 @@
+package accessrequest
+
+import (
+	"fmt"
+
+	"github.com/openshift/backplane-cli/pkg/accessrequest"
+	"github.com/openshift/backplane-cli/pkg/ocm"
+
+	logger "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// newListAccessRequestsCmd returns cobra command
+func newListAccessRequestsCmd() *cobra.Command {
+	var states []string
+
+	cmd := &cobra.Command{
+		Use:           "list",
+		Short:         "List the access requests of the cluster, including the denied and expired ones",
+		Args:          cobra.ExactArgs(0),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runListAccessRequests(cmd, states)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(
+		&states,
+		"state",
+		"s",
+		[]string{},
+		"Only list the access requests in the given states: pending, approved, denied or expired")
+	_ = cmd.RegisterFlagCompletionFunc("state", cobra.FixedCompletions(
+		[]string{"pending", "approved", "denied", "expired"}, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
+
+// runListAccessRequests lists the access requests of the cluster
+func runListAccessRequests(cmd *cobra.Command, stateNames []string) error {
+	states, err := accessrequest.ParseAccessRequestStates(stateNames)
+	if err != nil {
+		return err
+	}
+
+	clusterID, err := accessrequest.GetClusterID(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to compute cluster ID: %v", err)
+	}
+
+	ocmConnection, err := ocm.DefaultOCMInterface.SetupOCMConnection()
+	if err != nil {
+		return fmt.Errorf("failed to create OCM connection: %v", err)
+	}
+
+	accessRequests, err := accessrequest.ListAccessRequests(ocmConnection, clusterID, states)
+	if err != nil {
+		return err
+	}
+
+	if len(accessRequests) == 0 {
+		logger.Warnf("no access request found for cluster '%s'", clusterID)
+		return nil
+	}
+
+	accessrequest.PrintAccessRequests(accessRequests)
+
+	return nil
+}
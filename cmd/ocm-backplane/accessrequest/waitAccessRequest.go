@@ -0,0 +1,77 @@
+package accessrequest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/openshift/backplane-cli/cmd/ocm-backplane/login"
+	"github.com/openshift/backplane-cli/pkg/accessrequest"
+	"github.com/openshift/backplane-cli/pkg/ocm"
+
+	"github.com/spf13/cobra"
+)
+
+// waitOptions define how to wait for the approval of an access request
+type waitOptions struct {
+	timeout time.Duration
+	noLogin bool
+}
+
+// addWaitFlags adds the flags defining how to wait for the approval of an access request
+func addWaitFlags(cmd *cobra.Command, opts *waitOptions) {
+	cmd.Flags().DurationVar(
+		&opts.timeout,
+		"wait-timeout",
+		0,
+		"The maximal period of time to wait for the approval of the access request, until it is denied or expires when zero")
+
+	cmd.Flags().BoolVar(
+		&opts.noLogin,
+		"no-login",
+		false,
+		"Do not log into the cluster once the access request is approved")
+}
+
+// newWaitAccessRequestCmd returns cobra command
+func newWaitAccessRequestCmd() *cobra.Command {
+	opts := waitOptions{}
+
+	cmd := &cobra.Command{
+		Use:           "wait",
+		Short:         "Wait for the approval of the active access request, and log into the cluster once approved",
+		Args:          cobra.ExactArgs(0),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusterID, err := accessrequest.GetClusterID(cmd)
+			if err != nil {
+				return fmt.Errorf("failed to compute cluster ID: %v", err)
+			}
+			return waitAndLogin(cmd, clusterID, "", opts)
+		},
+	}
+
+	addWaitFlags(cmd, &opts)
+
+	return cmd
+}
+
+// waitAndLogin waits for the approval of the access request of the cluster, and logs into the cluster once approved
+func waitAndLogin(cmd *cobra.Command, clusterID, accessRequestID string, opts waitOptions) error {
+	ocmConnection, err := ocm.DefaultOCMInterface.SetupOCMConnection()
+	if err != nil {
+		return fmt.Errorf("failed to create OCM connection: %v", err)
+	}
+
+	_, err = accessrequest.WaitForAccessRequest(ocmConnection, clusterID, accessRequestID, accessrequest.DefaultWaitInterval, opts.timeout)
+	if err != nil {
+		return err
+	}
+
+	if opts.noLogin {
+		return nil
+	}
+
+	fmt.Printf("Logging into cluster '%s'\n", clusterID)
+	return login.LoginCmd.RunE(cmd, []string{clusterID})
+}
@@ -14,6 +14,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/openshift/backplane-cli/pkg/cli/config"
+	"github.com/openshift/backplane-cli/pkg/releasecheck"
 	"github.com/openshift/backplane-cli/pkg/utils"
 )
 
@@ -90,8 +91,6 @@ func init() {
 func runConsole(cmd *cobra.Command, argv []string) (err error) {
 	var clusterKey string
 
-	utils.CheckBackplaneVersion(cmd)
-
 	err = validateParams(argv)
 
 	if err != nil {
@@ -131,20 +130,26 @@ func runConsole(cmd *cobra.Command, argv []string) (err error) {
 	if err != nil {
 		return fmt.Errorf("unable to build backplane configuration: %w", err)
 	}
+	releasecheck.Notify(cmd, backplaneConfiguration)
 
 	// ============Get Backplane URl ==========================
 	if consoleArgs.backplaneURL != "" { // Overwrite if parameter is set
 		backplaneConfiguration.URL = consoleArgs.backplaneURL
 	}
 
+	// The flag takes precedence over the session-duration-minutes of the config file
+	sessionDurationMinutes := backplaneConfiguration.SessionDurationMinutes
+	if consoleArgs.sessionDurationMinutes > 0 {
+		sessionDurationMinutes = consoleArgs.sessionDurationMinutes
+	}
 	// Only evaluate if the session duration is greater than the default of 15 minutes
 	// The default does not need to be configured anywhere
-	if consoleArgs.sessionDurationMinutes > 15 {
-		if consoleArgs.sessionDurationMinutes > MAX_SESSION_TIMEOUT_DURATION {
+	if sessionDurationMinutes > 15 {
+		if sessionDurationMinutes > MAX_SESSION_TIMEOUT_DURATION {
 			logger.Warnf("Session duration cannot exceed %d minutes, setting to maximum of %d minutes", MAX_SESSION_TIMEOUT_DURATION, MAX_SESSION_TIMEOUT_DURATION)
 			backplaneConfiguration.SessionDurationMinutes = MAX_SESSION_TIMEOUT_DURATION
 		} else {
-			backplaneConfiguration.SessionDurationMinutes = consoleArgs.sessionDurationMinutes
+			backplaneConfiguration.SessionDurationMinutes = sessionDurationMinutes
 		}
 	}
 
@@ -164,7 +169,7 @@ func runConsole(cmd *cobra.Command, argv []string) (err error) {
 	consoleResponse, err := queryConfig.GetCloudConsole()
 
 	// Declare helperMsg
-	helperMsg := "\n\033[1mNOTE: To troubleshoot the connectivity issues, please run `ocm-backplane health-check`\033[0m\n\n"
+	helperMsg := fmt.Sprintf("\n\033[1mNOTE: To find which hop of the path to the cluster fails, please run `ocm-backplane healthcheck --cluster %s`\033[0m\n\n", clusterID)
 
 	if err != nil {
 		// Check API connection with configured proxy
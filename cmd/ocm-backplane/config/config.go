@@ -19,21 +19,23 @@ func NewConfigCmd() *cobra.Command {
 		Long: `Get or set backplane-cli configuration variables.
 The location of the configuration file is gleaned from ~/.config/backplane/config.json or the 'BACKPLANE_CONFIG' environment variable if set.
 
-The following variables are supported:
-url          Backplane API URL
-proxy-url    Squid proxy URL
-session-dir  Backplane CLI session directory
-pd-key	     PagerDuty API User Key
-jira-token   JIRA token
-jira-email   JIRA email
-govcloud     Set to true if used in FedRAMP
+Run 'ocm backplane config list' for the supported variables, along with their type and value.
+
+The config file can hold profiles, each overriding these variables for an environment,
+see 'ocm backplane config profiles --help'.
 `,
 		SilenceUsage: true,
 	}
 
 	cmd.AddCommand(newGetCmd())
 	cmd.AddCommand(newSetCmd())
+	cmd.AddCommand(newUnsetCmd())
+	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newEditCmd())
+	cmd.AddCommand(newValidateCmd())
+	cmd.AddCommand(newMigrateSecretsCmd())
 	cmd.AddCommand(newTroubleshootCmd())
 	cmd.AddCommand(newRefreshCmd())
+	cmd.AddCommand(newProfilesCmd())
 	return cmd
 }
\ No newline at end of file
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/backplane-cli/pkg/cli/config"
+)
+
+// runEditor opens a file in the editor of the user
+var runEditor = func(editor string, path string) error {
+	args := strings.Fields(editor)
+	cmd := exec.Command(args[0], append(args[1:], path)...) //#nosec G204
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func newEditCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "edit",
+		Short:        "Edit the Backplane CLI config file",
+		Long:         "Open the config file in $VISUAL or $EDITOR, vi by default, and validate it once edited.",
+		Example:      "EDITOR=nano ocm backplane config edit",
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE:         editConfig,
+	}
+	return cmd
+}
+
+func editConfig(cmd *cobra.Command, args []string) error {
+	configPath, err := config.GetConfigFilePath()
+	if err != nil {
+		return err
+	}
+	if created, err := ensureConfigDirectory(configPath); err != nil || !created {
+		return err
+	}
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		if err := os.WriteFile(configPath, []byte("{}\n"), 0600); err != nil {
+			return err
+		}
+	}
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	if err := runEditor(editor, configPath); err != nil {
+		return fmt.Errorf("failed to edit the config file with %s: %v", editor, err)
+	}
+
+	configFile, err := config.ReadConfigFile()
+	if err != nil {
+		return err
+	}
+	return reportIssues(configFile)
+}
@@ -12,6 +12,7 @@ func newGetCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:          "get",
 		Short:        "Get Backplane CLI configuration variables",
+		Long:         "Get Backplane CLI configuration variables, the secret ones such as pd-key are masked.",
 		Example:      "ocm backplane config get url",
 		SilenceUsage: true,
 		Args:         cobra.ExactArgs(1),
@@ -21,33 +22,33 @@ func newGetCmd() *cobra.Command {
 }
 
 func getConfig(cmd *cobra.Command, args []string) error {
-	config, err := config.GetBackplaneConfiguration()
+	bpConfig, err := config.GetBackplaneConfiguration()
 	if err != nil {
 		return err
 	}
 
 	proxyURL := ""
-	if config.ProxyURL != nil {
-		proxyURL = *config.ProxyURL
+	if bpConfig.ProxyURL != nil {
+		proxyURL = *bpConfig.ProxyURL
 	}
 
 	switch args[0] {
 	case URLConfigVar:
-		fmt.Printf("%s: %s\n", URLConfigVar, config.URL)
+		fmt.Printf("%s: %s\n", URLConfigVar, bpConfig.URL)
 	case ProxyURLConfigVar:
 		fmt.Printf("%s: %s\n", ProxyURLConfigVar, proxyURL)
 	case SessionConfigVar:
-		fmt.Printf("%s: %s\n", SessionConfigVar, config.SessionDirectory)
+		fmt.Printf("%s: %s\n", SessionConfigVar, bpConfig.SessionDirectory)
 	case PagerDutyAPIConfigVar:
-		fmt.Printf("%s: %s\n", PagerDutyAPIConfigVar, config.PagerDutyAPIKey)
+		fmt.Printf("%s: %s\n", PagerDutyAPIConfigVar, config.MaskSecret(bpConfig.PagerDutyAPIKey))
 	case GovcloudVar:
-		fmt.Printf("%s: %t\n", GovcloudVar, config.Govcloud)
+		fmt.Printf("%s: %t\n", GovcloudVar, bpConfig.Govcloud)
 	case "all":
-		fmt.Printf("%s: %s\n", URLConfigVar, config.URL)
+		fmt.Printf("%s: %s\n", URLConfigVar, bpConfig.URL)
 		fmt.Printf("%s: %s\n", ProxyURLConfigVar, proxyURL)
-		fmt.Printf("%s: %s\n", SessionConfigVar, config.SessionDirectory)
-		fmt.Printf("%s: %s\n", PagerDutyAPIConfigVar, config.PagerDutyAPIKey)
-		fmt.Printf("%s: %t\n", GovcloudVar, config.Govcloud)
+		fmt.Printf("%s: %s\n", SessionConfigVar, bpConfig.SessionDirectory)
+		fmt.Printf("%s: %s\n", PagerDutyAPIConfigVar, config.MaskSecret(bpConfig.PagerDutyAPIKey))
+		fmt.Printf("%s: %t\n", GovcloudVar, bpConfig.Govcloud)
 	default:
 		return fmt.Errorf("supported config variables are %s, %s, %s, %s, & %s", URLConfigVar, ProxyURLConfigVar, SessionConfigVar, PagerDutyAPIConfigVar, GovcloudVar)
 	}
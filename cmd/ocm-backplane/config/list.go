@@ -0,0 +1,72 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/backplane-cli/pkg/cli/config"
+	"github.com/openshift/backplane-cli/pkg/utils"
+)
+
+func newListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "list",
+		Short:        "List the Backplane CLI configuration variables",
+		Long:         "List the supported variables along with their value in the config file, for the profile in use if any. Secret values are masked.",
+		Example:      "ocm backplane config list\nocm backplane --profile stage config list",
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE:         listConfig,
+	}
+	return cmd
+}
+
+func listConfig(cmd *cobra.Command, args []string) error {
+	configFile, err := config.ReadConfigFile()
+	if err != nil {
+		return err
+	}
+	settings := configFile.Settings
+	if profile := configFile.CurrentProfile(); profile != "" {
+		if settings, err = configFile.EffectiveSettings(profile); err != nil {
+			return err
+		}
+	}
+
+	rows := [][]string{}
+	for _, key := range config.Schema() {
+		if key.Name == config.ProfilesKey {
+			continue
+		}
+		description := key.Description
+		if key.Deprecated {
+			description = "(Deprecated) " + description
+		}
+		rows = append(rows, []string{key.Name, key.Kind, formatValue(key, settings[key.Name]), description})
+	}
+	utils.RenderTabbedTable([]string{"KEY", "TYPE", "VALUE", "DESCRIPTION"}, rows)
+	return nil
+}
+
+// formatValue formats a value of the config file, masking the secret ones
+func formatValue(key config.Key, value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		if key.Secret {
+			return config.MaskSecret(v)
+		}
+		return v
+	case bool, float64:
+		return fmt.Sprint(v)
+	default:
+		content, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(content)
+	}
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/backplane-cli/pkg/cli/config"
+	"github.com/openshift/backplane-cli/pkg/info"
+	"github.com/openshift/backplane-cli/pkg/secrets"
+)
+
+var migrateSecretsArgs struct {
+	secretStore string
+}
+
+func newMigrateSecretsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate-secrets",
+		Short: "Move the plain text secrets of the config file to a secret store",
+		Long: `Move the plain text values of the secret variables of the config file and its profiles, such as pd-key
+and jira-token, to the OS keyring when available, else to a file encrypted with age and a passphrase next to the config file.
+The config file only references them afterwards.`,
+		Example:      "ocm backplane config migrate-secrets\nocm backplane config migrate-secrets --secret-store file",
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE:         migrateSecrets,
+	}
+	cmd.Flags().StringVar(
+		&migrateSecretsArgs.secretStore,
+		"secret-store",
+		"",
+		fmt.Sprintf("Where to move the secrets: %s|%s, the keyring when available by default, else %s",
+			secrets.StoreKeyring, secrets.StoreFile, info.BackplaneSecretStoreEnvName),
+	)
+	return cmd
+}
+
+func migrateSecrets(cmd *cobra.Command, args []string) error {
+	storeName := migrateSecretsArgs.secretStore
+	if storeName == "" {
+		storeName = config.DefaultSecretStoreName()
+	}
+	if storeName == config.StorePlain {
+		return fmt.Errorf("cannot migrate the secrets to the %s store", config.StorePlain)
+	}
+
+	configFile, err := config.ReadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	migrated := 0
+	for _, profile := range append([]string{""}, configFile.ProfileNames()...) {
+		for _, key := range config.Schema() {
+			if !key.Secret {
+				continue
+			}
+			value, _ := configFile.Get(profile, key.Name)
+			plain, ok := value.(string)
+			if !ok || plain == "" || config.IsSecretRef(plain) {
+				continue
+			}
+
+			ref, err := config.StoreSecret(storeName, profile, key.Name, plain)
+			if err != nil {
+				return err
+			}
+			configFile.Set(profile, key.Name, ref)
+			// write after each secret, to not lose the ones already moved on failure
+			if err := configFile.Write(); err != nil {
+				return err
+			}
+			migrated++
+
+			location := key.Name
+			if profile != "" {
+				location = fmt.Sprintf("%s of profile %s", key.Name, profile)
+			}
+			printCorrect("%s moved to the %s secret store\n", location, storeName)
+		}
+	}
+
+	if migrated == 0 {
+		fmt.Printf("No plain text secrets in the config file %s\n", configFile.Path)
+		return nil
+	}
+	fmt.Printf("%d secret(s) moved, the config file %s only references them\n", migrated, configFile.Path)
+	return nil
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/backplane-cli/pkg/cli/config"
+	"github.com/openshift/backplane-cli/pkg/utils"
+)
+
+func newProfilesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profiles",
+		Short: "List, use and show the profiles of the config file",
+		Long: `The profiles of the config file hold the settings of an environment, e.g. production, stage or govcloud.
+The settings of the profile in use override the top level settings of the config file.
+
+The profile in use is the one given by the --profile flag, else by the BACKPLANE_PROFILE environment variable,
+else the current profile of the config file. When a profile sets 'ocm-env', the OCM environment
+must be the one of the profile.
+`,
+		Example: `ocm backplane config profiles list
+ocm backplane config profiles use stage
+ocm backplane config profiles show production`,
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:          "list",
+		Short:        "List the profiles of the config file",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         listProfiles,
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:          "use <profile>",
+		Short:        "Make a profile the current profile of the config file",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE:         useProfile,
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:          "show [profile]",
+		Short:        "Show the settings of a profile, the profile in use by default, with the secrets masked",
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE:         showProfile,
+	})
+	return cmd
+}
+
+func listProfiles(cmd *cobra.Command, args []string) error {
+	configFile, err := config.ReadConfigFile()
+	if err != nil {
+		return err
+	}
+	names := configFile.ProfileNames()
+	if len(names) == 0 {
+		fmt.Printf("No profiles in the config file %s\n", configFile.Path)
+		return nil
+	}
+
+	current := configFile.CurrentProfile()
+	profiles := configFile.Profiles()
+	rows := [][]string{}
+	for _, name := range names {
+		ocmEnv, _ := profiles[name][config.OCMEnvKey].(string)
+		inUse := ""
+		if strings.EqualFold(name, current) {
+			inUse = "*"
+		}
+		rows = append(rows, []string{inUse, name, ocmEnv})
+	}
+	utils.RenderTabbedTable([]string{"CURRENT", "NAME", "OCM ENV"}, rows)
+	return nil
+}
+
+func useProfile(cmd *cobra.Command, args []string) error {
+	name, err := config.UseProfile(args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Switched to profile %s\n", name)
+	return nil
+}
+
+func showProfile(cmd *cobra.Command, args []string) error {
+	configFile, err := config.ReadConfigFile()
+	if err != nil {
+		return err
+	}
+	name := configFile.CurrentProfile()
+	if len(args) > 0 {
+		name = args[0]
+	}
+	if name == "" {
+		return fmt.Errorf("no profile in use, give the profile to show, one of: %s", strings.Join(configFile.ProfileNames(), ", "))
+	}
+
+	settings, err := configFile.EffectiveSettings(name)
+	if err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(config.MaskSecrets(settings), "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(content))
+	return nil
+}
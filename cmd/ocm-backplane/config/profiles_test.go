@@ -0,0 +1,56 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("profiles command", func() {
+	var configFile string
+
+	BeforeEach(func() {
+		configFile = filepath.Join(GinkgoT().TempDir(), "config.json")
+		GinkgoT().Setenv("BACKPLANE_CONFIG", configFile)
+		Expect(os.WriteFile(configFile, []byte(`{
+			"proxy-url": "http://proxy.example.com:3128",
+			"profiles": {
+				"stage": {"ocm-env": "staging"},
+				"govcloud": {"govcloud": true}
+			}
+		}`), 0600)).To(Succeed())
+	})
+
+	runProfiles := func(args ...string) error {
+		cmd := newProfilesCmd()
+		cmd.SetArgs(args)
+		return cmd.Execute()
+	}
+
+	It("should make a profile the current one", func() {
+		Expect(runProfiles("use", "govcloud")).To(Succeed())
+
+		content, err := os.ReadFile(configFile)
+		Expect(err).To(BeNil())
+		var settings map[string]interface{}
+		Expect(json.Unmarshal(content, &settings)).To(Succeed())
+		Expect(settings["current-profile"]).To(Equal("govcloud"))
+		Expect(settings["proxy-url"]).To(Equal("http://proxy.example.com:3128"))
+		Expect(settings["profiles"]).To(HaveKey("stage"))
+	})
+
+	It("should fail to use an unknown profile", func() {
+		err := runProfiles("use", "production")
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring("expected one of govcloud, stage"))
+	})
+
+	It("should fail to show without profile in use", func() {
+		err := runProfiles("show")
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(Equal("no profile in use, give the profile to show, one of: govcloud, stage"))
+	})
+})
@@ -4,98 +4,143 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"strings"
 
-	"strconv"
-
+	logger "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 	"golang.org/x/term"
 	"gopkg.in/AlecAivazis/survey.v1"
 
 	"github.com/openshift/backplane-cli/pkg/cli/config"
+	"github.com/openshift/backplane-cli/pkg/info"
+	"github.com/openshift/backplane-cli/pkg/secrets"
 )
 
+var setArgs struct {
+	secretStore string
+}
+
 func newSetCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:          "set",
-		Short:        "Set Backplane CLI configuration variables",
-		Example:      "ocm backplane config set url https://example.com",
+		Use:   "set <key> <value>",
+		Short: "Set Backplane CLI configuration variables",
+		Long: `Set a variable of the config file, after checking its value is of the type of the variable.
+Lists of URLs are comma separated, objects are given as JSON.
+With --profile, the variable is set in the profile, which is created when it does not exist.
+
+The secret variables, such as pd-key and jira-token, are kept in the OS keyring when available,
+else in a file next to the config file encrypted with age and the passphrase of BACKPLANE_SECRETS_PASSPHRASE,
+prompted when not set, and the config file only references them.
+
+Run 'ocm backplane config list' for the supported variables.`,
+		Example:      "ocm backplane config set url https://example.com\nocm backplane config set proxy-url http://proxy1:3128,http://proxy2:3128\nocm backplane --profile stage config set pd-key <api-key>",
 		SilenceUsage: true,
 		Args:         cobra.ExactArgs(2),
 		RunE:         setConfig,
 	}
+	cmd.Flags().StringVar(
+		&setArgs.secretStore,
+		"secret-store",
+		"",
+		fmt.Sprintf("Where to keep the secret variables: %s|%s|%s, the keyring when available by default, else %s",
+			secrets.StoreKeyring, secrets.StoreFile, config.StorePlain, info.BackplaneSecretStoreEnvName),
+	)
 
 	return cmd
 }
 
 func setConfig(cmd *cobra.Command, args []string) error {
-	configPath, err := config.GetConfigFilePath()
+	key, ok := config.LookupKey(args[0])
+	if !ok {
+		return fmt.Errorf("unsupported config variable %s, supported config variables are %s", args[0], strings.Join(config.KeyNames(), ", "))
+	}
+	value, err := key.Parse(args[1])
 	if err != nil {
 		return err
 	}
+	if key.Deprecated {
+		logger.Warnf("%s is deprecated, please consider removing it with 'ocm backplane config unset %s'", key.Name, key.Name)
+	}
 
-	if _, err = os.Stat(configPath); err == nil {
-		viper.SetConfigFile(configPath)
-		if err := viper.ReadInConfig(); err != nil {
+	configFile, err := config.ReadConfigFile()
+	if err != nil {
+		return err
+	}
+	if created, err := ensureConfigDirectory(configFile.Path); err != nil || !created {
+		return err
+	}
+
+	profile := config.ProfileFlag()
+	if name, _, err := configFile.Profile(profile); profile != "" && err == nil {
+		profile = name
+	}
+	if key.Secret {
+		if value, err = storeSecret(configFile, profile, key.Name, args[1], setArgs.secretStore); err != nil {
 			return err
 		}
 	}
 
-	// create config directory if it doesn't exist
-	if dir, err := os.Stat(path.Dir(configPath)); os.IsNotExist(err) || !dir.IsDir() {
-		// check if stdout is a terminal. if so, prompt user to create config directory
-		if term.IsTerminal(int(os.Stdout.Fd())) {
-			confirm := false
-			prompt := &survey.Confirm{
-				Message: fmt.Sprintf("Config directory \"%s\" does not exist. Create it?", path.Dir(configPath)),
-				Default: true,
-			}
-			if err := survey.AskOne(prompt, &confirm, nil); err != nil {
-				return err
-			}
-			if confirm {
-				if err := os.MkdirAll(path.Dir(configPath), 0750); err != nil {
-					return err
-				}
-			} else {
-				fmt.Println("Aborted")
-				return nil
-			}
-		} else {
-			// if we aren't in a terminal, just return an error
-			return fmt.Errorf("config directory does not exist: %s", path.Dir(configPath))
-		}
+	configFile.Set(profile, key.Name, value)
+	if err := configFile.Write(); err != nil {
+		return err
 	}
+	fmt.Println("Configuration file updated at " + configFile.Path)
 
-	switch args[0] {
-	case URLConfigVar:
-		viper.Set(URLConfigVar, args[1])
-	case ProxyURLConfigVar:
-		viper.Set(ProxyURLConfigVar, args[1])
-	case SessionConfigVar:
-		viper.Set(SessionConfigVar, args[1])
-	case PagerDutyAPIConfigVar:
-		viper.Set(PagerDutyAPIConfigVar, args[1])
-	case config.JiraTokenViperKey:
-		viper.Set(config.JiraTokenViperKey, args[1])
-	case config.JiraEmailViperKey:
-		viper.Set(config.JiraEmailViperKey, args[1])
-	case GovcloudVar:
-		govcloud, err := strconv.ParseBool(args[1])
-		if err != nil {
-			return fmt.Errorf("invalid value for %s: %v", GovcloudVar, err)
-		}
-		viper.Set(GovcloudVar, govcloud)
-	default:
-		return fmt.Errorf("supported config variables are %s, %s, %s, %s, %s, %s & %s", URLConfigVar, ProxyURLConfigVar, SessionConfigVar, PagerDutyAPIConfigVar, config.JiraTokenViperKey, config.JiraEmailViperKey, GovcloudVar)
+	return nil
+}
+
+// ensureConfigDirectory creates the directory of the config file when it does not exist,
+// after a confirmation in a terminal. It returns false when the user declines.
+func ensureConfigDirectory(configPath string) (bool, error) {
+	dir, err := os.Stat(path.Dir(configPath))
+	if err == nil && dir.IsDir() {
+		return true, nil
 	}
 
-	viper.SetConfigType("json")
-	err = viper.WriteConfigAs(configPath)
+	// check if stdout is a terminal. if so, prompt user to create config directory
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		// if we aren't in a terminal, just return an error
+		return false, fmt.Errorf("config directory does not exist: %s", path.Dir(configPath))
+	}
+	confirm := false
+	prompt := &survey.Confirm{
+		Message: fmt.Sprintf("Config directory \"%s\" does not exist. Create it?", path.Dir(configPath)),
+		Default: true,
+	}
+	if err := survey.AskOne(prompt, &confirm, nil); err != nil {
+		return false, err
+	}
+	if !confirm {
+		fmt.Println("Aborted")
+		return false, nil
+	}
+	if err := os.MkdirAll(path.Dir(configPath), 0750); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// storeSecret keeps the value of a secret variable in a secret store, replacing the secret it previously referenced,
+// and returns the reference to write in the config file
+func storeSecret(configFile *config.ConfigFile, profile string, key string, value string, storeName string) (string, error) {
+	if storeName == "" {
+		storeName = config.DefaultSecretStoreName()
+	}
+	ref, err := config.StoreSecret(storeName, profile, key, value)
 	if err != nil {
-		return err
+		return "", err
 	}
-	fmt.Println("Configuration file updated at " + configPath)
 
-	return nil
-}
\ No newline at end of file
+	// the previous secret is deleted once the new one is stored only, unless the new one replaced it in place
+	if previous, ok := configFile.Get(profile, key); ok {
+		if previousRef, isString := previous.(string); isString && config.IsSecretRef(previousRef) && previousRef != ref {
+			if err := config.DeleteSecret(previousRef); err != nil {
+				logger.Warn(err)
+			}
+		}
+	}
+	if ref != value {
+		fmt.Printf("%s kept in the %s secret store\n", key, storeName)
+	}
+	return ref, nil
+}
@@ -2,12 +2,18 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/zalando/go-keyring"
+
+	"github.com/openshift/backplane-cli/pkg/cli/config"
+	"github.com/openshift/backplane-cli/pkg/secrets"
 )
 
 var _ = Describe("set command", func() {
@@ -23,6 +29,11 @@ var _ = Describe("set command", func() {
 
 		configFile = filepath.Join(tempDir, "config.json")
 		os.Setenv("BACKPLANE_CONFIG", configFile)
+		GinkgoT().Setenv("BACKPLANE_SECRET_STORE", "file")
+		GinkgoT().Setenv("BACKPLANE_SECRETS_PASSPHRASE", "my-passphrase")
+		workFactor := secrets.ScryptWorkFactor
+		secrets.ScryptWorkFactor = 10
+		DeferCleanup(func() { secrets.ScryptWorkFactor = workFactor })
 
 		viper.Reset()
 	})
@@ -106,7 +117,8 @@ var _ = Describe("set command", func() {
 			Expect(err).To(BeNil())
 
 			cfg := readConfig()
-			Expect(cfg["jira-token"]).To(Equal("new-token"))
+			Expect(cfg["jira-token"]).To(Equal("secret-store:file/jira-token"))
+			Expect(config.ResolveSecret(cfg["jira-token"].(string))).To(Equal("new-token"))
 
 			proxyURL, ok := cfg["proxy-url"].([]interface{})
 			Expect(ok).To(BeTrue(), "proxy-url should remain an array")
@@ -120,7 +132,8 @@ var _ = Describe("set command", func() {
 			Expect(err).To(BeNil())
 
 			cfg := readConfig()
-			Expect(cfg["pd-key"]).To(Equal("new-pd-key"))
+			Expect(cfg["pd-key"]).To(Equal("secret-store:file/pd-key"))
+			Expect(config.ResolveSecret(cfg["pd-key"].(string))).To(Equal("new-pd-key"))
 
 			proxyURL, ok := cfg["proxy-url"].([]interface{})
 			Expect(ok).To(BeTrue(), "proxy-url should remain an array")
@@ -147,7 +160,7 @@ var _ = Describe("set command", func() {
 			Expect(err).To(BeNil())
 
 			cfg := readConfig()
-			Expect(cfg["pd-key"]).To(Equal("new-pd-key"))
+			Expect(cfg["pd-key"]).To(Equal("secret-store:file/pd-key"))
 			Expect(cfg["jira-base-url"]).To(Equal("https://redhat.atlassian.net"))
 			Expect(cfg["assume-initial-arn"]).To(Equal("arn:aws:iam::123456789012:role/Example"))
 			Expect(cfg["prod-env-name"]).To(Equal("production"))
@@ -220,3 +233,152 @@ var _ = Describe("set command", func() {
 		})
 	})
 })
+
+var _ = Describe("schema commands", func() {
+	var configFile string
+
+	BeforeEach(func() {
+		configFile = filepath.Join(GinkgoT().TempDir(), "config.json")
+		GinkgoT().Setenv("BACKPLANE_CONFIG", configFile)
+		GinkgoT().Setenv("BACKPLANE_SECRET_STORE", "file")
+		GinkgoT().Setenv("BACKPLANE_SECRETS_PASSPHRASE", "my-passphrase")
+		workFactor := secrets.ScryptWorkFactor
+		secrets.ScryptWorkFactor = 10
+		DeferCleanup(func() { secrets.ScryptWorkFactor = workFactor })
+	})
+
+	writeConfig := func(content string) {
+		Expect(os.WriteFile(configFile, []byte(content), 0600)).To(Succeed())
+	}
+
+	readConfig := func() map[string]interface{} {
+		data, err := os.ReadFile(configFile)
+		Expect(err).To(BeNil())
+		var result map[string]interface{}
+		Expect(json.Unmarshal(data, &result)).To(Succeed())
+		return result
+	}
+
+	run := func(cmd *cobra.Command, args ...string) error {
+		cmd.SetArgs(args)
+		return cmd.Execute()
+	}
+
+	It("should set typed values and keep the case of the profiles", func() {
+		writeConfig(`{"proxy-url": "http://proxy.example.com:3128", "profiles": {"Stage": {"ocm-env": "staging"}}}`)
+
+		Expect(run(newSetCmd(), "display-cluster-info", "true")).To(Succeed())
+		Expect(run(newSetCmd(), "session-duration-minutes", "30")).To(Succeed())
+		Expect(run(newSetCmd(), "aws-proxy", "http://proxy1:3128,http://proxy2:3128")).To(Succeed())
+
+		cfg := readConfig()
+		Expect(cfg["display-cluster-info"]).To(Equal(true))
+		Expect(cfg["session-duration-minutes"]).To(Equal(float64(30)))
+		Expect(cfg["aws-proxy"]).To(Equal([]interface{}{"http://proxy1:3128", "http://proxy2:3128"}))
+		Expect(cfg["profiles"]).To(HaveKey("Stage"))
+	})
+
+	It("should set and unset the values of the profile given by --profile", func() {
+		writeConfig(`{"proxy-url": "http://proxy.example.com:3128", "profiles": {"Stage": {"ocm-env": "staging"}}}`)
+		config.SetProfile("stage")
+		defer config.SetProfile("")
+
+		Expect(run(newSetCmd(), "pd-key", "stage-key")).To(Succeed())
+		Expect(run(newSetCmd(), "vpn-check-endpoint", "https://vpn.stage.example.com")).To(Succeed())
+		Expect(run(newUnsetCmd(), "ocm-env")).To(Succeed())
+
+		cfg := readConfig()
+		Expect(cfg).NotTo(HaveKey("pd-key"))
+		Expect(cfg["profiles"]).To(Equal(map[string]interface{}{
+			"Stage": map[string]interface{}{"pd-key": "secret-store:file/Stage/pd-key", "vpn-check-endpoint": "https://vpn.stage.example.com"},
+		}))
+		Expect(config.ResolveSecret("secret-store:file/Stage/pd-key")).To(Equal("stage-key"))
+	})
+
+	It("should replace the previous secret and delete it once unset", func() {
+		writeConfig(`{"proxy-url": "http://proxy.example.com:3128"}`)
+
+		Expect(run(newSetCmd(), "jira-token", "old-token")).To(Succeed())
+		Expect(run(newSetCmd(), "jira-token", "new-token")).To(Succeed())
+		Expect(config.ResolveSecret("secret-store:file/jira-token")).To(Equal("new-token"))
+
+		Expect(run(newUnsetCmd(), "jira-token")).To(Succeed())
+		Expect(readConfig()).NotTo(HaveKey("jira-token"))
+		_, err := config.ResolveSecret("secret-store:file/jira-token")
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should keep the previous secret when the new one cannot be stored", func() {
+		writeConfig(`{"proxy-url": "http://proxy.example.com:3128"}`)
+		Expect(run(newSetCmd(), "jira-token", "old-token")).To(Succeed())
+
+		keyring.MockInitWithError(errors.New("keyring locked"))
+		GinkgoT().Setenv("BACKPLANE_SECRET_STORE", "keyring")
+		Expect(run(newSetCmd(), "jira-token", "new-token")).NotTo(Succeed())
+
+		Expect(readConfig()["jira-token"]).To(Equal("secret-store:file/jira-token"))
+		Expect(config.ResolveSecret("secret-store:file/jira-token")).To(Equal("old-token"))
+	})
+
+	It("should move the plain text secrets to the secret store", func() {
+		writeConfig(`{
+			"proxy-url": "http://proxy.example.com:3128",
+			"pd-key": "plain-pd-key",
+			"jira-token": "secret-store:file/jira-token",
+			"profiles": {"stage": {"jira-token": "plain-stage-token", "ocm-env": "staging"}}
+		}`)
+
+		Expect(run(newMigrateSecretsCmd())).To(Succeed())
+
+		cfg := readConfig()
+		Expect(cfg["pd-key"]).To(Equal("secret-store:file/pd-key"))
+		Expect(cfg["jira-token"]).To(Equal("secret-store:file/jira-token"))
+		Expect(cfg["profiles"]).To(Equal(map[string]interface{}{
+			"stage": map[string]interface{}{"jira-token": "secret-store:file/stage/jira-token", "ocm-env": "staging"},
+		}))
+		Expect(config.ResolveSecret("secret-store:file/pd-key")).To(Equal("plain-pd-key"))
+		Expect(config.ResolveSecret("secret-store:file/stage/jira-token")).To(Equal("plain-stage-token"))
+
+		content, err := os.ReadFile(configFile)
+		Expect(err).To(BeNil())
+		Expect(string(content)).NotTo(ContainSubstring("plain-"))
+	})
+
+	It("should reject values not of the type of the variable", func() {
+		writeConfig(`{}`)
+
+		err := run(newSetCmd(), "session-duration-minutes", "30m")
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring("invalid value for session-duration-minutes"))
+
+		err = run(newSetCmd(), "proxy-check-endpoint", "not a url")
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring("invalid value for proxy-check-endpoint"))
+		Expect(readConfig()).To(BeEmpty())
+	})
+
+	It("should fail to validate a config file with errors", func() {
+		writeConfig(`{"proxy-url": "http://proxy.example.com:3128", "govcloud": "false", "unknown-key": 1}`)
+
+		err := run(newValidateCmd())
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(Equal("1 error(s) found in the config file " + configFile))
+	})
+
+	It("should validate the config file once edited", func() {
+		originalRunEditor := runEditor
+		defer func() { runEditor = originalRunEditor }()
+		GinkgoT().Setenv("VISUAL", "")
+		GinkgoT().Setenv("EDITOR", "nano -w")
+
+		var usedEditor string
+		runEditor = func(editor string, path string) error {
+			usedEditor = editor
+			return os.WriteFile(path, []byte(`{"proxy-url": "http://proxy.example.com:3128", "govcloud": true}`), 0600)
+		}
+
+		Expect(run(newEditCmd())).To(Succeed())
+		Expect(usedEditor).To(Equal("nano -w"))
+		Expect(readConfig()["govcloud"]).To(Equal(true))
+	})
+})
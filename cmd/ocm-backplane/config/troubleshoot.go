@@ -26,7 +26,11 @@ func newTroubleshootCmd() *cobra.Command {
 		Use:   "troubleshoot",
 		Short: "Show the debug info of backplane",
 		Long: `It prints the debug info for troubleshooting backplane issues.
+
+Deprecated: the diagnostics bundle of 'ocm-backplane diagnose --bundle' holds this info along with the health checks,
+the kubeconfig contexts and the debug logs of the diagnose run only.
 `,
+		Deprecated:   "use 'ocm-backplane diagnose --bundle' to collect the diagnostics to attach to a support request",
 		Args:         cobra.ExactArgs(0),
 		SilenceUsage: true,
 		RunE:         ops.run,
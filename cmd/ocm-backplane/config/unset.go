@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+
+	logger "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/backplane-cli/pkg/cli/config"
+)
+
+func newUnsetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unset <key>",
+		Short: "Remove Backplane CLI configuration variables",
+		Long: `Remove a variable from the config file, its default value is used instead.
+Unknown and deprecated variables reported by 'ocm backplane config validate' can be removed too.
+With --profile, the variable is removed from the profile.`,
+		Example:      "ocm backplane config unset url\nocm backplane --profile stage config unset pd-key",
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(1),
+		RunE:         unsetConfig,
+	}
+	return cmd
+}
+
+func unsetConfig(cmd *cobra.Command, args []string) error {
+	configFile, err := config.ReadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	profile := config.ProfileFlag()
+	previous, _ := configFile.Get(profile, args[0])
+	if !configFile.Unset(profile, args[0]) {
+		fmt.Printf("%s is not set in the configuration file %s\n", args[0], configFile.Path)
+		return nil
+	}
+	if err := configFile.Write(); err != nil {
+		return err
+	}
+	// the secret the variable referenced is not needed anymore
+	if ref, ok := previous.(string); ok && config.IsSecretRef(ref) {
+		if err := config.DeleteSecret(ref); err != nil {
+			logger.Warn(err)
+		}
+	}
+	fmt.Println("Configuration file updated at " + configFile.Path)
+	return nil
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/backplane-cli/pkg/cli/config"
+)
+
+func newValidateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate the Backplane CLI config file",
+		Long: `Validate the config file and its profiles: the values must be of the type of their variable,
+the current profile must exist and the configuration in use must hold the required variables.
+Unknown and deprecated variables are reported as warnings.`,
+		Example:      "ocm backplane config validate",
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE:         validateConfig,
+	}
+	return cmd
+}
+
+func validateConfig(cmd *cobra.Command, args []string) error {
+	configFile, err := config.ReadConfigFile()
+	if err != nil {
+		return err
+	}
+	return reportIssues(configFile)
+}
+
+// reportIssues prints the issues of the config file, it fails when some are errors
+func reportIssues(configFile *config.ConfigFile) error {
+	errors := 0
+	for _, issue := range configFile.Validate() {
+		if issue.Severity == config.SeverityError {
+			errors++
+			printWrong("%s\n", issue)
+		} else {
+			printNotice("%s\n", issue)
+		}
+	}
+	if errors > 0 {
+		return fmt.Errorf("%d error(s) found in the config file %s", errors, configFile.Path)
+	}
+	printCorrect("The config file %s is valid\n", configFile.Path)
+	return nil
+}
@@ -25,6 +25,7 @@ import (
 	"os/exec"
 	"os/signal"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"syscall"
@@ -43,10 +44,13 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
 
 	"github.com/openshift/backplane-cli/pkg/cli/config"
 	"github.com/openshift/backplane-cli/pkg/container"
 	"github.com/openshift/backplane-cli/pkg/info"
+	"github.com/openshift/backplane-cli/pkg/login"
 	"github.com/openshift/backplane-cli/pkg/ocm"
 	"github.com/openshift/backplane-cli/pkg/utils"
 )
@@ -67,6 +71,10 @@ type consoleOptions struct {
 	needMonitorPlugin   bool
 	monitorPluginPort   string
 	monitorPluginImage  string
+	cluster             string
+	clusterID           string
+	clusterName         string
+	kubeconfig          *rest.Config
 	terminationFunction execActionOnTermInterface
 }
 
@@ -135,6 +143,8 @@ func NewConsoleCmd() *cobra.Command {
 		Clusters below 4.8 will not display metrics, alerts, or dashboards. If you need to view metrics, alerts, or dashboards use the latest console image
 		with --image=quay.io/openshift/origin-console .
 		You can specify container engine with -c. If not specified, it will lookup the PATH in the order of podman and docker.
+		You can launch the console of any logged in cluster with --cluster, each console runs in its own container and port.
+		The active consoles are listed in an index page under the backplane configuration directory.
 `,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -183,6 +193,12 @@ func NewConsoleCmd() *cobra.Command {
 		"",
 		"The full console url, e.g. from PagerDuty. The hostname will be replaced with that of the locally running console.",
 	)
+	flags.StringVar(
+		&ops.cluster,
+		"cluster",
+		"",
+		"Cluster ID, external ID or name of a logged in cluster to launch the console for. Default: The cluster of the current kubeconfig context",
+	)
 
 	return consoleCmd
 }
@@ -200,7 +216,11 @@ func (o *consoleOptions) run() error {
 	if err != nil {
 		return err
 	}
-	kubeconfig, err := getCurrentKubeconfig()
+	err = o.determineCluster()
+	if err != nil {
+		return err
+	}
+	kubeconfig, err := o.getKubeconfig()
 	if err != nil {
 		return err
 	}
@@ -264,6 +284,8 @@ func (o *consoleOptions) runContainers(ce container.ContainerEngine, errs chan<-
 	if err := o.printURL(); err != nil {
 		errs <- err
 	}
+
+	o.registerActiveConsole(ce)
 }
 
 // Parse environment variables
@@ -383,7 +405,7 @@ func (o *consoleOptions) pullConsoleImage(ce container.ContainerEngine) error {
 }
 
 func (o *consoleOptions) determineNeedMonitorPlugin() error {
-	if isRunningHigherOrEqualTo(versionForMonitoringPlugin) {
+	if o.isRunningHigherOrEqualTo(versionForMonitoringPlugin) {
 		logger.Debugln("monitoring plugin is needed")
 		o.needMonitorPlugin = true
 		return nil
@@ -401,7 +423,7 @@ func (o *consoleOptions) determineMonitorPluginPort() error {
 	}
 
 	// We use a default port for the plugin which doesn't need Nginx
-	if isRunningHigherOrEqualTo(versionForMonitoringPluginWithoutNginx) {
+	if o.isRunningHigherOrEqualTo(versionForMonitoringPluginWithoutNginx) {
 		o.monitorPluginPort = DefaultMonitoringPluginPort
 		logger.Debugf("monitoring plugin does not require Nginx, assign a default port %s", o.monitorPluginPort)
 		return nil
@@ -454,11 +476,11 @@ func (o *consoleOptions) getPlugins() (string, error) {
 
 	// user enabled plugins
 	if o.enablePlugins {
-		config, err := getCurrentKubeconfig()
+		config, err := o.getKubeconfig()
 		if err != nil {
 			return "", err
 		}
-		if isRunningHigherOrEqualTo(versionForConsolePluginsBackendService) {
+		if o.isRunningHigherOrEqualTo(versionForConsolePluginsBackendService) {
 			consolePlugins, err = getConsolePluginFromCluster(config)
 			if err != nil {
 				return "", err
@@ -481,23 +503,25 @@ func (o *consoleOptions) getPlugins() (string, error) {
 }
 
 func (o *consoleOptions) runConsoleContainer(ce container.ContainerEngine) error {
-	clusterID, err := getClusterID()
+	clusterID, err := o.getClusterID()
 	if err != nil {
 		return err
 	}
-	consoleContainerName := fmt.Sprintf("console-%s", clusterID)
 
 	c, err := ocm.DefaultOCMInterface.GetClusterInfoByID(clusterID)
 	if err != nil {
 		return err
 	}
+	if o.clusterName == "" && c != nil {
+		o.clusterName = c.Name()
+	}
 	brandConfig, err := getBrandingConfig(c)
 	if err != nil {
 		logger.Warnf("%s - defaulting to OSD Brand", err.Error())
 	}
 
-	// Get the RESTconfig from the current kubeconfig context.
-	config, err := getCurrentKubeconfig()
+	// Get the RESTconfig of the target cluster.
+	config, err := o.getKubeconfig()
 	if err != nil {
 		return err
 	}
@@ -559,7 +583,7 @@ func (o *consoleOptions) runConsoleContainer(ce container.ContainerEngine) error
 		"-listen", bridgeListen,
 	}
 
-	return ce.RunConsoleContainer(consoleContainerName, o.port, containerArgs, envVars)
+	return ce.RunConsoleContainer(consoleContainerName(clusterID), o.port, containerArgs, envVars)
 }
 
 func (o *consoleOptions) runMonitorPlugin(ce container.ContainerEngine) error {
@@ -568,13 +592,11 @@ func (o *consoleOptions) runMonitorPlugin(ce container.ContainerEngine) error {
 		return nil
 	}
 
-	clusterID, err := getClusterID()
+	clusterID, err := o.getClusterID()
 	if err != nil {
 		return err
 	}
 
-	consoleContainerName := fmt.Sprintf("console-%s", clusterID)
-	pluginContainerName := fmt.Sprintf("monitoring-plugin-%s", clusterID)
 	pluginArgs := []string{o.monitorPluginImage}
 
 	var envVars []container.EnvVar
@@ -588,12 +610,12 @@ func (o *consoleOptions) runMonitorPlugin(ce container.ContainerEngine) error {
 		return err
 	}
 
-	if isRunningHigherOrEqualTo(versionForMonitoringPluginWithoutNginx) {
+	if o.isRunningHigherOrEqualTo(versionForMonitoringPluginWithoutNginx) {
 		logger.Debugln("monitoring plugin does not require nginx, passing an environment variable to specify the port")
 		envVars = append(envVars, container.EnvVar{Key: "PORT", Value: o.monitorPluginPort})
 	}
 
-	return ce.RunMonitorPlugin(pluginContainerName, consoleContainerName, nginxFilename, pluginArgs, envVars)
+	return ce.RunMonitorPlugin(monitorPluginContainerName(clusterID), consoleContainerName(clusterID), nginxFilename, pluginArgs, envVars)
 }
 
 // print the console URL and pop a browser if required
@@ -625,13 +647,13 @@ func (o *consoleOptions) printURL() error {
 }
 
 func (o *consoleOptions) beforeStartCleanUp(ce container.ContainerEngine) error {
-	clusterID, err := getClusterID()
+	clusterID, err := o.getClusterID()
 	if err != nil {
 		return fmt.Errorf("error getting cluster ID: %v", err)
 	}
 	containersToCleanUp := []string{
-		fmt.Sprintf("monitoring-plugin-%s", clusterID),
-		fmt.Sprintf("console-%s", clusterID),
+		monitorPluginContainerName(clusterID),
+		consoleContainerName(clusterID),
 	}
 
 	logger.Infoln("Starting initial cleanup of containers")
@@ -656,7 +678,7 @@ func (o *consoleOptions) beforeStartCleanUp(ce container.ContainerEngine) error
 // cleanUp will first populate the containers needed to clean up, then call the blocking function
 // o.terminateFunction, which will block until a system signal is received.
 func (o *consoleOptions) cleanUp(ce container.ContainerEngine) error {
-	clusterID, err := getClusterID()
+	clusterID, err := o.getClusterID()
 	if err != nil {
 		return err
 	}
@@ -666,9 +688,9 @@ func (o *consoleOptions) cleanUp(ce container.ContainerEngine) error {
 	// forcing order of removal as the order is not deterministic between container engines
 	if o.needMonitorPlugin {
 		logger.Debugln("adding monitoring plugin to containers for cleanup")
-		containersToCleanUp = append(containersToCleanUp, fmt.Sprintf("monitoring-plugin-%s", clusterID))
+		containersToCleanUp = append(containersToCleanUp, monitorPluginContainerName(clusterID))
 	}
-	containersToCleanUp = append(containersToCleanUp, fmt.Sprintf("console-%s", clusterID))
+	containersToCleanUp = append(containersToCleanUp, consoleContainerName(clusterID))
 
 	// If for whatever reason the user did not call the proper function to create a console option
 	// And the Cleanup method is called without a termination function defined
@@ -692,12 +714,64 @@ func (o *consoleOptions) cleanUp(ce container.ContainerEngine) error {
 			}
 
 		}
+		unregisterActiveConsole(clusterID)
 		return nil
 	})
 
 	return err
 }
 
+// determineCluster resolves the target cluster and its kubeconfig.
+// Without --cluster, the cluster of the current kubeconfig context is used.
+func (o *consoleOptions) determineCluster() error {
+	if len(o.cluster) == 0 {
+		clusterID, err := o.getClusterID()
+		if err != nil {
+			return err
+		}
+		logger.Debugf("using cluster %s from the current kubeconfig context\n", clusterID)
+		return nil
+	}
+
+	clusterID, clusterName, err := ocm.DefaultOCMInterface.GetTargetCluster(o.cluster)
+	if err != nil {
+		return err
+	}
+	kubeconfig, err := getClusterKubeconfig(clusterID)
+	if err != nil {
+		return err
+	}
+	o.clusterID = clusterID
+	o.clusterName = clusterName
+	o.kubeconfig = kubeconfig
+	logger.Debugf("using cluster %s (%s)\n", clusterName, clusterID)
+	return nil
+}
+
+// getClusterID returns the ID of the target cluster, defaulting to the cluster in current kubeconfig
+func (o *consoleOptions) getClusterID() (string, error) {
+	if len(o.clusterID) == 0 {
+		clusterID, err := getClusterID()
+		if err != nil {
+			return "", err
+		}
+		o.clusterID = clusterID
+	}
+	return o.clusterID, nil
+}
+
+// getKubeconfig returns the RESTconfig of the target cluster, defaulting to the current kubeconfig context
+func (o *consoleOptions) getKubeconfig() (*rest.Config, error) {
+	if o.kubeconfig == nil {
+		kubeconfig, err := getCurrentKubeconfig()
+		if err != nil {
+			return nil, err
+		}
+		o.kubeconfig = kubeconfig
+	}
+	return o.kubeconfig, nil
+}
+
 // getClusterID returns the current cluster id in current kubeconfig
 func getClusterID() (string, error) {
 	currentClusterInfo, err := utils.DefaultClusterUtils.GetBackplaneClusterFromConfig()
@@ -707,6 +781,78 @@ func getClusterID() (string, error) {
 	return currentClusterInfo.ClusterID, nil
 }
 
+// getClusterKubeconfig looks up a backplane context of the given cluster, first in the
+// kubeconfig written by a multi-cluster login, then in the default kubeconfig.
+// The current context is preferred when it points to the cluster.
+func getClusterKubeconfig(clusterID string) (*rest.Config, error) {
+	var kubeconfigPaths []string
+	if path, err := login.GetClusterKubeConfigPath(clusterID); err == nil {
+		kubeconfigPaths = append(kubeconfigPaths, path)
+	}
+	kubeconfigPaths = append(kubeconfigPaths, clientcmd.NewDefaultPathOptions().GetDefaultFilename())
+
+	for _, path := range kubeconfigPaths {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		rawConfig, err := clientcmd.LoadFromFile(path)
+		if err != nil {
+			logger.Debugf("failed to load kubeconfig %s: %v\n", path, err)
+			continue
+		}
+		contextName := findClusterContext(rawConfig, clusterID)
+		if contextName == "" {
+			continue
+		}
+		logger.Debugf("using context %s from kubeconfig %s\n", contextName, path)
+		return clientcmd.NewNonInteractiveClientConfig(*rawConfig, contextName, &clientcmd.ConfigOverrides{}, nil).ClientConfig()
+	}
+
+	return nil, fmt.Errorf("cannot find a kubeconfig context for cluster %s, please login to the cluster using backplane first", clusterID)
+}
+
+// findClusterContext returns the name of a context pointing to the backplane url of the given cluster
+func findClusterContext(rawConfig *api.Config, clusterID string) string {
+	matches := func(contextName string) bool {
+		ctx, ok := rawConfig.Contexts[contextName]
+		if !ok {
+			return false
+		}
+		cluster, ok := rawConfig.Clusters[ctx.Cluster]
+		if !ok {
+			return false
+		}
+		id, _, err := utils.DefaultClusterUtils.GetClusterIDAndHostFromClusterURL(cluster.Server)
+		return err == nil && id == clusterID
+	}
+
+	if matches(rawConfig.CurrentContext) {
+		return rawConfig.CurrentContext
+	}
+	// iterate in a stable order
+	contextNames := make([]string, 0, len(rawConfig.Contexts))
+	for name := range rawConfig.Contexts {
+		contextNames = append(contextNames, name)
+	}
+	sort.Strings(contextNames)
+	for _, name := range contextNames {
+		if matches(name) {
+			return name
+		}
+	}
+	return ""
+}
+
+// consoleContainerName returns the console container name of the given cluster
+func consoleContainerName(clusterID string) string {
+	return fmt.Sprintf("console-%s", clusterID)
+}
+
+// monitorPluginContainerName returns the monitoring plugin container name of the given cluster
+func monitorPluginContainerName(clusterID string) string {
+	return fmt.Sprintf("monitoring-plugin-%s", clusterID)
+}
+
 // getProxyURL returns the proxy url
 func getProxyURL() (proxyURL *string, err error) {
 	bpConfig, err := config.GetBackplaneConfiguration()
@@ -844,18 +990,18 @@ func isConsolePluginEnabled(config *rest.Config, consolePlugin string) (bool, er
 	return false, nil
 }
 
-// isRunningHigherOrEqualTo check if the cluster is running higher or equal to target version
-func isRunningHigherOrEqualTo(targetVersionStr string) bool {
+// isRunningHigherOrEqualTo check if the target cluster is running higher or equal to target version
+func (o *consoleOptions) isRunningHigherOrEqualTo(targetVersionStr string) bool {
 	var (
 		clusterVersion *semver.Version
 		targetVersion  *semver.Version
 	)
 
-	currentClusterInfo, err := utils.DefaultClusterUtils.GetBackplaneClusterFromConfig()
+	clusterID, err := o.getClusterID()
 	if err != nil {
 		return false
 	}
-	currentCluster, err := ocm.DefaultOCMInterface.GetClusterInfoByID(currentClusterInfo.ClusterID)
+	currentCluster, err := ocm.DefaultOCMInterface.GetClusterInfoByID(clusterID)
 
 	if err != nil {
 		return false
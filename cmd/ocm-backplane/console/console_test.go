@@ -3,6 +3,7 @@ package console
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -96,6 +97,7 @@ var _ = Describe("console command", func() {
 
 	AfterEach(func() {
 		_ = os.Setenv("HTTPS_PROXY", "")
+		_ = os.Unsetenv(info.BackplaneConfigPathEnvName)
 		mockCtrl.Finish()
 		utils.RemoveTempKubeConfig()
 	})
@@ -104,6 +106,9 @@ var _ = Describe("console command", func() {
 		err := os.Setenv(info.BackplaneProxyEnvName, proxyURL)
 		Expect(err).To(BeNil())
 
+		err = os.Setenv(info.BackplaneConfigPathEnvName, filepath.Join(GinkgoT().TempDir(), "config.json"))
+		Expect(err).To(BeNil())
+
 		err = utils.CreateTempKubeConfig(&testKubeCfg)
 		Expect(err).To(BeNil())
 	}
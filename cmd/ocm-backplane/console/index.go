@@ -0,0 +1,225 @@
+package console
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/openshift/backplane-cli/pkg/cli/config"
+	"github.com/openshift/backplane-cli/pkg/container"
+)
+
+const (
+	// The directory under the backplane config directory holding the active consoles
+	activeConsolesDirectory = "consoles"
+
+	// The index page listing the active consoles
+	activeConsolesIndexFilename = "index.html"
+)
+
+// activeConsole describes a locally running console
+type activeConsole struct {
+	ClusterID     string    `json:"cluster_id"`
+	ClusterName   string    `json:"cluster_name"`
+	URL           string    `json:"url"`
+	Port          string    `json:"port"`
+	ContainerName string    `json:"container_name"`
+	StartedAt     time.Time `json:"started_at"`
+}
+
+var activeConsolesIndexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Backplane consoles</title>
+</head>
+<body>
+<h1>Backplane consoles</h1>
+{{- if . }}
+<table>
+<tr><th>Cluster</th><th>Cluster ID</th><th>Console</th><th>Started</th></tr>
+{{- range . }}
+<tr><td>{{ .ClusterName }}</td><td>{{ .ClusterID }}</td><td><a href="{{ .URL }}">{{ .URL }}</a></td><td>{{ .StartedAt.Format "2006-01-02 15:04:05" }}</td></tr>
+{{- end }}
+</table>
+{{- else }}
+<p>No active consoles.</p>
+{{- end }}
+</body>
+</html>
+`))
+
+// getActiveConsolesDirectory returns the directory holding the active consoles
+func getActiveConsolesDirectory() (string, error) {
+	configDirectory, err := config.GetConfigDirectory()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDirectory, activeConsolesDirectory), nil
+}
+
+// registerActiveConsole records the running console and refreshes the index page.
+// Failures are only reported as the console itself is up and running.
+func (o *consoleOptions) registerActiveConsole(ce container.ContainerEngine) {
+	clusterID, err := o.getClusterID()
+	if err != nil {
+		logger.Warnf("failed to register the console: %v", err)
+		return
+	}
+
+	pruneActiveConsoles(ce)
+
+	consoleURL, err := replaceConsoleURL(fmt.Sprintf("http://127.0.0.1:%s", o.port), o.url)
+	if err != nil {
+		logger.Warnf("failed to register the console: %v", err)
+		return
+	}
+	c := activeConsole{
+		ClusterID:     clusterID,
+		ClusterName:   o.clusterName,
+		URL:           consoleURL,
+		Port:          o.port,
+		ContainerName: consoleContainerName(clusterID),
+		StartedAt:     time.Now(),
+	}
+	if err := saveActiveConsole(c); err != nil {
+		logger.Warnf("failed to register the console: %v", err)
+		return
+	}
+
+	indexPath, err := writeActiveConsolesIndex()
+	if err != nil {
+		logger.Warnf("failed to write the console index page: %v", err)
+		return
+	}
+	fmt.Printf("== All active consoles are listed at file://%s ==\n\n", indexPath)
+}
+
+// unregisterActiveConsole removes the console of the given cluster and refreshes the index page
+func unregisterActiveConsole(clusterID string) {
+	dir, err := getActiveConsolesDirectory()
+	if err != nil {
+		logger.Warnf("failed to unregister the console: %v", err)
+		return
+	}
+	err = os.Remove(filepath.Join(dir, clusterID+".json"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.Warnf("failed to unregister the console: %v", err)
+		return
+	}
+	if _, err := writeActiveConsolesIndex(); err != nil {
+		logger.Warnf("failed to write the console index page: %v", err)
+	}
+}
+
+// pruneActiveConsoles removes the records of consoles whose container is gone,
+// e.g. when a previous console process was killed before cleaning up
+func pruneActiveConsoles(ce container.ContainerEngine) {
+	consoles, err := listActiveConsoles()
+	if err != nil {
+		logger.Debugf("failed to list active consoles: %v", err)
+		return
+	}
+	dir, err := getActiveConsolesDirectory()
+	if err != nil {
+		return
+	}
+	for _, c := range consoles {
+		exist, err := ce.ContainerIsExist(c.ContainerName)
+		if err != nil || exist {
+			continue
+		}
+		logger.Debugf("removing stale console record of cluster %s", c.ClusterID)
+		_ = os.Remove(filepath.Join(dir, c.ClusterID+".json"))
+	}
+}
+
+// saveActiveConsole writes the console record to the active consoles directory
+func saveActiveConsole(c activeConsole) error {
+	dir, err := getActiveConsolesDirectory()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, c.ClusterID+".json"), content, 0600)
+}
+
+// listActiveConsoles returns the recorded consoles sorted by cluster name
+func listActiveConsoles() ([]activeConsole, error) {
+	dir, err := getActiveConsolesDirectory()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var consoles []activeConsole
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name())) //#nosec G304
+		if err != nil {
+			return nil, err
+		}
+		var c activeConsole
+		if err := json.Unmarshal(content, &c); err != nil {
+			logger.Debugf("skipping malformed console record %s: %v", entry.Name(), err)
+			continue
+		}
+		consoles = append(consoles, c)
+	}
+	sort.Slice(consoles, func(i, j int) bool {
+		if consoles[i].ClusterName == consoles[j].ClusterName {
+			return consoles[i].ClusterID < consoles[j].ClusterID
+		}
+		return consoles[i].ClusterName < consoles[j].ClusterName
+	})
+	return consoles, nil
+}
+
+// writeActiveConsolesIndex renders the index page of active consoles and returns its path
+func writeActiveConsolesIndex() (string, error) {
+	consoles, err := listActiveConsoles()
+	if err != nil {
+		return "", err
+	}
+	dir, err := getActiveConsolesDirectory()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return "", err
+	}
+
+	indexPath := filepath.Join(dir, activeConsolesIndexFilename)
+	f, err := os.Create(indexPath) //#nosec G304
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	if err := activeConsolesIndexTemplate.Execute(f, consoles); err != nil {
+		return "", err
+	}
+	return indexPath, nil
+}
@@ -0,0 +1,149 @@
+package console
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	ceMock "github.com/openshift/backplane-cli/pkg/container/mocks"
+	"github.com/openshift/backplane-cli/pkg/info"
+	"github.com/openshift/backplane-cli/pkg/login"
+	"github.com/openshift/backplane-cli/pkg/ocm"
+	ocmMock "github.com/openshift/backplane-cli/pkg/ocm/mocks"
+	"github.com/openshift/backplane-cli/pkg/utils"
+)
+
+var _ = Describe("multi-cluster consoles", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockOcmInterface *ocmMock.MockOCMInterface
+		mockEngine       *ceMock.MockContainerEngine
+		tempDir          string
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockOcmInterface = ocmMock.NewMockOCMInterface(mockCtrl)
+		mockEngine = ceMock.NewMockContainerEngine(mockCtrl)
+		ocm.DefaultOCMInterface = mockOcmInterface
+
+		tempDir = GinkgoT().TempDir()
+		_ = os.Setenv(info.BackplaneConfigPathEnvName, filepath.Join(tempDir, "config.json"))
+	})
+
+	AfterEach(func() {
+		_ = os.Unsetenv(info.BackplaneConfigPathEnvName)
+		mockCtrl.Finish()
+		utils.RemoveTempKubeConfig()
+	})
+
+	Context("index of active consoles", func() {
+		It("should list registered consoles in the index page", func() {
+			mockEngine.EXPECT().ContainerIsExist(gomock.Any()).Return(true, nil).AnyTimes()
+
+			o := newConsoleOptions()
+			o.clusterID = "hc123"
+			o.clusterName = "hosted-cluster"
+			o.port = "1337"
+			o.registerActiveConsole(mockEngine)
+
+			o = newConsoleOptions()
+			o.clusterID = "mc123"
+			o.clusterName = "management-cluster"
+			o.port = "1338"
+			o.registerActiveConsole(mockEngine)
+
+			consoles, err := listActiveConsoles()
+			Expect(err).To(BeNil())
+			Expect(consoles).To(HaveLen(2))
+			Expect(consoles[0].ContainerName).To(Equal("console-hc123"))
+			Expect(consoles[1].ContainerName).To(Equal("console-mc123"))
+
+			index, err := os.ReadFile(filepath.Join(tempDir, activeConsolesDirectory, activeConsolesIndexFilename))
+			Expect(err).To(BeNil())
+			Expect(string(index)).To(ContainSubstring("http://127.0.0.1:1337"))
+			Expect(string(index)).To(ContainSubstring("http://127.0.0.1:1338"))
+
+			unregisterActiveConsole("hc123")
+			consoles, err = listActiveConsoles()
+			Expect(err).To(BeNil())
+			Expect(consoles).To(HaveLen(1))
+			Expect(consoles[0].ClusterID).To(Equal("mc123"))
+
+			index, err = os.ReadFile(filepath.Join(tempDir, activeConsolesDirectory, activeConsolesIndexFilename))
+			Expect(err).To(BeNil())
+			Expect(string(index)).NotTo(ContainSubstring("http://127.0.0.1:1337"))
+		})
+
+		It("should prune consoles whose container is gone", func() {
+			Expect(saveActiveConsole(activeConsole{ClusterID: "stale123", ContainerName: "console-stale123"})).To(Succeed())
+			mockEngine.EXPECT().ContainerIsExist("console-stale123").Return(false, nil).Times(1)
+
+			pruneActiveConsoles(mockEngine)
+
+			consoles, err := listActiveConsoles()
+			Expect(err).To(BeNil())
+			Expect(consoles).To(BeEmpty())
+		})
+	})
+
+	Context("console for a given cluster", func() {
+		It("should use the kubeconfig written by a multi-cluster login", func() {
+			Expect(login.SetKubeConfigBasePath(tempDir)).To(Succeed())
+			_, err := login.CreateClusterKubeConfig("mc123", api.Config{
+				Clusters: map[string]*api.Cluster{
+					"mc": {Server: "https://api-backplane.apps.something.com/backplane/cluster/mc123"},
+				},
+				AuthInfos:      map[string]*api.AuthInfo{"user": {Token: "token123"}},
+				Contexts:       map[string]*api.Context{"default/mc/user": {Cluster: "mc", AuthInfo: "user"}},
+				CurrentContext: "default/mc/user",
+			})
+			Expect(err).To(BeNil())
+			mockOcmInterface.EXPECT().GetTargetCluster("mc").Return("mc123", "mc", nil).Times(1)
+
+			o := newConsoleOptions()
+			o.cluster = "mc"
+			Expect(o.determineCluster()).To(Succeed())
+			Expect(o.clusterID).To(Equal("mc123"))
+			Expect(o.kubeconfig.Host).To(Equal("https://api-backplane.apps.something.com/backplane/cluster/mc123"))
+		})
+
+		It("should find a non-current context of the cluster in the default kubeconfig", func() {
+			Expect(login.SetKubeConfigBasePath(tempDir)).To(Succeed())
+			err := utils.CreateTempKubeConfig(&api.Config{
+				Clusters: map[string]*api.Cluster{
+					"hc": {Server: "https://api-backplane.apps.something.com/backplane/cluster/hc123"},
+					"mc": {Server: "https://api-backplane.apps.something.com/backplane/cluster/mc123"},
+				},
+				AuthInfos: map[string]*api.AuthInfo{"user": {Token: "token123"}},
+				Contexts: map[string]*api.Context{
+					"default/hc/user": {Cluster: "hc", AuthInfo: "user"},
+					"default/mc/user": {Cluster: "mc", AuthInfo: "user"},
+				},
+				CurrentContext: "default/hc/user",
+			})
+			Expect(err).To(BeNil())
+
+			config, err := getClusterKubeconfig("mc123")
+			Expect(err).To(BeNil())
+			Expect(config.Host).To(Equal("https://api-backplane.apps.something.com/backplane/cluster/mc123"))
+		})
+
+		It("should fail when the cluster is not logged in", func() {
+			Expect(login.SetKubeConfigBasePath(tempDir)).To(Succeed())
+			err := utils.CreateTempKubeConfig(nil)
+			Expect(err).To(BeNil())
+			rawConfig, err := clientcmd.LoadFromFile(os.Getenv(info.BackplaneKubeconfigEnvName))
+			Expect(err).To(BeNil())
+			Expect(findClusterContext(rawConfig, "unknown123")).To(BeEmpty())
+
+			_, err = getClusterKubeconfig("unknown123")
+			Expect(err).To(MatchError(ContainSubstring("please login to the cluster using backplane first")))
+		})
+	})
+})
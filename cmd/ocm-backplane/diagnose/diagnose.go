@@ -0,0 +1,86 @@
+package diagnose
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/backplane-cli/pkg/diagnose"
+)
+
+var diagnoseArgs struct {
+	bundle string
+}
+
+// NewDiagnoseCmd returns the command collecting the diagnostics bundle
+func NewDiagnoseCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diagnose",
+		Short: "Collect the diagnostics of backplane-cli in a bundle to attach to a support request",
+		Long: `Collect the diagnostics of backplane-cli in a gzipped tarball to attach to a support request.
+
+The bundle holds the version of backplane-cli, its config file and effective configuration, the OCM environment,
+the kubeconfig contexts of the clusters logged into through backplane, the container engine info, the health check
+results and the debug logs of this diagnose run in diagnose-run.log. Tokens, passwords and other secrets are redacted.
+
+The earlier commands are not logged to disk: run the failing command again with --verbosity=debug
+and attach its output along with the bundle.
+
+The items which cannot be collected are listed in the metadata.json file of the bundle.`,
+		Example:      " backplane diagnose\n backplane diagnose --bundle /tmp/diagnostics.tar.gz",
+		Args:         cobra.NoArgs,
+		RunE:         runDiagnose,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(
+		&diagnoseArgs.bundle,
+		"bundle",
+		"",
+		"The path of the bundle to write, backplane-diagnostics-<timestamp>.tar.gz by default.",
+	)
+
+	return cmd
+}
+
+// runDiagnose collects the diagnostics and writes their bundle
+func runDiagnose(cmd *cobra.Command, argv []string) error {
+	fmt.Println("Collecting the diagnostics of backplane-cli")
+	bundle := diagnose.Collect(cmd.Context())
+
+	path := diagnoseArgs.bundle
+	if path == "" {
+		path = fmt.Sprintf("backplane-diagnostics-%s.tar.gz", bundle.CreatedAt.Format("20060102T150405Z"))
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create the bundle file: %v", err)
+	}
+
+	err = bundle.WriteArchive(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// do not leave a truncated bundle behind
+		_ = os.Remove(path)
+		return fmt.Errorf("failed to write the bundle: %v", err)
+	}
+
+	for _, name := range bundle.Names() {
+		fmt.Printf("[V] %s\n", name)
+	}
+	failed := make([]string, 0, len(bundle.Errors))
+	for name := range bundle.Errors {
+		failed = append(failed, name)
+	}
+	sort.Strings(failed)
+	for _, name := range failed {
+		fmt.Printf("[X] %s: %s\n", name, bundle.Errors[name])
+	}
+	fmt.Printf("Bundle written to %s at %s, please review it before attaching it to the support request\n", path, bundle.CreatedAt.Format(time.RFC3339))
+	return nil
+}
@@ -1,6 +1,9 @@
 package healthcheck
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/openshift/backplane-cli/pkg/healthcheck"
 	"github.com/spf13/cobra"
 )
@@ -8,19 +11,76 @@ import (
 var (
 	checkVPN   bool
 	checkProxy bool
+	checks     []string
+	cluster    string
+	output     string
 )
 
 // HealthCheckCmd is the command for performing health checks
 var HealthCheckCmd = &cobra.Command{
 	Use:     "healthcheck",
 	Aliases: []string{"healthCheck", "health-check", "healthchecks"},
-	Short:   "Check VPN and Proxy connectivity on the localhost",
-	Run: func(cmd *cobra.Command, args []string) {
-		healthcheck.RunHealthCheck(checkVPN, checkProxy)(cmd, args)
-	},
+	Short:   "Check the local environment backplane-cli relies on",
+	Long: fmt.Sprintf(`Check the VPN and proxy connectivity, the backplane API, the OCM token and the tools backplane-cli relies on.
+
+The checks run in parallel as soon as the checks they depend on pass, and are skipped otherwise.
+Every check runs even when some fail, the hints of the failed checks tell how to fix them.
+
+With --cluster, the checks of each hop of the path to the cluster run instead, along with the checks they depend on:
+the cluster is resolved through OCM, its hibernation and access protection are checked, the backplane API is asked
+for its proxy URL, which is tried through the configured proxy, and a SelfSubjectReview makes sure the cluster
+authenticates the OCM token.
+
+Available checks: %s.
+
+Exit code: 0 when every check passes, 1 when a check fails or is skipped.`, strings.Join(checkNames(), ", ")),
+	Example:      " backplane healthcheck\n backplane healthcheck --check backplane-api,ocm-token -o json\n backplane healthcheck --cluster <cluster-id>",
+	Args:         cobra.NoArgs,
+	RunE:         runHealthCheck,
+	SilenceUsage: true,
 }
 
 func init() {
 	HealthCheckCmd.Flags().BoolVar(&checkVPN, "vpn", false, "Check only VPN connectivity")
 	HealthCheckCmd.Flags().BoolVar(&checkProxy, "proxy", false, "Check only Proxy connectivity")
+	HealthCheckCmd.Flags().StringSliceVar(&checks, "check", nil, "Run only the given checks along with the checks they depend on. Can be repeated or comma separated.")
+	HealthCheckCmd.Flags().StringVar(&cluster, "cluster", "", "Check each hop of the path to the cluster, given by its ID, external ID or name")
+	HealthCheckCmd.Flags().StringVarP(&output, "output", "o", healthcheck.OutputTable,
+		fmt.Sprintf("Format the output of the checks. One of %s|%s", healthcheck.OutputTable, healthcheck.OutputJSON))
+}
+
+func runHealthCheck(cmd *cobra.Command, args []string) error {
+	if output != healthcheck.OutputTable && output != healthcheck.OutputJSON {
+		return fmt.Errorf("unknown output format %s, expected one of %s|%s", output, healthcheck.OutputTable, healthcheck.OutputJSON)
+	}
+
+	names := append([]string{}, checks...)
+	if checkVPN {
+		names = append(names, healthcheck.CheckVPN)
+	}
+	if checkProxy {
+		names = append(names, healthcheck.CheckProxy)
+	}
+
+	results, err := healthcheck.RunChecks(cmd.Context(), names, cluster)
+	if err != nil {
+		return err
+	}
+	if err := healthcheck.PrintResults(results, output); err != nil {
+		return err
+	}
+
+	if !healthcheck.Passed(results) {
+		return fmt.Errorf("some of the %d health checks did not pass", len(results))
+	}
+	return nil
+}
+
+// checkNames returns the names of the available checks
+func checkNames() []string {
+	names := []string{}
+	for _, check := range healthcheck.NewClusterRegistry("<cluster>").Checks() {
+		names = append(names, check.Name)
+	}
+	return names
 }
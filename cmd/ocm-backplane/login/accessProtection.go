@@ -0,0 +1,107 @@
+package login
+
+import (
+	"fmt"
+	"strings"
+
+	ocmsdk "github.com/openshift-online/ocm-sdk-go"
+	acctrspv1 "github.com/openshift-online/ocm-sdk-go/accesstransparency/v1"
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/openshift/backplane-cli/pkg/accessrequest"
+	"github.com/openshift/backplane-cli/pkg/jira"
+	"github.com/openshift/backplane-cli/pkg/ocm"
+	"github.com/openshift/backplane-cli/pkg/utils"
+)
+
+// checkAccessProtection makes sure the cluster can be logged into when its access protection is enabled,
+// which requires an access request approved by the customer.
+// When running interactively, it offers to create the missing access request and to wait for its approval.
+func checkAccessProtection(clusterID, elevateReason string) error {
+	ocmConnection, err := ocm.DefaultOCMInterface.SetupOCMConnection()
+	if err != nil {
+		return fmt.Errorf("failed to create OCM connection: %v", err)
+	}
+
+	// Don't block the login when the access protection cannot be checked, backplane-api has the final say anyway
+	isEnabled, err := ocm.DefaultOCMInterface.IsClusterAccessProtectionEnabled(ocmConnection, clusterID)
+	if err != nil {
+		logger.Warnf("unable to determine if access protection is enabled or not for cluster '%s': %v", clusterID, err)
+		return nil
+	}
+	if !isEnabled {
+		return nil
+	}
+
+	accessRequest, err := ocm.DefaultOCMInterface.GetClusterActiveAccessRequest(ocmConnection, clusterID)
+	if err != nil {
+		logger.Warnf("unable to retrieve the active access request of access protected cluster '%s': %v", clusterID, err)
+		return nil
+	}
+
+	if accessRequest == nil {
+		fmt.Printf("Access protection is enabled for cluster '%s': an access request approved by the customer is needed to log in.\n", clusterID)
+		accessRequest, err = offerAccessRequestCreation(ocmConnection, clusterID, elevateReason)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch accessrequest.GetAccessRequestState(accessRequest) {
+	case acctrspv1.AccessRequestStateApproved:
+		logger.Infof("Access protection is enabled for cluster '%s', using access request '%s' approved until %s",
+			clusterID, accessRequest.ID(), accessRequest.Status().ExpiresAt())
+		return nil
+	case acctrspv1.AccessRequestStatePending:
+		accessrequest.PrintAccessRequest(clusterID, accessRequest)
+		if !args.waitAccessRequest && !askYesNo("Do you want to wait for its approval by the customer (Y/n)? ") {
+			return fmt.Errorf("access request '%s' of cluster '%s' is pending approval by the customer, run 'ocm-backplane accessrequest wait --cluster-id %s' to wait for its approval and log in",
+				accessRequest.ID(), clusterID, clusterID)
+		}
+		_, err = accessrequest.WaitForAccessRequest(ocmConnection, clusterID, accessRequest.ID(), accessrequest.DefaultWaitInterval, args.waitAccessRequestTimeout)
+		return err
+	default:
+		return fmt.Errorf("access request '%s' of cluster '%s' is %s and does not grant access to the cluster",
+			accessRequest.ID(), clusterID, strings.ToLower(string(accessrequest.GetAccessRequestState(accessRequest))))
+	}
+}
+
+// offerAccessRequestCreation creates an access request for the cluster once confirmed by the user,
+// using the elevation reason as justification when there is one
+func offerAccessRequestCreation(ocmConnection *ocmsdk.Connection, clusterID, elevateReason string) (*acctrspv1.AccessRequest, error) {
+	createCommand := fmt.Sprintf("ocm-backplane accessrequest create --cluster-id %s --wait", clusterID)
+	if elevateReason != "" {
+		createCommand += fmt.Sprintf(" --reason %q", elevateReason)
+	}
+	noAccessRequestErr := fmt.Errorf("there is no access request for cluster '%s', create one running '%s'", clusterID, createCommand)
+
+	if !askYesNo("Do you want to create an access request (Y/n)? ") {
+		return nil, noAccessRequestErr
+	}
+
+	reason := elevateReason
+	if reason != "" {
+		fmt.Printf("Reason/justification of the access request: %s\n", reason)
+	} else {
+		reason = utils.AskQuestionFromPrompt("Please enter a reason/justification for the access request to create: ")
+		if reason == "" {
+			return nil, noAccessRequestErr
+		}
+	}
+
+	// The OHSS issue the login comes from is the natural place for the notifications on production
+	notificationIssueID := ""
+	if loginType == LoginTypeJira && isProductionEnvironment() && strings.HasPrefix(jiraIssueKey(), jira.JiraOHSSProjectKey+"-") {
+		notificationIssueID = jiraIssueKey()
+	}
+
+	return accessrequest.CreateAccessRequest(ocmConnection, clusterID, reason, notificationIssueID, accessrequest.DefaultApprovalDuration)
+}
+
+// askYesNo asks a question defaulting to yes, and returns false when not running interactively
+func askYesNo(question string) bool {
+	if !utils.CheckValidPrompt() {
+		return false
+	}
+	return strings.ToLower(strings.TrimSpace(utils.AskQuestionFromPrompt(question))) != "n"
+}
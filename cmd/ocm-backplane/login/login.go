@@ -10,6 +10,7 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"time"
 
 	logger "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -24,11 +25,11 @@ import (
 	"github.com/openshift/backplane-cli/pkg/backplaneapi"
 	"github.com/openshift/backplane-cli/pkg/cli/config"
 	"github.com/openshift/backplane-cli/pkg/cli/globalflags"
-	"github.com/openshift/backplane-cli/pkg/info"
 	"github.com/openshift/backplane-cli/pkg/jira"
 	"github.com/openshift/backplane-cli/pkg/login"
 	"github.com/openshift/backplane-cli/pkg/ocm"
 	"github.com/openshift/backplane-cli/pkg/pagerduty"
+	"github.com/openshift/backplane-cli/pkg/releasecheck"
 	"github.com/openshift/backplane-cli/pkg/utils"
 )
 
@@ -48,10 +49,14 @@ var (
 		pd               string
 		defaultNamespace string
 		ohss             string
+		jira             string
 		clusterInfo      bool
 		remediation      string
 		govcloud         bool
 		readonly         bool
+
+		waitAccessRequest        bool
+		waitAccessRequestTimeout time.Duration
 	}
 
 	// loginType derive the login type based on flags and args
@@ -68,9 +73,9 @@ var (
 		using OCM token. The backplane api will return a proxy url for
 		target cluster. The url will be written to kubeconfig, so we can
 		run oc command later to operate the target cluster.`,
-		Example: " backplane login <id>\n backplane login %test%\n backplane login <external_id>\n backplane login --pd <incident-id>",
+		Example: " backplane login <id>\n backplane login %test%\n backplane login <external_id>\n backplane login --pd <incident-id>\n backplane login --jira <issue-key>",
 		Args: func(cmd *cobra.Command, args []string) error {
-			if cmd.Flags().Lookup("pd").Changed || cmd.Flags().Lookup("ohss").Changed {
+			if cmd.Flags().Lookup("pd").Changed || cmd.Flags().Lookup("ohss").Changed || cmd.Flags().Lookup("jira").Changed {
 				if err := cobra.ExactArgs(0)(cmd, args); err != nil {
 					return err
 				}
@@ -81,13 +86,14 @@ var (
 			}
 			return nil
 		},
-		PreRunE:      preLogin,
-		RunE:         runLogin,
-		SilenceUsage: true,
+		ValidArgsFunction: CompleteClusterNames,
+		PreRunE:           preLogin,
+		RunE:              runLogin,
+		SilenceUsage:      true,
 	}
 
-	//ohss service
-	ohssService *jira.OHSSService
+	// jiraIssueService finds the cluster of the JIRA issues, overridden in tests
+	jiraIssueService *jira.ClusterIssueService
 )
 
 func init() {
@@ -127,7 +133,13 @@ func init() {
 		&args.ohss,
 		"ohss",
 		"",
-		"Login using JIRA Id",
+		"Login using an OHSS JIRA issue key, same as --jira",
+	)
+	flags.StringVar(
+		&args.jira,
+		"jira",
+		"",
+		"Login using the key of a JIRA issue of a project configured in 'jira-login-projects' (OHSS by default)",
 	)
 	flags.BoolVar(
 		&args.clusterInfo,
@@ -140,6 +152,18 @@ func init() {
 		false,
 		"Login with read-only access to the cluster",
 	)
+	flags.BoolVar(
+		&args.waitAccessRequest,
+		"wait-access-request",
+		false,
+		"Wait without prompting for the approval of the pending access request when the cluster is access protected",
+	)
+	flags.DurationVar(
+		&args.waitAccessRequestTimeout,
+		"wait-access-request-timeout",
+		0,
+		"The maximal period of time to wait for the approval of the access request, until it is denied or expires when zero",
+	)
 }
 
 // TODO there is something about the proxy config in relation to overriding with --url
@@ -148,9 +172,6 @@ func runLogin(cmd *cobra.Command, argv []string) (err error) {
 	var clusterKey string
 	var elevateReason string
 	logger.Debugf("Running Login Command ...")
-	logger.Debugf("Checking Backplane Version")
-	utils.CheckBackplaneVersion(cmd)
-
 	logger.Debugf("Extracting Backplane configuration")
 	// Get Backplane configuration
 	bpConfig, err := config.GetBackplaneConfiguration()
@@ -158,26 +179,29 @@ func runLogin(cmd *cobra.Command, argv []string) (err error) {
 		return err
 	}
 
+	logger.Debugf("Checking Backplane Version")
+	releasecheck.Notify(cmd, bpConfig)
+
 	// login to the cluster based on login type
 	logger.Debugf("Extracting Backplane Cluster ID")
 	switch loginType {
 	case LoginTypePagerduty:
-		info, err := getClusterInfoFromPagerduty(bpConfig)
+		info, err := getClusterInfoFromPagerduty()
 		if err != nil {
 			return err
 		}
 		clusterKey = info.ClusterID
 		elevateReason = info.WebURL
 	case LoginTypeJira:
-		ohssIssue, err := getClusterInfoFromJira()
+		issue, err := getClusterInfoFromJira(bpConfig)
 		if err != nil {
 			return err
 		}
-		if ohssIssue.ClusterID == "" {
-			return fmt.Errorf("clusterID cannot be detected for JIRA issue:%s", args.ohss)
+		if issue.ClusterKey == "" {
+			return fmt.Errorf("clusterID cannot be detected for JIRA issue:%s", jiraIssueKey())
 		}
-		clusterKey = ohssIssue.ClusterID
-		elevateReason = ohssIssue.WebURL
+		clusterKey = issue.ClusterKey
+		elevateReason = issue.WebURL
 	case LoginTypeClusterID:
 		logger.Debugf("Cluster Key is given in argument")
 		clusterKey = argv[0]
@@ -257,7 +281,7 @@ func runLogin(cmd *cobra.Command, argv []string) (err error) {
 		logger.Debugln("Finding K8s namespaces")
 		// Print the related namespace if login to manager cluster
 		var namespaces map[string]string
-		namespaces, err = listNamespaces(targetClusterID, isHostedControlPlane)
+		namespaces, err = ListNamespaces(targetClusterID, isHostedControlPlane)
 		if err != nil {
 			return err
 		}
@@ -340,6 +364,14 @@ func runLogin(cmd *cobra.Command, argv []string) (err error) {
 		return fmt.Errorf("cluster %s is hibernating, login failed", clusterKey)
 	}
 
+	// Access protection only applies to the customer clusters
+	if !globalOpts.Manager && !globalOpts.Service {
+		logger.Debugln("Check for Cluster Access Protection")
+		if err = checkAccessProtection(clusterID, elevateReason); err != nil {
+			return err
+		}
+	}
+
 	logger.WithFields(logger.Fields{
 		"bpURL":     bpURL,
 		"clusterID": clusterID,
@@ -349,7 +381,7 @@ func runLogin(cmd *cobra.Command, argv []string) (err error) {
 	bpAPIClusterURL, err := doLoginWithConn(bpURL, clusterID, *accessToken, nil, args.readonly)
 	if err != nil {
 		// Declare helperMsg
-		helperMsg := "\n\033[1mNOTE: To troubleshoot the connectivity issues, please run `ocm-backplane health-check`\033[0m\n\n"
+		helperMsg := fmt.Sprintf("\n\033[1mNOTE: To find which hop of the path to the cluster fails, please run `ocm-backplane healthcheck --cluster %s`\033[0m\n\n", clusterID)
 
 		// Check API connection with configured proxy
 		if connErr := bpConfig.CheckAPIConnection(); connErr != nil {
@@ -429,6 +461,15 @@ func runLogin(cmd *cobra.Command, argv []string) (err error) {
 		return err
 	}
 
+	// Remember the login for the shell prompt and the cluster name completion
+	if err = login.RecordRecentLogin(login.RecentLogin{
+		ClusterID:   clusterID,
+		ClusterName: clusterName,
+		Production:  isProductionEnvironment(),
+	}); err != nil {
+		logger.Debugf("Could not record the login: %v", err)
+	}
+
 	// We return without error from here because the user is still successfully logged in
 	// however, we just cannot check for other logged in users for some reason. Therefore,
 	// we should still return that this command exited successfully, as checking for
@@ -601,13 +642,15 @@ func doLoginWithConn(api, clusterID, accessToken string, ocmConn *ocmsdk.Connect
 		return "", err
 	}
 
-	err = backplaneapi.CheckResponseDeprecation(resp)
-	if errors.Is(err, backplaneapi.ErrDeprecation) {
-		logger.Warnf("The server indicated that backplane-cli version %s is deprecated. Please update as soon as possible.", info.DefaultInfoService.GetVersion())
-	}
-
 	if resp.StatusCode != http.StatusOK {
-		return "", utils.TryPrintAPIError(resp, false)
+		err := utils.TryPrintAPIError(resp, false)
+		switch {
+		case errors.Is(err, backplaneapi.ErrAccessProtectionRequired):
+			return "", fmt.Errorf("%w\nan access request approved by the customer is needed, run 'ocm-backplane accessrequest create --cluster-id %s' to create one", err, clusterID)
+		case errors.Is(err, backplaneapi.ErrClusterHibernating):
+			return "", fmt.Errorf("cluster %s is hibernating, login failed: %w", clusterID, err)
+		}
+		return "", err
 	}
 
 	loginResp, err := BackplaneApi.ParseLoginClusterResponse(resp)
@@ -619,7 +662,8 @@ func doLoginWithConn(api, clusterID, accessToken string, ocmConn *ocmsdk.Connect
 	return api + *loginResp.JSON200.ProxyUri, nil
 }
 
-func listNamespaces(clusterID string, isHostedControlPlane bool) (map[string]string, error) {
+// ListNamespaces returns the namespaces of the cluster on its management cluster, keyed by the name of their env variable
+func ListNamespaces(clusterID string, isHostedControlPlane bool) (map[string]string, error) {
 
 	env, err := ocm.DefaultOCMInterface.GetOCMEnvironment()
 	if err != nil {
@@ -656,6 +700,36 @@ func listNamespaces(clusterID string, isHostedControlPlane bool) (map[string]str
 	return nsList, nil
 }
 
+// CompleteClusterNames completes the names and IDs of the clusters recently logged into
+func CompleteClusterNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	recentLogins, err := login.GetRecentLogins()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	completions := []string{}
+	for _, recent := range recentLogins {
+		if strings.HasPrefix(recent.ClusterName, toComplete) {
+			completions = append(completions, recent.ClusterName+"\t"+recent.ClusterID)
+		} else if strings.HasPrefix(recent.ClusterID, toComplete) {
+			completions = append(completions, recent.ClusterID+"\t"+recent.ClusterName)
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// isProductionEnvironment returns whether the current OCM environment is the production one
+func isProductionEnvironment() bool {
+	env, err := ocm.DefaultOCMInterface.GetOCMEnvironment()
+	if err != nil {
+		logger.Debugf("Could not get the OCM environment: %v", err)
+		return false
+	}
+	return env.Name() == "production"
+}
+
 // getBackplaneCNAME returns the DNS/CNAME resolution of the ocm backplane URL
 func getBackplaneCNAME(backplaneURL string) (string, error) {
 	backplaneDomain, err := url.Parse(backplaneURL)
@@ -685,9 +759,9 @@ func preLogin(cmd *cobra.Command, argv []string) (err error) {
 		loginType = LoginTypeClusterID
 
 	case 0:
-		if args.pd == "" && args.ohss == "" {
+		if args.pd == "" && jiraIssueKey() == "" {
 			loginType = LoginTypeExistingKubeConfig
-		} else if args.ohss != "" {
+		} else if jiraIssueKey() != "" {
 			loginType = LoginTypeJira
 		} else if args.pd != "" {
 			loginType = LoginTypePagerduty
@@ -699,41 +773,39 @@ func preLogin(cmd *cobra.Command, argv []string) (err error) {
 
 // getClusterInfoFromPagerduty returns a pagerduty.Alert from Pagerduty incident,
 // which contains alert info including the cluster id.
-func getClusterInfoFromPagerduty(bpConfig config.BackplaneConfiguration) (alert pagerduty.Alert, err error) {
-	if bpConfig.PagerDutyAPIKey == "" {
-		return alert, fmt.Errorf("please make sure the PD API Key is configured correctly in the config file")
-	}
-	pdClient, err := pagerduty.NewWithToken(bpConfig.PagerDutyAPIKey)
+func getClusterInfoFromPagerduty() (alert pagerduty.Alert, err error) {
+	pdClient, err := pagerduty.NewWithConfiguredKey()
 	if err != nil {
-		return alert, fmt.Errorf("could not initialize the client: %w", err)
+		return alert, err
 	}
-	if strings.Contains(args.pd, "/incidents/") {
-		incidentID := args.pd[strings.LastIndex(args.pd, "/")+1:]
-		alert, err = pdClient.GetClusterInfoFromIncident(incidentID)
-		if err != nil {
-			return alert, err
-		}
-	} else {
-		alert, err = pdClient.GetClusterInfoFromIncident(args.pd)
-		if err != nil {
-			return alert, err
-		}
+	alert, err = pdClient.GetClusterInfoFromIncident(pagerduty.ParseIncidentID(args.pd))
+	if err != nil {
+		return alert, err
 	}
 	return alert, nil
 }
 
-// getClusterInfoFromJira returns a cluster info OHSS card
-func getClusterInfoFromJira() (ohss jira.OHSSIssue, err error) {
-	if ohssService == nil {
-		ohssService = jira.NewOHSSService(jira.DefaultIssueService)
+// jiraIssueKey returns the JIRA issue to log in from, given with --jira or --ohss
+func jiraIssueKey() string {
+	if args.jira != "" {
+		return args.jira
+	}
+	return args.ohss
+}
+
+// getClusterInfoFromJira returns the JIRA issue along with the cluster it is about,
+// found as configured for the project of the issue
+func getClusterInfoFromJira(bpConfig config.BackplaneConfiguration) (issue jira.ClusterIssue, err error) {
+	if jiraIssueService == nil {
+		jiraIssueService = jira.NewClusterIssueService(jira.DefaultIssueService, bpConfig.JiraLoginProjects)
 	}
 
-	ohss, err = ohssService.GetIssue(args.ohss)
+	issue, err = jiraIssueService.GetIssue(jiraIssueKey())
 	if err != nil {
-		return ohss, err
+		return issue, err
 	}
 
-	return ohss, nil
+	return issue, nil
 }
 
 // getClusterIDFromExistingKubeConfig returns clusterId from kubeconfig
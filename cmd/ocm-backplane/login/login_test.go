@@ -9,9 +9,11 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	acctrspv1 "github.com/openshift-online/ocm-sdk-go/accesstransparency/v1"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/trivago/tgo/tcontainer"
 	"go.uber.org/mock/gomock"
@@ -24,6 +26,7 @@ import (
 	backplaneapiMock "github.com/openshift/backplane-cli/pkg/backplaneapi/mocks"
 	"github.com/openshift/backplane-cli/pkg/cli/config"
 	"github.com/openshift/backplane-cli/pkg/client/mocks"
+	"github.com/openshift/backplane-cli/pkg/info"
 	jiraClient "github.com/openshift/backplane-cli/pkg/jira"
 	jiraMock "github.com/openshift/backplane-cli/pkg/jira/mocks"
 	"github.com/openshift/backplane-cli/pkg/login"
@@ -67,6 +70,15 @@ var _ = Describe("Login command", func() {
 	)
 
 	BeforeEach(func() {
+		// keep the files the login records, such as the recent logins, out of the real config directory
+		home := GinkgoT().TempDir()
+		GinkgoT().Setenv("HOME", home)
+		homeConfigPath := filepath.Join(home, ".config", "backplane", "config.json")
+		GinkgoT().Setenv(info.BackplaneConfigPathEnvName, homeConfigPath)
+		// and do not check the releases on GitHub
+		Expect(os.MkdirAll(filepath.Dir(homeConfigPath), 0750)).To(Succeed())
+		Expect(os.WriteFile(homeConfigPath, []byte(`{"disable-update-check": true}`), 0600)).To(Succeed())
+
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockClient = mocks.NewMockClientInterface(mockCtrl)
 		mockClientWithResp = mocks.NewMockClientWithResponsesInterface(mockCtrl)
@@ -152,6 +164,9 @@ var _ = Describe("Login command", func() {
 			mockClientUtil.EXPECT().MakeRawBackplaneAPIClientWithAccessToken(backplaneAPIURI, testToken).Return(mockClient, nil)
 			mockClient.EXPECT().LoginCluster(gomock.Any(), gomock.Eq(trueClusterID)).Return(fakeResp, nil)
 
+			mockOcmInterface.EXPECT().SetupOCMConnection().Return(nil, nil)
+			mockOcmInterface.EXPECT().IsClusterAccessProtectionEnabled(gomock.Any(), trueClusterID).Return(false, nil)
+
 			err = runLogin(nil, []string{testClusterID})
 
 			Expect(err).To(BeNil())
@@ -176,6 +191,9 @@ var _ = Describe("Login command", func() {
 			mockClientUtil.EXPECT().MakeRawBackplaneAPIClientWithAccessToken("https://sadge.app", testToken).Return(mockClient, nil)
 			mockClient.EXPECT().LoginCluster(gomock.Any(), gomock.Eq(trueClusterID)).Return(fakeResp, nil)
 
+			mockOcmInterface.EXPECT().SetupOCMConnection().Return(nil, nil)
+			mockOcmInterface.EXPECT().IsClusterAccessProtectionEnabled(gomock.Any(), trueClusterID).Return(false, nil)
+
 			err = runLogin(nil, []string{testClusterID})
 
 			Expect(err).To(BeNil())
@@ -201,6 +219,9 @@ var _ = Describe("Login command", func() {
 			mockClientUtil.EXPECT().MakeRawBackplaneAPIClientWithAccessToken(backplaneAPIURI, testToken).Return(mockClient, nil)
 			mockClient.EXPECT().LoginCluster(gomock.Any(), gomock.Eq(trueClusterID)).Return(fakeResp, nil)
 
+			mockOcmInterface.EXPECT().SetupOCMConnection().Return(nil, nil)
+			mockOcmInterface.EXPECT().IsClusterAccessProtectionEnabled(gomock.Any(), trueClusterID).Return(false, nil)
+
 			err = runLogin(nil, []string{testClusterID})
 
 			Expect(err).To(BeNil())
@@ -222,6 +243,9 @@ var _ = Describe("Login command", func() {
 			mockOcmInterface.EXPECT().GetOCMAccessToken().Return(&testToken, nil)
 			mockClientUtil.EXPECT().MakeRawBackplaneAPIClientWithAccessToken(backplaneAPIURI, testToken).Return(nil, errors.New("err"))
 
+			mockOcmInterface.EXPECT().SetupOCMConnection().Return(nil, nil)
+			mockOcmInterface.EXPECT().IsClusterAccessProtectionEnabled(gomock.Any(), trueClusterID).Return(false, nil)
+
 			err := runLogin(nil, []string{testClusterID})
 
 			Expect(err).ToNot(BeNil())
@@ -258,8 +282,8 @@ var _ = Describe("Login command", func() {
 			mockClientUtil.EXPECT().MakeRawBackplaneAPIClientWithAccessToken(backplaneAPIURI, testToken).Return(mockClient, nil)
 			mockClient.EXPECT().LoginCluster(gomock.Any(), gomock.Eq(trueClusterID)).Return(fakeResp, nil)
 			mockOcmInterface.EXPECT().GetClusterInfoByID(gomock.Any()).Return(mockCluster, nil).Times(2)
-			mockOcmInterface.EXPECT().SetupOCMConnection().Return(nil, nil)
-			mockOcmInterface.EXPECT().IsClusterAccessProtectionEnabled(gomock.Any(), trueClusterID).Return(false, nil)
+			mockOcmInterface.EXPECT().SetupOCMConnection().Return(nil, nil).Times(2)
+			mockOcmInterface.EXPECT().IsClusterAccessProtectionEnabled(gomock.Any(), trueClusterID).Return(false, nil).Times(2)
 
 			err = runLogin(nil, []string{testClusterID})
 
@@ -297,6 +321,9 @@ var _ = Describe("Login command", func() {
 			mockClientUtil.EXPECT().MakeRawBackplaneAPIClientWithAccessToken(backplaneAPIURI, testToken).Return(mockClient, nil)
 			mockClient.EXPECT().LoginCluster(gomock.Any(), gomock.Eq(trueClusterID)).Return(fakeResp, nil)
 
+			mockOcmInterface.EXPECT().SetupOCMConnection().Return(nil, nil)
+			mockOcmInterface.EXPECT().IsClusterAccessProtectionEnabled(gomock.Any(), trueClusterID).Return(false, nil)
+
 			err = runLogin(nil, []string{testClusterID})
 
 			Expect(err).To(BeNil())
@@ -320,6 +347,9 @@ var _ = Describe("Login command", func() {
 			mockClientUtil.EXPECT().MakeRawBackplaneAPIClientWithAccessToken(backplaneAPIURI, testToken).Return(mockClient, nil)
 			mockClient.EXPECT().LoginCluster(gomock.Any(), gomock.Eq(trueClusterID)).Return(fakeResp, nil)
 
+			mockOcmInterface.EXPECT().SetupOCMConnection().Return(nil, nil)
+			mockOcmInterface.EXPECT().IsClusterAccessProtectionEnabled(gomock.Any(), trueClusterID).Return(false, nil)
+
 			err = runLogin(nil, []string{testClusterID})
 
 			Expect(err).To(BeNil())
@@ -411,6 +441,9 @@ var _ = Describe("Login command", func() {
 			mockClientUtil.EXPECT().MakeRawBackplaneAPIClientWithAccessToken(backplaneAPIURI, testToken).Return(mockClient, nil)
 			mockClient.EXPECT().LoginCluster(gomock.Any(), gomock.Eq(testClusterID)).Return(fakeResp, nil)
 
+			mockOcmInterface.EXPECT().SetupOCMConnection().Return(nil, nil)
+			mockOcmInterface.EXPECT().IsClusterAccessProtectionEnabled(gomock.Any(), testClusterID).Return(false, nil)
+
 			err = runLogin(nil, nil)
 
 			Expect(err).To(BeNil())
@@ -433,6 +466,9 @@ var _ = Describe("Login command", func() {
 			mockClientUtil.EXPECT().MakeRawBackplaneAPIClientWithAccessToken(backplaneAPIURI, testToken).Return(mockClient, nil)
 			mockClient.EXPECT().LoginCluster(gomock.Any(), gomock.Eq(trueClusterID)).Return(fakeResp, errors.New("dial tcp: lookup yourproxy.com: no such host"))
 
+			mockOcmInterface.EXPECT().SetupOCMConnection().Return(nil, nil)
+			mockOcmInterface.EXPECT().IsClusterAccessProtectionEnabled(gomock.Any(), trueClusterID).Return(false, nil)
+
 			err = runLogin(nil, []string{testClusterID})
 
 			Expect(err).NotTo(BeNil())
@@ -461,6 +497,9 @@ var _ = Describe("Login command", func() {
 			mockClientUtil.EXPECT().MakeRawBackplaneAPIClientWithAccessToken(backplaneAPIURI, testToken).Return(mockClient, nil)
 			mockClient.EXPECT().LoginCluster(gomock.Any(), gomock.Eq(trueClusterID)).Return(fakeResp, nil)
 
+			mockOcmInterface.EXPECT().SetupOCMConnection().Return(nil, nil)
+			mockOcmInterface.EXPECT().IsClusterAccessProtectionEnabled(gomock.Any(), trueClusterID).Return(false, nil)
+
 			err = runLogin(nil, []string{testClusterID})
 
 			Expect(os.Getenv("KUBECONFIG")).Should(ContainSubstring(trueClusterID))
@@ -495,17 +534,18 @@ var _ = Describe("Login command", func() {
 			mockOcmInterface.EXPECT().GetOCMEnvironment().Return(ocmEnv, nil).AnyTimes()
 
 			// Create a temporary JSON configuration file in the temp directory for testing purposes.
-			tempDir := os.TempDir()
+			tempDir := GinkgoT().TempDir()
 			bpConfigPath = filepath.Join(tempDir, "mock.json")
 			tempFile, err := os.Create(bpConfigPath) //nolint:gosec
 			Expect(err).To(BeNil())
 
 			testData := config.BackplaneConfiguration{
-				URL:              backplaneAPIURI,
-				ProxyURL:         new(string),
-				SessionDirectory: "",
-				AssumeInitialArn: "",
-				PagerDutyAPIKey:  falsePagerDutyAPITkn,
+				URL:                backplaneAPIURI,
+				ProxyURL:           new(string),
+				SessionDirectory:   "",
+				AssumeInitialArn:   "",
+				PagerDutyAPIKey:    falsePagerDutyAPITkn,
+				DisableUpdateCheck: true,
 			}
 
 			// Marshal the testData into JSON format and write it to tempFile.
@@ -531,15 +571,16 @@ var _ = Describe("Login command", func() {
 			mockOcmInterface.EXPECT().GetOCMEnvironment().Return(ocmEnv, nil).AnyTimes()
 
 			// Create a temporary JSON configuration file in the temp directory for testing purposes.
-			tempDir := os.TempDir()
+			tempDir := GinkgoT().TempDir()
 			bpConfigPath = filepath.Join(tempDir, "mock.json")
 			tempFile, err := os.Create(bpConfigPath) //nolint:gosec
 			Expect(err).To(BeNil())
 
 			testData := config.BackplaneConfiguration{
-				URL:             backplaneAPIURI,
-				ProxyURL:        new(string),
-				PagerDutyAPIKey: falsePagerDutyAPITkn,
+				URL:                backplaneAPIURI,
+				ProxyURL:           new(string),
+				PagerDutyAPIKey:    falsePagerDutyAPITkn,
+				DisableUpdateCheck: true,
 			}
 
 			// Marshal the testData into JSON format and write it to tempFile.
@@ -567,15 +608,16 @@ var _ = Describe("Login command", func() {
 			mockOcmInterface.EXPECT().GetOCMEnvironment().Return(ocmEnv, nil).AnyTimes()
 
 			// Create a temporary JSON configuration file in the temp directory for testing purposes.
-			tempDir := os.TempDir()
+			tempDir := GinkgoT().TempDir()
 			bpConfigPath = filepath.Join(tempDir, "mock.json")
 			tempFile, err := os.Create(bpConfigPath) //nolint:gosec
 			Expect(err).To(BeNil())
 
 			testData := config.BackplaneConfiguration{
-				URL:             backplaneAPIURI,
-				ProxyURL:        new(string),
-				PagerDutyAPIKey: truePagerDutyAPITkn,
+				URL:                backplaneAPIURI,
+				ProxyURL:           new(string),
+				PagerDutyAPIKey:    truePagerDutyAPITkn,
+				DisableUpdateCheck: true,
 			}
 
 			// Marshal the testData into JSON format and write it to tempFile.
@@ -593,6 +635,74 @@ var _ = Describe("Login command", func() {
 
 	})
 
+	Context("check access protection", func() {
+		newAccessRequest := func(state acctrspv1.AccessRequestState) *acctrspv1.AccessRequest {
+			accessRequest, err := acctrspv1.NewAccessRequest().ID("req-1").
+				Status(acctrspv1.NewAccessRequestStatus().State(state).ExpiresAt(time.Now().Add(time.Hour))).Build()
+			Expect(err).To(BeNil())
+			return accessRequest
+		}
+
+		BeforeEach(func() {
+			err := utils.CreateTempKubeConfig(nil)
+			Expect(err).To(BeNil())
+			args.defaultNamespace = "default"
+			args.clusterInfo = false
+			args.kubeConfigPath = ""
+			mockOcmInterface.EXPECT().GetOCMEnvironment().Return(ocmEnv, nil).AnyTimes()
+			mockOcmInterface.EXPECT().GetTargetCluster(testClusterID).Return(trueClusterID, testClusterID, nil)
+			mockOcmInterface.EXPECT().IsClusterHibernating(gomock.Eq(trueClusterID)).Return(false, nil).AnyTimes()
+			mockOcmInterface.EXPECT().GetOCMAccessToken().Return(&testToken, nil)
+			mockOcmInterface.EXPECT().SetupOCMConnection().Return(nil, nil)
+			mockOcmInterface.EXPECT().IsClusterAccessProtectionEnabled(gomock.Any(), trueClusterID).Return(true, nil)
+		})
+
+		AfterEach(func() {
+			args.waitAccessRequest = false
+		})
+
+		It("should login when the access request is approved", func() {
+			mockOcmInterface.EXPECT().GetClusterActiveAccessRequest(gomock.Any(), trueClusterID).Return(newAccessRequest(acctrspv1.AccessRequestStateApproved), nil)
+			mockClientUtil.EXPECT().MakeRawBackplaneAPIClientWithAccessToken(backplaneAPIURI, testToken).Return(mockClient, nil)
+			mockClient.EXPECT().LoginCluster(gomock.Any(), gomock.Eq(trueClusterID)).Return(fakeResp, nil)
+
+			err := runLogin(nil, []string{testClusterID})
+
+			Expect(err).To(BeNil())
+		})
+
+		It("should explain how to create the missing access request when not running interactively", func() {
+			mockOcmInterface.EXPECT().GetClusterActiveAccessRequest(gomock.Any(), trueClusterID).Return(nil, nil)
+
+			err := runLogin(nil, []string{testClusterID})
+
+			Expect(err).To(MatchError(ContainSubstring("there is no access request for cluster 'trueID123'")))
+			Expect(err).To(MatchError(ContainSubstring("ocm-backplane accessrequest create --cluster-id trueID123 --wait")))
+		})
+
+		It("should fail when the access request is pending and waiting is not requested", func() {
+			mockOcmInterface.EXPECT().GetClusterActiveAccessRequest(gomock.Any(), trueClusterID).Return(newAccessRequest(acctrspv1.AccessRequestStatePending), nil)
+
+			err := runLogin(nil, []string{testClusterID})
+
+			Expect(err).To(MatchError(ContainSubstring("ocm-backplane accessrequest wait --cluster-id trueID123")))
+		})
+
+		It("should wait for the approval of the pending access request before login", func() {
+			args.waitAccessRequest = true
+			gomock.InOrder(
+				mockOcmInterface.EXPECT().GetClusterActiveAccessRequest(gomock.Any(), trueClusterID).Return(newAccessRequest(acctrspv1.AccessRequestStatePending), nil),
+				mockOcmInterface.EXPECT().GetClusterActiveAccessRequest(gomock.Any(), trueClusterID).Return(newAccessRequest(acctrspv1.AccessRequestStateApproved), nil),
+			)
+			mockClientUtil.EXPECT().MakeRawBackplaneAPIClientWithAccessToken(backplaneAPIURI, testToken).Return(mockClient, nil)
+			mockClient.EXPECT().LoginCluster(gomock.Any(), gomock.Eq(trueClusterID)).Return(fakeResp, nil)
+
+			err := runLogin(nil, []string{testClusterID})
+
+			Expect(err).To(BeNil())
+		})
+	})
+
 	Context("check GetRestConfigAsUser", func() {
 		It("check config creation with username and without elevationReasons", func() {
 			mockOcmInterface.EXPECT().GetClusterInfoByID(testClusterID).Return(mockCluster, nil)
@@ -636,8 +746,10 @@ var _ = Describe("Login command", func() {
 		)
 		BeforeEach(func() {
 			mockIssueService = jiraMock.NewMockIssueServiceInterface(mockCtrl)
-			ohssService = jiraClient.NewOHSSService(mockIssueService)
+			jiraIssueService = jiraClient.NewClusterIssueService(mockIssueService, config.JiraLoginProjectsDefaultValue)
 			testOHSSID = "OHSS-1000"
+			args.ohss = ""
+			args.jira = ""
 		})
 
 		It("should login to ohss card cluster", func() {
@@ -662,6 +774,9 @@ var _ = Describe("Login command", func() {
 			mockClientUtil.EXPECT().MakeRawBackplaneAPIClientWithAccessToken(backplaneAPIURI, testToken).Return(mockClient, nil)
 			mockClient.EXPECT().LoginCluster(gomock.Any(), gomock.Eq(testClusterID)).Return(fakeResp, nil)
 
+			mockOcmInterface.EXPECT().SetupOCMConnection().Return(nil, nil)
+			mockOcmInterface.EXPECT().IsClusterAccessProtectionEnabled(gomock.Any(), testClusterID).Return(false, nil)
+
 			err = runLogin(nil, nil)
 
 			Expect(err).To(BeNil())
@@ -684,6 +799,49 @@ var _ = Describe("Login command", func() {
 			Expect(err).NotTo(BeNil())
 			Expect(err.Error()).To(Equal("clusterID cannot be detected for JIRA issue:OHSS-1000"))
 		})
+
+		It("should login to the cluster found in the summary of an issue of a configured project", func() {
+			jiraIssueService = jiraClient.NewClusterIssueService(mockIssueService, config.JiraLoginProjectsConfiguration{
+				"SREP": {ClusterPattern: `cluster (\S+)`},
+			})
+			loginType = LoginTypeJira
+			args.jira = "SREP-42"
+			err := utils.CreateTempKubeConfig(nil)
+			args.kubeConfigPath = ""
+			Expect(err).To(BeNil())
+			testIssue = jira.Issue{ID: "SREP-42", Fields: &jira.IssueFields{
+				Project: jira.Project{Key: "SREP"},
+				Summary: "Upgrade stuck on cluster my-cluster",
+			}}
+			globalOpts.ProxyURL = "https://squid.myproxy.com"
+			mockIssueService.EXPECT().Get("SREP-42", nil).Return(&testIssue, nil, nil).Times(1)
+			mockOcmInterface.EXPECT().GetOCMEnvironment().Return(ocmEnv, nil).AnyTimes()
+			mockClientUtil.EXPECT().SetClientProxyURL(globalOpts.ProxyURL).Return(nil)
+			mockOcmInterface.EXPECT().GetTargetCluster("my-cluster").Return(testClusterID, "my-cluster", nil)
+			mockOcmInterface.EXPECT().IsClusterHibernating(gomock.Eq(testClusterID)).Return(false, nil).AnyTimes()
+			mockOcmInterface.EXPECT().GetOCMAccessToken().Return(&testToken, nil)
+			mockClientUtil.EXPECT().MakeRawBackplaneAPIClientWithAccessToken(backplaneAPIURI, testToken).Return(mockClient, nil)
+			mockClient.EXPECT().LoginCluster(gomock.Any(), gomock.Eq(testClusterID)).Return(fakeResp, nil)
+
+			mockOcmInterface.EXPECT().SetupOCMConnection().Return(nil, nil)
+			mockOcmInterface.EXPECT().IsClusterAccessProtectionEnabled(gomock.Any(), testClusterID).Return(false, nil)
+
+			err = runLogin(nil, nil)
+
+			Expect(err).To(BeNil())
+		})
+
+		It("should fail for an issue of a project which is not configured", func() {
+			loginType = LoginTypeJira
+			args.jira = "SREP-42"
+			testIssue = jira.Issue{ID: "SREP-42", Fields: &jira.IssueFields{Project: jira.Project{Key: "SREP"}}}
+			mockIssueService.EXPECT().Get("SREP-42", nil).Return(&testIssue, nil, nil).Times(1)
+			mockOcmInterface.EXPECT().GetOCMEnvironment().Return(ocmEnv, nil).AnyTimes()
+
+			err := runLogin(nil, nil)
+
+			Expect(err).To(MatchError(ContainSubstring("which is not one of the JIRA projects to log in from (OHSS)")))
+		})
 	})
 
 	Context("readonly flag functionality", func() {
@@ -721,6 +879,9 @@ var _ = Describe("Login command", func() {
 				},
 			)
 
+			mockOcmInterface.EXPECT().SetupOCMConnection().Return(nil, nil)
+			mockOcmInterface.EXPECT().IsClusterAccessProtectionEnabled(gomock.Any(), trueClusterID).Return(false, nil)
+
 			err := runLogin(nil, []string{testClusterID})
 
 			Expect(err).To(BeNil())
@@ -751,6 +912,9 @@ var _ = Describe("Login command", func() {
 				},
 			)
 
+			mockOcmInterface.EXPECT().SetupOCMConnection().Return(nil, nil)
+			mockOcmInterface.EXPECT().IsClusterAccessProtectionEnabled(gomock.Any(), trueClusterID).Return(false, nil)
+
 			err := runLogin(nil, []string{testClusterID})
 
 			Expect(err).To(BeNil())
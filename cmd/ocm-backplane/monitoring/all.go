@@ -0,0 +1,158 @@
+package monitoring
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/backplane-cli/pkg/monitoring"
+	"github.com/openshift/backplane-cli/pkg/utils"
+)
+
+const (
+	// how long to wait for the background proxy to answer
+	monitoringDaemonStartTimeout = 30 * time.Second
+)
+
+var allArgs struct {
+	keepAlive bool
+	stop      bool
+}
+
+// newAllCmd returns the command serving every monitoring backend from one listener
+func newAllCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "all",
+		Short: "Create a local proxy to every monitoring UI",
+		Long: `Serve every monitoring UI of the currently logged in cluster from a single local listener, each on the path
+prefix of its name, e.g. /prometheus/. The root serves a landing page linking to each UI, and /healthz reports
+the health of every backend.
+
+With --keep-alive the proxy keeps running in the background. It is discovered by the other monitoring commands,
+e.g. 'monitoring prometheus' prints its URL instead of starting a new proxy.`,
+		Example:      " backplane monitoring all -b\n backplane monitoring all --keep-alive\n backplane monitoring all --stop",
+		Args:         cobra.NoArgs,
+		RunE:         runAll,
+		SilenceUsage: true,
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(
+		&monitoring.MonitoringOpts.Browser,
+		"browser",
+		"b",
+		false,
+		"Open the browser automatically.",
+	)
+	flags.StringVar(
+		&monitoring.MonitoringOpts.ListenAddr,
+		"listen",
+		"",
+		"The local address to listen to. Recommend using 127.0.0.1:xxxx to minimize security risk. The default will pick a random port on 127.0.0.1",
+	)
+	flags.BoolVar(
+		&allArgs.keepAlive,
+		"keep-alive",
+		false,
+		"Keep the proxy running in the background after the command returns.",
+	)
+	flags.BoolVar(
+		&allArgs.stop,
+		"stop",
+		false,
+		"Stop the proxy running in the background for the current cluster.",
+	)
+	addNamespaceFlag(cmd)
+
+	return cmd
+}
+
+// runAll serves every monitoring backend, in the foreground or in the background
+func runAll(cmd *cobra.Command, argv []string) error {
+	if allArgs.keepAlive && allArgs.stop {
+		return fmt.Errorf("--keep-alive and --stop cannot be used together")
+	}
+
+	if allArgs.stop || allArgs.keepAlive {
+		clusterInfo, err := utils.DefaultClusterUtils.GetBackplaneClusterFromConfig()
+		if err != nil {
+			return err
+		}
+		if allArgs.stop {
+			if err := monitoring.StopRunningProxy(clusterInfo.ClusterID); err != nil {
+				return err
+			}
+			fmt.Printf("Stopped the monitoring proxy of cluster %s\n", clusterInfo.ClusterID)
+			return nil
+		}
+		return startMonitoringDaemon(clusterInfo.ClusterID)
+	}
+
+	return monitoring.NewClient("", http.Client{}).RunAllMonitoring()
+}
+
+// startMonitoringDaemon runs 'monitoring all' detached from the terminal, and waits until it answers
+func startMonitoringDaemon(clusterID string) error {
+	running, err := monitoring.GetRunningProxy(clusterID)
+	if err != nil {
+		return err
+	}
+	if running != nil {
+		fmt.Printf("Monitoring of cluster %s is already served at %s\n", clusterID, running.URL)
+		return nil
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	logPath, err := monitoring.GetProxyLogPath(clusterID)
+	if err != nil {
+		return err
+	}
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = logFile.Close() }()
+
+	args := []string{"monitoring", "all", "--namespace", monitoring.MonitoringOpts.Namespace}
+	if monitoring.MonitoringOpts.ListenAddr != "" {
+		args = append(args, "--listen", monitoring.MonitoringOpts.ListenAddr)
+	}
+	if monitoring.MonitoringOpts.Browser {
+		args = append(args, "--browser")
+	}
+	daemon := exec.Command(executable, args...) //#nosec G204 -- runs this binary
+	daemon.Stdout = logFile
+	daemon.Stderr = logFile
+	daemon.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	if err := daemon.Start(); err != nil {
+		return fmt.Errorf("failed to start the monitoring proxy in the background: %v", err)
+	}
+	exited := make(chan error, 1)
+	go func() { exited <- daemon.Wait() }()
+
+	deadline := time.Now().Add(monitoringDaemonStartTimeout)
+	for time.Now().Before(deadline) {
+		select {
+		case err := <-exited:
+			return fmt.Errorf("the monitoring proxy exited early (%v), see %s", err, logPath)
+		case <-time.After(500 * time.Millisecond):
+		}
+		running, err := monitoring.GetRunningProxy(clusterID)
+		if err != nil {
+			return err
+		}
+		if running != nil {
+			fmt.Printf("Serving monitoring of cluster %s at %s in the background, logs are written to %s\n", clusterID, running.URL, logPath)
+			return nil
+		}
+	}
+	return fmt.Errorf("the monitoring proxy did not start within %s, see %s", monitoringDaemonStartTimeout, logPath)
+}
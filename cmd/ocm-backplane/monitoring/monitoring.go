@@ -64,6 +64,11 @@ func init() {
 		"The local address to listen to. Recommend using 127.0.0.1:xxxx to minimize security risk. The default will pick a random port on 127.0.0.1",
 	)
 
+	MonitoringCmd.AddCommand(newQueryCmd())
+	MonitoringCmd.AddCommand(newAlertsCmd())
+	MonitoringCmd.AddCommand(newSilenceCmd())
+	MonitoringCmd.AddCommand(newAllCmd())
+	MonitoringCmd.AddCommand(newSnapshotCmd())
 }
 
 // runMonitoring create local proxy url to serve monitoring dashboard
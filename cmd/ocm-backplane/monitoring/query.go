@@ -0,0 +1,270 @@
+package monitoring
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/backplane-cli/pkg/monitoring"
+	"github.com/openshift/backplane-cli/pkg/utils"
+)
+
+const (
+	outputTable     = "table"
+	outputJSON      = "json"
+	outputSparkline = "sparkline"
+
+	// the number of points a range query returns when no step is given
+	defaultRangePoints = 120
+)
+
+var (
+	// monitoring backends serving the Prometheus HTTP API
+	validQuerySources = []string{monitoring.THANOS, monitoring.PROMETHEUS}
+
+	queryArgs struct {
+		source     string
+		output     string
+		queryRange time.Duration
+		step       time.Duration
+		firingOnly bool
+	}
+)
+
+// newQueryCmd returns the command running a PromQL query
+func newQueryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query <promql>",
+		Short: "Run a PromQL query against the cluster monitoring stack",
+		Long: `Run an instant PromQL query, or a range query with --range, through the backplane monitoring endpoint
+of the currently logged in cluster and print the result.`,
+		Example:      " backplane monitoring query 'up == 0'\n backplane monitoring query 'sum(rate(apiserver_request_total[5m]))' --range 1h --step 30s -o sparkline",
+		Args:         cobra.ExactArgs(1),
+		RunE:         runQuery,
+		SilenceUsage: true,
+	}
+
+	flags := cmd.Flags()
+	addQueryFlags(cmd)
+	flags.DurationVar(
+		&queryArgs.queryRange,
+		"range",
+		0,
+		"Run a range query over the given duration until now, e.g. 1h.",
+	)
+	flags.DurationVar(
+		&queryArgs.step,
+		"step",
+		0,
+		fmt.Sprintf("The resolution of the range query, e.g. 30s. Default: the range divided by %d", defaultRangePoints),
+	)
+	flags.StringVarP(
+		&queryArgs.output,
+		"output",
+		"o",
+		outputTable,
+		fmt.Sprintf("Format the output of the query. One of %s|%s|%s (range queries only)", outputTable, outputJSON, outputSparkline),
+	)
+
+	return cmd
+}
+
+// newAlertsCmd returns the command listing alerts
+func newAlertsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "alerts",
+		Short:        "List the alerts of the cluster monitoring stack",
+		Example:      " backplane monitoring alerts\n backplane monitoring alerts --firing -o json",
+		Args:         cobra.NoArgs,
+		RunE:         runAlerts,
+		SilenceUsage: true,
+	}
+
+	flags := cmd.Flags()
+	addQueryFlags(cmd)
+	flags.BoolVar(
+		&queryArgs.firingOnly,
+		"firing",
+		false,
+		"Only list firing alerts, leaving out pending ones.",
+	)
+	flags.StringVarP(
+		&queryArgs.output,
+		"output",
+		"o",
+		outputTable,
+		fmt.Sprintf("Format the output of the alerts. One of %s|%s", outputTable, outputJSON),
+	)
+
+	return cmd
+}
+
+// addQueryFlags adds the flags shared by the commands using the Prometheus HTTP API
+func addQueryFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&queryArgs.source,
+		"source",
+		monitoring.THANOS,
+		fmt.Sprintf("The monitoring backend to query. One of %s", strings.Join(validQuerySources, "|")),
+	)
+	addNamespaceFlag(cmd)
+}
+
+// addNamespaceFlag adds the flag selecting the monitoring stack
+func addNamespaceFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(
+		&monitoring.MonitoringOpts.Namespace,
+		"namespace",
+		"n",
+		monitoring.OpenShiftMonitoringNS,
+		"Specify namespace of monitoring stack.",
+	)
+}
+
+// validateSource checks the queried monitoring backend serves the Prometheus HTTP API
+func validateSource(source string) error {
+	for _, s := range validQuerySources {
+		if s == source {
+			return nil
+		}
+	}
+	return fmt.Errorf("source can only be one of %s", strings.Join(validQuerySources, "|"))
+}
+
+// runQuery runs the PromQL query and renders its result
+func runQuery(cmd *cobra.Command, argv []string) error {
+	if err := validateSource(queryArgs.source); err != nil {
+		return err
+	}
+	switch queryArgs.output {
+	case outputTable, outputJSON:
+	case outputSparkline:
+		if queryArgs.queryRange == 0 {
+			return fmt.Errorf("the %s output is only available for range queries, please specify --range", outputSparkline)
+		}
+	default:
+		return fmt.Errorf("output can only be one of %s|%s|%s", outputTable, outputJSON, outputSparkline)
+	}
+
+	client := monitoring.NewClient("", http.Client{})
+	var (
+		result *monitoring.QueryResult
+		err    error
+	)
+	if queryArgs.queryRange > 0 {
+		end := time.Now()
+		step := queryArgs.step
+		if step == 0 {
+			step = max(queryArgs.queryRange/defaultRangePoints, time.Second)
+		}
+		result, err = client.QueryRange(queryArgs.source, argv[0], end.Add(-queryArgs.queryRange), end, step)
+	} else {
+		result, err = client.Query(queryArgs.source, argv[0], time.Time{})
+	}
+	if err != nil {
+		return err
+	}
+
+	return renderQueryResult(result, queryArgs.output)
+}
+
+// runAlerts lists the alerts and renders them
+func runAlerts(cmd *cobra.Command, argv []string) error {
+	if err := validateSource(queryArgs.source); err != nil {
+		return err
+	}
+	if queryArgs.output != outputTable && queryArgs.output != outputJSON {
+		return fmt.Errorf("output can only be one of %s|%s", outputTable, outputJSON)
+	}
+
+	alerts, err := monitoring.NewClient("", http.Client{}).Alerts(queryArgs.source, queryArgs.firingOnly)
+	if err != nil {
+		return err
+	}
+
+	return renderAlerts(alerts, queryArgs.output)
+}
+
+// renderQueryResult prints the query result in the given output format
+func renderQueryResult(result *monitoring.QueryResult, output string) error {
+	if output == outputJSON {
+		return utils.RenderJSONBytes(result)
+	}
+	if len(result.Series) == 0 {
+		fmt.Println("No data")
+		return nil
+	}
+
+	switch {
+	case output == outputSparkline:
+		rows := [][]string{}
+		for _, s := range result.Series {
+			minValue, maxValue, lastValue := summarize(s.Values())
+			rows = append(rows, []string{s.Name(), utils.RenderSparkline(s.Values()), formatValue(minValue), formatValue(maxValue), formatValue(lastValue)})
+		}
+		utils.RenderTable([]string{"SERIES", "TREND", "MIN", "MAX", "LAST"}, rows)
+	case result.ResultType == "matrix":
+		rows := [][]string{}
+		for _, s := range result.Series {
+			minValue, maxValue, lastValue := summarize(s.Values())
+			rows = append(rows, []string{s.Name(), strconv.Itoa(len(s.Samples)), formatValue(minValue), formatValue(maxValue), formatValue(lastValue)})
+		}
+		utils.RenderTable([]string{"SERIES", "SAMPLES", "MIN", "MAX", "LAST"}, rows)
+	default:
+		rows := [][]string{}
+		for _, s := range result.Series {
+			for _, sample := range s.Samples {
+				rows = append(rows, []string{s.Name(), formatValue(sample.Value), sample.Timestamp.Format(time.RFC3339)})
+			}
+		}
+		utils.RenderTable([]string{"SERIES", "VALUE", "TIMESTAMP"}, rows)
+	}
+	return nil
+}
+
+// renderAlerts prints the alerts in the given output format
+func renderAlerts(alerts []monitoring.Alert, output string) error {
+	if output == outputJSON {
+		return utils.RenderJSONBytes(alerts)
+	}
+	if len(alerts) == 0 {
+		fmt.Println("No alerts")
+		return nil
+	}
+
+	rows := [][]string{}
+	for _, alert := range alerts {
+		rows = append(rows, []string{
+			alert.Labels["alertname"],
+			alert.Labels["severity"],
+			alert.State,
+			alert.Labels["namespace"],
+			alert.ActiveAt.Format(time.RFC3339),
+			alert.Annotations["summary"],
+		})
+	}
+	utils.RenderTable([]string{"ALERT", "SEVERITY", "STATE", "NAMESPACE", "ACTIVE SINCE", "SUMMARY"}, rows)
+	return nil
+}
+
+// summarize returns the minimum, maximum and last of the values
+func summarize(values []float64) (minValue, maxValue, lastValue float64) {
+	if len(values) == 0 {
+		return 0, 0, 0
+	}
+	minValue, maxValue = values[0], values[0]
+	for _, v := range values {
+		minValue = min(minValue, v)
+		maxValue = max(maxValue, v)
+	}
+	return minValue, maxValue, values[len(values)-1]
+}
+
+// formatValue formats a sample value without trailing zeros
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,230 @@
+package monitoring
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	logger "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/openshift/backplane-cli/pkg/login"
+	"github.com/openshift/backplane-cli/pkg/monitoring"
+	"github.com/openshift/backplane-cli/pkg/ocm"
+	"github.com/openshift/backplane-cli/pkg/utils"
+)
+
+var silenceArgs struct {
+	matchers  []string
+	duration  time.Duration
+	comment   string
+	createdBy string
+	all       bool
+	output    string
+}
+
+// newSilenceCmd returns the command managing alertmanager silences
+func newSilenceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "silence",
+		Short: "Manage the alertmanager silences of the cluster monitoring stack",
+		Long: `Create, list and expire alertmanager silences through the backplane monitoring endpoint
+of the currently logged in cluster.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+	}
+
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a silence",
+		Long: `Create a silence for the alerts matching all the given matchers.
+
+The comment defaults to the elevation reasons of the current context, e.g. the PagerDuty incident or
+OHSS ticket given at login, and the creator defaults to the username of the OCM token.`,
+		Example:      " backplane monitoring silence create -m alertname=KubePodCrashLooping -m namespace=~\"openshift-.*\" --duration 2h",
+		Args:         cobra.NoArgs,
+		RunE:         runCreateSilence,
+		SilenceUsage: true,
+	}
+	createFlags := createCmd.Flags()
+	createFlags.StringArrayVarP(
+		&silenceArgs.matchers,
+		"matcher",
+		"m",
+		nil,
+		"Label matcher of the alerts to silence, e.g. alertname=Watchdog. One of =|!=|=~|!~ operators. Can be repeated.",
+	)
+	createFlags.DurationVarP(
+		&silenceArgs.duration,
+		"duration",
+		"d",
+		time.Hour,
+		"How long the silence lasts from now.",
+	)
+	createFlags.StringVarP(
+		&silenceArgs.comment,
+		"comment",
+		"c",
+		"",
+		"The comment of the silence. Default: the elevation reasons of the current context",
+	)
+	createFlags.StringVar(
+		&silenceArgs.createdBy,
+		"created-by",
+		"",
+		"The creator of the silence. Default: the username of the OCM token",
+	)
+	_ = createCmd.MarkFlagRequired("matcher")
+
+	listCmd := &cobra.Command{
+		Use:          "list",
+		Short:        "List the silences",
+		Example:      " backplane monitoring silence list\n backplane monitoring silence list --all -o json",
+		Args:         cobra.NoArgs,
+		RunE:         runListSilences,
+		SilenceUsage: true,
+	}
+	listFlags := listCmd.Flags()
+	listFlags.BoolVar(
+		&silenceArgs.all,
+		"all",
+		false,
+		"Also list expired silences.",
+	)
+	listFlags.StringVarP(
+		&silenceArgs.output,
+		"output",
+		"o",
+		outputTable,
+		fmt.Sprintf("Format the output of the silences. One of %s|%s", outputTable, outputJSON),
+	)
+
+	expireCmd := &cobra.Command{
+		Use:          "expire <silence-id> [<silence-id>...]",
+		Short:        "Expire silences",
+		Example:      " backplane monitoring silence expire 2f3f6e5d-8b0e-4a5e-9b8e-d3f1b4c2a1e0",
+		Args:         cobra.MinimumNArgs(1),
+		RunE:         runExpireSilences,
+		SilenceUsage: true,
+	}
+
+	for _, c := range []*cobra.Command{createCmd, listCmd, expireCmd} {
+		addNamespaceFlag(c)
+		cmd.AddCommand(c)
+	}
+	return cmd
+}
+
+// runCreateSilence creates the silence and prints its ID
+func runCreateSilence(cmd *cobra.Command, argv []string) error {
+	matchers, err := monitoring.ParseMatchers(silenceArgs.matchers)
+	if err != nil {
+		return err
+	}
+	if silenceArgs.duration <= 0 {
+		return fmt.Errorf("duration should be a positive duration")
+	}
+
+	comment := silenceArgs.comment
+	if comment == "" {
+		comment = defaultSilenceComment()
+		if comment == "" {
+			return fmt.Errorf("no elevation reason found in the current context, please specify a comment with --comment")
+		}
+	}
+	createdBy := silenceArgs.createdBy
+	if createdBy == "" {
+		createdBy, err = defaultSilenceCreator()
+		if err != nil {
+			return err
+		}
+	}
+
+	start := time.Now()
+	silence := monitoring.Silence{
+		Matchers:  matchers,
+		StartsAt:  start,
+		EndsAt:    start.Add(silenceArgs.duration),
+		CreatedBy: createdBy,
+		Comment:   comment,
+	}
+	id, err := monitoring.NewClient("", http.Client{}).CreateSilence(silence)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Created silence %s until %s\n", id, silence.EndsAt.Format(time.RFC3339))
+	return nil
+}
+
+// runListSilences lists the silences and renders them
+func runListSilences(cmd *cobra.Command, argv []string) error {
+	if silenceArgs.output != outputTable && silenceArgs.output != outputJSON {
+		return fmt.Errorf("output can only be one of %s|%s", outputTable, outputJSON)
+	}
+
+	silences, err := monitoring.NewClient("", http.Client{}).ListSilences(silenceArgs.all)
+	if err != nil {
+		return err
+	}
+
+	if silenceArgs.output == outputJSON {
+		return utils.RenderJSONBytes(silences)
+	}
+	if len(silences) == 0 {
+		fmt.Println("No silences")
+		return nil
+	}
+
+	rows := [][]string{}
+	for _, s := range silences {
+		matchers := []string{}
+		for _, m := range s.Matchers {
+			matchers = append(matchers, m.String())
+		}
+		rows = append(rows, []string{
+			s.ID,
+			s.State(),
+			strings.Join(matchers, ", "),
+			s.EndsAt.Format(time.RFC3339),
+			s.CreatedBy,
+			s.Comment,
+		})
+	}
+	utils.RenderTable([]string{"ID", "STATE", "MATCHERS", "ENDS AT", "CREATED BY", "COMMENT"}, rows)
+	return nil
+}
+
+// runExpireSilences expires the given silences
+func runExpireSilences(cmd *cobra.Command, argv []string) error {
+	client := monitoring.NewClient("", http.Client{})
+	for _, id := range argv {
+		if err := client.ExpireSilence(id); err != nil {
+			return fmt.Errorf("failed to expire silence %s: %v", id, err)
+		}
+		fmt.Printf("Expired silence %s\n", id)
+	}
+	return nil
+}
+
+// defaultSilenceComment returns the elevation reasons of the current context,
+// which holds the PagerDuty incident or OHSS ticket given at login
+func defaultSilenceComment() string {
+	config, err := clientcmd.NewDefaultPathOptions().GetStartingConfig()
+	if err != nil {
+		logger.Debugf("cannot read the kubeconfig for elevation reasons: %v", err)
+		return ""
+	}
+	return strings.Join(login.GetElevateContextReasons(*config), ", ")
+}
+
+// defaultSilenceCreator returns the username of the OCM token
+func defaultSilenceCreator() (string, error) {
+	accessToken, err := ocm.DefaultOCMInterface.GetOCMAccessToken()
+	if err != nil {
+		return "", err
+	}
+	return utils.GetUsernameFromJWT(*accessToken), nil
+}
@@ -0,0 +1,102 @@
+package monitoring
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/backplane-cli/pkg/monitoring"
+	"github.com/openshift/backplane-cli/pkg/utils"
+)
+
+var snapshotArgs struct {
+	since     time.Duration
+	outputDir string
+}
+
+// newSnapshotCmd returns the command capturing a monitoring snapshot
+func newSnapshotCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Capture a point-in-time monitoring snapshot of the cluster",
+		Long: `Capture the firing alerts, the scrape targets which are down and a set of cluster health series over the
+given duration, through the backplane monitoring endpoint of the currently logged in cluster. They are written
+with the cluster ID and the OCM description of the cluster into a timestamped tarball, for post-incident review.`,
+		Example:      " backplane monitoring snapshot --since 2h\n backplane monitoring snapshot --since 30m --output-dir /tmp",
+		Args:         cobra.NoArgs,
+		RunE:         runSnapshot,
+		SilenceUsage: true,
+	}
+
+	flags := cmd.Flags()
+	flags.DurationVar(
+		&snapshotArgs.since,
+		"since",
+		time.Hour,
+		"The duration until now covered by the health series, e.g. 2h.",
+	)
+	flags.StringVar(
+		&snapshotArgs.outputDir,
+		"output-dir",
+		".",
+		"The directory the snapshot tarball is written to.",
+	)
+	addNamespaceFlag(cmd)
+
+	return cmd
+}
+
+// runSnapshot captures the snapshot and writes its tarball
+func runSnapshot(cmd *cobra.Command, argv []string) error {
+	clusterInfo, err := utils.DefaultClusterUtils.GetBackplaneClusterFromConfig()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Capturing the monitoring snapshot of cluster %s over the last %s\n", clusterInfo.ClusterID, snapshotArgs.since)
+	snapshot, err := monitoring.NewClient("", http.Client{}).TakeSnapshot(clusterInfo.ClusterID, snapshotArgs.since)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(snapshotArgs.outputDir, snapshot.Filename())
+	if err := writeSnapshot(snapshot, path); err != nil {
+		return err
+	}
+
+	if len(snapshot.Errors) > 0 {
+		fmt.Printf("Snapshot written to %s, %d item(s) could not be captured, see metadata.json\n", path, len(snapshot.Errors))
+		return nil
+	}
+	fmt.Printf("Snapshot written to %s\n", path)
+	return nil
+}
+
+// writeSnapshot writes the snapshot archive to a temporary file renamed to path once complete,
+// so that a failed write does not leave a partial snapshot behind
+func writeSnapshot(snapshot *monitoring.Snapshot, path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("failed to create the snapshot file: %s already exists", path)
+	}
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return fmt.Errorf("failed to create the snapshot file: %v", err)
+	}
+
+	err = snapshot.WriteArchive(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return fmt.Errorf("failed to write the snapshot: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+package note
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/andygrunwald/go-jira"
+	logger "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/backplane-cli/pkg/cli/session"
+	jiraClient "github.com/openshift/backplane-cli/pkg/jira"
+	"github.com/openshift/backplane-cli/pkg/login"
+	"github.com/openshift/backplane-cli/pkg/pagerduty"
+	"github.com/openshift/backplane-cli/pkg/utils"
+)
+
+const (
+	targetPagerDuty = "PagerDuty incident"
+	targetJira      = "JIRA issue"
+)
+
+var (
+	jiraBrowsePattern = regexp.MustCompile(`/browse/([A-Z][A-Z0-9]+-[0-9]+)`)
+	jiraKeyPattern    = regexp.MustCompile(`^[A-Z][A-Z0-9]+-[0-9]+$`)
+
+	// newPagerDuty returns the PagerDuty client, overridden in tests
+	newPagerDuty = pagerduty.NewWithConfiguredKey
+)
+
+// noteTarget is the PagerDuty incident or the JIRA issue a note is posted to
+type noteTarget struct {
+	Kind string
+	ID   string
+}
+
+type noteOptions struct {
+	to              string
+	session         string
+	history         int
+	transcript      bool
+	transcriptLines int
+	dryRun          bool
+}
+
+func NewNoteCmd() *cobra.Command {
+	opts := noteOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "note <text>",
+		Short: "Post a note to the PagerDuty incident or the OHSS issue the current login is for",
+		Long: `Post a note to the PagerDuty incident or the OHSS issue the current login is for.
+
+The incident or the issue is found from the elevation reasons of the current context,
+saved by 'login --pd' and 'login --jira' or by 'elevate', unless --to is set.
+The command history and the latest transcript of the current session can be attached to the note.`,
+		Example: ` ocm-backplane note "Restarted the stuck ingress controller pods"
+ ocm-backplane note "Found the root cause" --history 20 --transcript
+ ocm-backplane note "Handing over to the next shift" --to OHSS-12345`,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runNote(args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.to, "to", "", "The PagerDuty incident link or ID, or the JIRA issue link or key to post the note to, instead of the one of the current context")
+	cmd.Flags().StringVar(&opts.session, "session", "", "The session to attach the history or the transcript of, the current session by default")
+	cmd.Flags().IntVar(&opts.history, "history", 0, "Attach the given number of the last commands of the session history")
+	cmd.Flags().BoolVar(&opts.transcript, "transcript", false, "Attach a summary of the latest transcript of the session")
+	cmd.Flags().IntVar(&opts.transcriptLines, "transcript-lines", 30, "The number of the last output lines of the transcript to attach")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Print the note and where it would be posted without posting it")
+
+	return cmd
+}
+
+// runNote posts the note along with its attachments
+func runNote(text string, opts noteOptions) error {
+	if strings.TrimSpace(text) == "" {
+		return errors.New("the note cannot be empty")
+	}
+
+	target, err := resolveTarget(opts.to)
+	if err != nil {
+		return err
+	}
+
+	attachments, err := sessionAttachments(opts)
+	if err != nil {
+		return err
+	}
+	content := formatNote(target, text, attachments)
+
+	if opts.dryRun {
+		fmt.Printf("Note to post to %s %s:\n%s\n", target.Kind, target.ID, content)
+		return nil
+	}
+
+	if err := postNote(target, content); err != nil {
+		return err
+	}
+	fmt.Printf("Note posted to %s %s\n", target.Kind, target.ID)
+	return nil
+}
+
+// resolveTarget returns the target of the given reference, or the one of the elevation reasons of the current context
+func resolveTarget(reference string) (noteTarget, error) {
+	if reference != "" {
+		if target, ok := parseTarget(reference); ok {
+			return target, nil
+		}
+		// unlike the free text elevation reasons, an explicit reference which is not a link nor a JIRA key is an incident ID
+		return noteTarget{Kind: targetPagerDuty, ID: strings.TrimSpace(reference)}, nil
+	}
+
+	config, err := utils.ReadKubeconfigRaw()
+	if err != nil {
+		return noteTarget{}, fmt.Errorf("failed to read the kube config: %v", err)
+	}
+	return findTarget(login.GetElevateContextReasons(config))
+}
+
+// findTarget returns the target of the most recent elevation reason referencing an incident or an issue
+func findTarget(reasons []string) (noteTarget, error) {
+	for i := len(reasons) - 1; i >= 0; i-- {
+		if target, ok := parseTarget(reasons[i]); ok {
+			return target, nil
+		}
+	}
+	return noteTarget{}, errors.New("no PagerDuty incident or JIRA issue found in the elevation reasons of the current context, " +
+		"log in with --pd or --jira or pass the incident or the issue with --to")
+}
+
+// parseTarget returns the target referenced by a PagerDuty incident link, or a JIRA issue link or key
+func parseTarget(reference string) (noteTarget, bool) {
+	reference = strings.TrimSpace(reference)
+	if strings.Contains(reference, "/incidents/") {
+		return noteTarget{Kind: targetPagerDuty, ID: pagerduty.ParseIncidentID(reference)}, true
+	}
+	if match := jiraBrowsePattern.FindStringSubmatch(reference); match != nil {
+		return noteTarget{Kind: targetJira, ID: match[1]}, true
+	}
+	if jiraKeyPattern.MatchString(reference) {
+		return noteTarget{Kind: targetJira, ID: reference}, true
+	}
+	return noteTarget{}, false
+}
+
+// sessionAttachments returns the history and the transcript summary of the session to attach to the note
+func sessionAttachments(opts noteOptions) (map[string]string, error) {
+	attachments := map[string]string{}
+	if opts.history <= 0 && !opts.transcript {
+		return attachments, nil
+	}
+
+	var sessionPath string
+	var err error
+	if opts.session != "" {
+		sessionPath, err = session.GetSessionPath(opts.session)
+	} else {
+		sessionPath, err = session.CurrentSessionPath()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't attach the session history or transcript: %v, consider using --session", err)
+	}
+
+	if opts.history > 0 {
+		commands, err := session.ReadHistory(sessionPath, opts.history)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the session history: %v", err)
+		}
+		if len(commands) == 0 {
+			logger.Warnf("the history of the session is empty")
+		} else {
+			attachments["Command history"] = strings.Join(commands, "\n")
+		}
+	}
+
+	if opts.transcript {
+		summary, err := session.SummarizeTranscript(sessionPath, opts.transcriptLines)
+		if err != nil {
+			return nil, err
+		}
+		attachments["Session transcript"] = summary
+	}
+
+	return attachments, nil
+}
+
+// formatNote returns the note content with its attachments, as preformatted text for JIRA
+func formatNote(target noteTarget, text string, attachments map[string]string) string {
+	content := text
+	for _, title := range []string{"Command history", "Session transcript"} {
+		attachment, ok := attachments[title]
+		if !ok {
+			continue
+		}
+		if target.Kind == targetJira {
+			content += fmt.Sprintf("\n\n*%s*\n{noformat}\n%s\n{noformat}", title, attachment)
+		} else {
+			content += fmt.Sprintf("\n\n%s:\n%s", title, attachment)
+		}
+	}
+	return content
+}
+
+// postNote posts the note to the incident or the issue
+func postNote(target noteTarget, content string) error {
+	switch target.Kind {
+	case targetPagerDuty:
+		pd, err := newPagerDuty()
+		if err != nil {
+			return err
+		}
+		return pd.AddIncidentNote(target.ID, content)
+	case targetJira:
+		if _, _, err := jiraClient.DefaultIssueService.AddComment(target.ID, &jira.Comment{Body: content}); err != nil {
+			return fmt.Errorf("failed to comment the %s JIRA issue: %v", target.ID, err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown note target %s", target.Kind)
+	}
+}
@@ -0,0 +1,107 @@
+package note
+
+import (
+	"errors"
+
+	"github.com/andygrunwald/go-jira"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	jiraClient "github.com/openshift/backplane-cli/pkg/jira"
+	jiraMock "github.com/openshift/backplane-cli/pkg/jira/mocks"
+)
+
+var _ = Describe("note", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockIssueService *jiraMock.MockIssueServiceInterface
+		origIssueService jiraClient.IssueServiceInterface
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockIssueService = jiraMock.NewMockIssueServiceInterface(mockCtrl)
+		origIssueService = jiraClient.DefaultIssueService
+		jiraClient.DefaultIssueService = mockIssueService
+	})
+
+	AfterEach(func() {
+		jiraClient.DefaultIssueService = origIssueService
+		mockCtrl.Finish()
+	})
+
+	Context("targets", func() {
+		It("should parse the incident and issue references", func() {
+			target, ok := parseTarget("https://example.pagerduty.com/incidents/Q0ZNH7TDQBOO54")
+			Expect(ok).To(BeTrue())
+			Expect(target).To(Equal(noteTarget{Kind: targetPagerDuty, ID: "Q0ZNH7TDQBOO54"}))
+
+			target, ok = parseTarget("https://issues.example.com/browse/OHSS-1234")
+			Expect(ok).To(BeTrue())
+			Expect(target).To(Equal(noteTarget{Kind: targetJira, ID: "OHSS-1234"}))
+
+			target, ok = parseTarget("OHSS-1234")
+			Expect(ok).To(BeTrue())
+			Expect(target).To(Equal(noteTarget{Kind: targetJira, ID: "OHSS-1234"}))
+
+			_, ok = parseTarget("investigating a node issue")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should use the most recent elevation reason referencing an incident or an issue", func() {
+			target, err := findTarget([]string{
+				"https://example.pagerduty.com/incidents/Q0ZNH7TDQBOO54",
+				"https://issues.example.com/browse/OHSS-1234",
+				"checking the nodes",
+			})
+			Expect(err).To(BeNil())
+			Expect(target).To(Equal(noteTarget{Kind: targetJira, ID: "OHSS-1234"}))
+
+			_, err = findTarget([]string{"checking the nodes"})
+			Expect(err).To(MatchError(ContainSubstring("no PagerDuty incident or JIRA issue found")))
+		})
+
+		It("should take an explicit reference which is not a link as an incident ID", func() {
+			target, err := resolveTarget("Q0ZNH7TDQBOO54")
+			Expect(err).To(BeNil())
+			Expect(target).To(Equal(noteTarget{Kind: targetPagerDuty, ID: "Q0ZNH7TDQBOO54"}))
+		})
+	})
+
+	Context("content", func() {
+		It("should format the attachments for JIRA and PagerDuty", func() {
+			attachments := map[string]string{"Session transcript": "output", "Command history": "oc get nodes"}
+
+			Expect(formatNote(noteTarget{Kind: targetJira}, "root cause found", attachments)).To(Equal(
+				"root cause found\n\n*Command history*\n{noformat}\noc get nodes\n{noformat}\n\n*Session transcript*\n{noformat}\noutput\n{noformat}"))
+			Expect(formatNote(noteTarget{Kind: targetPagerDuty}, "root cause found", attachments)).To(Equal(
+				"root cause found\n\nCommand history:\noc get nodes\n\nSession transcript:\noutput"))
+		})
+	})
+
+	Context("posting", func() {
+		It("should comment the JIRA issue", func() {
+			mockIssueService.EXPECT().AddComment("OHSS-1234", &jira.Comment{Body: "root cause found"}).Return(&jira.Comment{}, nil, nil)
+
+			cmd := NewNoteCmd()
+			cmd.SetArgs([]string{"root cause found", "--to", "OHSS-1234"})
+			Expect(cmd.Execute()).To(Succeed())
+		})
+
+		It("should report the failure to comment the JIRA issue", func() {
+			mockIssueService.EXPECT().AddComment("OHSS-1234", gomock.Any()).Return(nil, nil, errors.New("forbidden"))
+
+			err := runNote("root cause found", noteOptions{to: "OHSS-1234"})
+			Expect(err).To(MatchError("failed to comment the OHSS-1234 JIRA issue: forbidden"))
+		})
+
+		It("should not post anything in dry-run mode", func() {
+			Expect(runNote("root cause found", noteOptions{to: "OHSS-1234", dryRun: true})).To(Succeed())
+		})
+
+		It("should reject an empty note", func() {
+			Expect(runNote("  ", noteOptions{to: "OHSS-1234"})).To(MatchError("the note cannot be empty"))
+		})
+	})
+})
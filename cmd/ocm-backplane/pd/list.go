@@ -0,0 +1,111 @@
+package pd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/backplane-cli/pkg/pagerduty"
+	"github.com/openshift/backplane-cli/pkg/utils"
+)
+
+// newListCmd returns cobra command
+func newListCmd() *cobra.Command {
+	var (
+		statuses []string
+		login    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the incidents assigned to the current user, including the ones escalated to the user",
+		Example: ` ocm-backplane pd list
+ ocm-backplane pd list --status triggered,acknowledged,resolved
+ ocm-backplane pd list --login`,
+		Args:          cobra.ExactArgs(0),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(statuses, login)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(
+		&statuses,
+		"status",
+		"s",
+		[]string{pagerduty.StatusTriggered, pagerduty.StatusAcknowledged},
+		"Only list the incidents in the given statuses: triggered, acknowledged or resolved")
+	_ = cmd.RegisterFlagCompletionFunc("status", cobra.FixedCompletions(
+		[]string{pagerduty.StatusTriggered, pagerduty.StatusAcknowledged, pagerduty.StatusResolved}, cobra.ShellCompDirectiveNoFileComp))
+
+	cmd.Flags().BoolVarP(
+		&login,
+		"login",
+		"l",
+		false,
+		"Prompt for the number of an incident and log into its cluster")
+
+	return cmd
+}
+
+// runList lists the incidents of the current user, and logs into the cluster of the selected one when asked to
+func runList(statuses []string, login bool) error {
+	pd, err := newPagerDuty()
+	if err != nil {
+		return err
+	}
+
+	incidents, err := pd.ListMyIncidents(statuses)
+	if err != nil {
+		return err
+	}
+
+	if len(incidents) == 0 {
+		fmt.Printf("No %s incident assigned to you\n", strings.Join(statuses, " or "))
+		return nil
+	}
+
+	printIncidents(incidents, time.Now())
+
+	if !login {
+		return nil
+	}
+
+	incident, err := selectIncident(incidents, utils.AskQuestionFromPrompt(fmt.Sprintf("Log into the cluster of incident [1-%d]: ", len(incidents))))
+	if err != nil {
+		return err
+	}
+
+	return loginToIncidentCluster(incident.ID)
+}
+
+// printIncidents prints the incidents as a numbered table
+func printIncidents(incidents []pagerduty.Incident, now time.Time) {
+	headers := []string{"#", "ID", "STATUS", "URGENCY", "AGE", "CLUSTER", "TITLE"}
+	rows := [][]string{}
+	for i, incident := range incidents {
+		rows = append(rows, []string{
+			strconv.Itoa(i + 1),
+			incident.ID,
+			incident.Status,
+			incident.Urgency,
+			now.Sub(incident.CreatedAt).Round(time.Minute).String(),
+			incident.ClusterName,
+			incident.Title,
+		})
+	}
+	utils.RenderTable(headers, rows)
+}
+
+// selectIncident returns the incident of the given number in the list
+func selectIncident(incidents []pagerduty.Incident, answer string) (pagerduty.Incident, error) {
+	number, err := strconv.Atoi(strings.TrimSpace(answer))
+	if err != nil || number < 1 || number > len(incidents) {
+		return pagerduty.Incident{}, fmt.Errorf("'%s' is not an incident number between 1 and %d", answer, len(incidents))
+	}
+	return incidents[number-1], nil
+}
@@ -0,0 +1,53 @@
+package pd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/backplane-cli/cmd/ocm-backplane/login"
+	"github.com/openshift/backplane-cli/pkg/pagerduty"
+)
+
+// newPagerDuty returns the PagerDuty client, overridden in tests
+var newPagerDuty = pagerduty.NewWithConfiguredKey
+
+// loginToIncidentCluster logs into the cluster of the incident, as 'ocm-backplane login --pd <incident>' does; overridden in tests
+var loginToIncidentCluster = func(incidentID string) error {
+	if err := login.LoginCmd.Flags().Set("pd", incidentID); err != nil {
+		return err
+	}
+	if err := login.LoginCmd.PreRunE(login.LoginCmd, []string{}); err != nil {
+		return err
+	}
+	return login.LoginCmd.RunE(login.LoginCmd, []string{})
+}
+
+func NewPDCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "pd",
+		Aliases:      []string{"pagerduty"},
+		Short:        "Manages the PagerDuty incidents of the current user",
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newUpdateCmd("ack", "Acknowledge the given incidents", (*pagerduty.PagerDuty).AcknowledgeIncident))
+	cmd.AddCommand(newUpdateCmd("resolve", "Resolve the given incidents", (*pagerduty.PagerDuty).ResolveIncident))
+	cmd.AddCommand(newNoteCmd())
+	cmd.AddCommand(newLoginCmd())
+
+	return cmd
+}
+
+// newLoginCmd returns cobra command
+func newLoginCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:           "login <incident-id|incident-url>",
+		Short:         "Log into the cluster of the given incident",
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return loginToIncidentCluster(pagerduty.ParseIncidentID(args[0]))
+		},
+	}
+}
@@ -0,0 +1,75 @@
+package pd
+
+import (
+	pdApi "github.com/PagerDuty/go-pagerduty"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift/backplane-cli/pkg/pagerduty"
+	pdMock "github.com/openshift/backplane-cli/pkg/pagerduty/mocks"
+)
+
+var _ = Describe("pd command", func() {
+	var (
+		mockCtrl      *gomock.Controller
+		mockPdClient  *pdMock.MockPagerDutyClient
+		loggedInto    []string
+		origNewPD     func() (*pagerduty.PagerDuty, error)
+		origLoginFunc func(string) error
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockPdClient = pdMock.NewMockPagerDutyClient(mockCtrl)
+
+		origNewPD = newPagerDuty
+		newPagerDuty = func() (*pagerduty.PagerDuty, error) { return pagerduty.NewPagerDuty(mockPdClient), nil }
+		origLoginFunc = loginToIncidentCluster
+		loggedInto = []string{}
+		loginToIncidentCluster = func(incidentID string) error {
+			loggedInto = append(loggedInto, incidentID)
+			return nil
+		}
+	})
+
+	AfterEach(func() {
+		newPagerDuty = origNewPD
+		loginToIncidentCluster = origLoginFunc
+		mockCtrl.Finish()
+	})
+
+	It("should acknowledge every given incident", func() {
+		mockPdClient.EXPECT().GetCurrentUser(gomock.Any()).Return(&pdApi.User{Email: "sre@example.com"}, nil).Times(2)
+		mockPdClient.EXPECT().ManageIncidents("sre@example.com", []pdApi.ManageIncidentsOptions{{ID: "inc-1", Status: pagerduty.StatusAcknowledged}}).Return(nil, nil)
+		mockPdClient.EXPECT().ManageIncidents("sre@example.com", []pdApi.ManageIncidentsOptions{{ID: "inc-2", Status: pagerduty.StatusAcknowledged}}).Return(nil, nil)
+
+		cmd := NewPDCmd()
+		cmd.SetArgs([]string{"ack", "inc-1", "https://example.pagerduty.com/incidents/inc-2"})
+		Expect(cmd.Execute()).To(Succeed())
+	})
+
+	It("should log into the cluster of the incident", func() {
+		cmd := NewPDCmd()
+		cmd.SetArgs([]string{"login", "https://example.pagerduty.com/incidents/inc-1"})
+		Expect(cmd.Execute()).To(Succeed())
+		Expect(loggedInto).To(Equal([]string{"inc-1"}))
+	})
+
+	It("should reject an empty note", func() {
+		cmd := NewPDCmd()
+		cmd.SetArgs([]string{"note", "inc-1", " "})
+		Expect(cmd.Execute()).To(MatchError("the note cannot be empty"))
+	})
+
+	It("should select the incident by its number", func() {
+		incidents := []pagerduty.Incident{{ID: "inc-1"}, {ID: "inc-2"}}
+
+		incident, err := selectIncident(incidents, " 2\n")
+		Expect(err).To(BeNil())
+		Expect(incident.ID).To(Equal("inc-2"))
+
+		_, err = selectIncident(incidents, "3")
+		Expect(err).To(MatchError(ContainSubstring("not an incident number between 1 and 2")))
+	})
+})
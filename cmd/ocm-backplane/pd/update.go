@@ -0,0 +1,69 @@
+package pd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/backplane-cli/pkg/pagerduty"
+)
+
+// newUpdateCmd returns the cobra command applying the update to the given incidents
+func newUpdateCmd(use, short string, update func(*pagerduty.PagerDuty, string) error) *cobra.Command {
+	return &cobra.Command{
+		Use:           use + " <incident-id|incident-url>...",
+		Short:         short,
+		Args:          cobra.MinimumNArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pd, err := newPagerDuty()
+			if err != nil {
+				return err
+			}
+
+			var failures []string
+			for _, arg := range args {
+				incidentID := pagerduty.ParseIncidentID(arg)
+				if err := update(pd, incidentID); err != nil {
+					failures = append(failures, err.Error())
+					continue
+				}
+				fmt.Printf("Incident %s updated\n", incidentID)
+			}
+			if len(failures) > 0 {
+				return fmt.Errorf("failed to update %d incident(s):\n%s", len(failures), strings.Join(failures, "\n"))
+			}
+			return nil
+		},
+	}
+}
+
+// newNoteCmd returns cobra command
+func newNoteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:           "note <incident-id|incident-url> <text>",
+		Short:         "Add a note to the given incident",
+		Args:          cobra.ExactArgs(2),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if strings.TrimSpace(args[1]) == "" {
+				return fmt.Errorf("the note cannot be empty")
+			}
+
+			pd, err := newPagerDuty()
+			if err != nil {
+				return err
+			}
+
+			incidentID := pagerduty.ParseIncidentID(args[0])
+			if err := pd.AddIncidentNote(incidentID, args[1]); err != nil {
+				return err
+			}
+			fmt.Printf("Note added to incident %s\n", incidentID)
+			return nil
+		},
+	}
+}
@@ -26,20 +26,25 @@ import (
 	"github.com/openshift/backplane-cli/cmd/ocm-backplane/cloud"
 	"github.com/openshift/backplane-cli/cmd/ocm-backplane/config"
 	"github.com/openshift/backplane-cli/cmd/ocm-backplane/console"
+	"github.com/openshift/backplane-cli/cmd/ocm-backplane/diagnose"
 	"github.com/openshift/backplane-cli/cmd/ocm-backplane/elevate"
 	healthcheck "github.com/openshift/backplane-cli/cmd/ocm-backplane/healthcheck"
 	"github.com/openshift/backplane-cli/cmd/ocm-backplane/login"
 	"github.com/openshift/backplane-cli/cmd/ocm-backplane/logout"
 	managedjob "github.com/openshift/backplane-cli/cmd/ocm-backplane/managedJob"
 	"github.com/openshift/backplane-cli/cmd/ocm-backplane/monitoring"
+	"github.com/openshift/backplane-cli/cmd/ocm-backplane/note"
+	"github.com/openshift/backplane-cli/cmd/ocm-backplane/pd"
 	"github.com/openshift/backplane-cli/cmd/ocm-backplane/remediation"
 	"github.com/openshift/backplane-cli/cmd/ocm-backplane/script"
 	"github.com/openshift/backplane-cli/cmd/ocm-backplane/session"
+	"github.com/openshift/backplane-cli/cmd/ocm-backplane/shellinit"
 	"github.com/openshift/backplane-cli/cmd/ocm-backplane/status"
 	testjob "github.com/openshift/backplane-cli/cmd/ocm-backplane/testJob"
 	"github.com/openshift/backplane-cli/cmd/ocm-backplane/upgrade"
 	"github.com/openshift/backplane-cli/cmd/ocm-backplane/version"
 	"github.com/openshift/backplane-cli/pkg/cli/globalflags"
+	"github.com/openshift/backplane-cli/pkg/releasecheck"
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -51,6 +56,10 @@ var rootCmd = &cobra.Command{
        which get a proxy url from backplane for the target cluster.
 	   After login, users can use oc command to operate the target cluster`,
 	SilenceErrors: true,
+	// Give the release check refreshed in the background by some commands the time to be saved
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		releasecheck.Wait(releasecheck.WaitTimeout)
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -65,6 +74,10 @@ func Execute() {
 func init() {
 	// Add Verbosity flag for all commands
 	globalflags.AddVerbosityFlag(rootCmd)
+	// Add Profile flag for all commands
+	globalflags.AddProfileFlag(rootCmd)
+	// Add Strict deprecation flag for all commands
+	globalflags.AddStrictDeprecationFlag(rootCmd)
 
 	// Register sub-commands
 	rootCmd.AddCommand(accessrequest.NewAccessRequestCmd())
@@ -75,13 +88,18 @@ func init() {
 	rootCmd.AddCommand(login.LoginCmd)
 	rootCmd.AddCommand(logout.LogoutCmd)
 	rootCmd.AddCommand(managedjob.NewManagedJobCmd())
+	rootCmd.AddCommand(note.NewNoteCmd())
+	rootCmd.AddCommand(pd.NewPDCmd())
 	rootCmd.AddCommand(script.NewScriptCmd())
 	rootCmd.AddCommand(status.StatusCmd)
 	rootCmd.AddCommand(session.NewCmdSession())
+	rootCmd.AddCommand(shellinit.NewShellInitCmd())
+	rootCmd.AddCommand(shellinit.NewEnvCmd())
 	rootCmd.AddCommand(testjob.NewTestJobCommand())
 	rootCmd.AddCommand(upgrade.UpgradeCmd)
 	rootCmd.AddCommand(version.VersionCmd)
 	rootCmd.AddCommand(monitoring.MonitoringCmd)
 	rootCmd.AddCommand(healthcheck.HealthCheckCmd)
+	rootCmd.AddCommand(diagnose.NewDiagnoseCmd())
 	rootCmd.AddCommand(remediation.NewRemediationCmd())
 }
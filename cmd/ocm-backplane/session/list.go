@@ -0,0 +1,61 @@
+package session
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/backplane-cli/pkg/cli/session"
+	"github.com/openshift/backplane-cli/pkg/utils"
+)
+
+// newListCmd returns the command listing the sessions
+func newListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:          "list",
+		Short:        "List the sessions, the most recently used first",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sessions, err := session.ListSessions()
+			if err != nil {
+				return err
+			}
+			if len(sessions) == 0 {
+				fmt.Println("No sessions")
+				return nil
+			}
+
+			rows := [][]string{}
+			for _, s := range sessions {
+				lastUsed := ""
+				if !s.LastUsed.IsZero() {
+					lastUsed = s.LastUsed.Format(time.RFC3339)
+				}
+				rows = append(rows, []string{s.Alias, s.ClusterName, s.ClusterID, lastUsed})
+			}
+			utils.RenderTable([]string{"ALIAS", "CLUSTER NAME", "CLUSTER ID", "LAST USED"}, rows)
+			return nil
+		},
+	}
+}
+
+// newRmCmd returns the command deleting sessions
+func newRmCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:          "rm <session-alias> [<session-alias>...]",
+		Short:        "Delete sessions",
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, alias := range args {
+				if err := session.RemoveSession(alias); err != nil {
+					return err
+				}
+				fmt.Printf("Deleted Backplane session %s\n", alias)
+			}
+			return nil
+		},
+	}
+}
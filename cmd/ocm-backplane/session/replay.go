@@ -0,0 +1,80 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/backplane-cli/pkg/cli/session"
+)
+
+// newReplayCmd returns the command replaying a session transcript
+func newReplayCmd() *cobra.Command {
+	opts := session.ReplayOptions{}
+
+	replayCmd := &cobra.Command{
+		Use:          "replay <session-alias>",
+		Short:        "Replay the latest transcript recorded in a session",
+		Example:      " backplane session replay my-session\n backplane session replay my-session --speed 2 --idle-time-limit 1s",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return session.Replay(args[0], os.Stdout, opts)
+		},
+	}
+
+	replayCmd.Flags().Float64Var(
+		&opts.Speed,
+		"speed",
+		1,
+		"Multiply the replay speed",
+	)
+	replayCmd.Flags().DurationVar(
+		&opts.IdleTimeLimit,
+		"idle-time-limit",
+		2*time.Second,
+		"Cap the pauses of the replay, 0 to keep them as recorded",
+	)
+
+	return replayCmd
+}
+
+// newExportCmd returns the command bundling a session for handovers
+func newExportCmd() *cobra.Command {
+	var outputDir string
+
+	exportCmd := &cobra.Command{
+		Use:          "export <session-alias>",
+		Short:        "Bundle the transcripts, history and cluster metadata of a session",
+		Example:      " backplane session export my-session --output-dir /tmp",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := filepath.Join(outputDir, session.ExportFilename(args[0], time.Now()))
+			file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0600)
+			if err != nil {
+				return fmt.Errorf("failed to create the export file: %v", err)
+			}
+			defer func() { _ = file.Close() }()
+
+			if err := session.Export(args[0], file); err != nil {
+				_ = os.Remove(path)
+				return err
+			}
+			fmt.Printf("Session %s exported to %s\n", args[0], path)
+			return nil
+		},
+	}
+
+	exportCmd.Flags().StringVar(
+		&outputDir,
+		"output-dir",
+		".",
+		"The directory the export is written to",
+	)
+
+	return exportCmd
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/backplane-cli/cmd/ocm-backplane/login"
 	"github.com/openshift/backplane-cli/pkg/cli/globalflags"
 	"github.com/openshift/backplane-cli/pkg/cli/session"
 	"github.com/openshift/backplane-cli/pkg/info"
@@ -62,6 +63,33 @@ func NewCmdSession() *cobra.Command {
 		"",
 		"The cluster to create the session for",
 	)
+	_ = sessionCmd.RegisterFlagCompletionFunc("cluster-id", login.CompleteClusterNames)
+
+	sessionCmd.Flags().BoolVar(
+		&options.Fresh,
+		"fresh",
+		false,
+		"Start the session from scratch instead of resuming it, deleting its history, bins and transcripts",
+	)
+
+	sessionCmd.Flags().BoolVar(
+		&options.Record,
+		"record",
+		false,
+		"Record a transcript of the session in the asciinema v2 format",
+	)
+
+	sessionCmd.Flags().StringSliceVar(
+		&options.Templates,
+		"template",
+		[]string{},
+		"The session templates to apply from the session-templates directory of the backplane config, all of them by default",
+	)
+
+	sessionCmd.AddCommand(newListCmd())
+	sessionCmd.AddCommand(newRmCmd())
+	sessionCmd.AddCommand(newReplayCmd())
+	sessionCmd.AddCommand(newExportCmd())
 
 	return sessionCmd
 }
@@ -0,0 +1,73 @@
+package shellinit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/backplane-cli/pkg/login"
+)
+
+// NewEnvCmd returns the command printing the export lines of the current login
+func NewEnvCmd() *cobra.Command {
+	var shell string
+
+	cmd := &cobra.Command{
+		Use:   "env",
+		Short: "Print the export lines of the current login",
+		Long: `Print the export lines of the KUBECONFIG, CLUSTERID and CLUSTERNAME variables of the current login,
+as set in the environment of a backplane session.`,
+		Example: `  eval "$(ocm-backplane env)"
+  ocm-backplane env --shell fish | source`,
+		Args:              cobra.NoArgs,
+		DisableAutoGenTag: true,
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			current, err := login.GetCurrentLogin()
+			if err != nil {
+				return fmt.Errorf("no current backplane login: %v", err)
+			}
+			lines, err := exportLines(shell, current.Env())
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprint(cmd.OutOrStdout(), lines)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&shell,
+		"shell",
+		defaultShell(),
+		"The shell to print the export lines for: bash, zsh or fish",
+	)
+	_ = cmd.RegisterFlagCompletionFunc("shell", cobra.FixedCompletions(supportedShells, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
+
+// exportLines returns the lines exporting the variables in the shell
+func exportLines(shell string, env [][2]string) (string, error) {
+	var b strings.Builder
+	for _, kv := range env {
+		switch shell {
+		case ShellBash, ShellZsh:
+			fmt.Fprintf(&b, "export %s=%s\n", kv[0], posixQuote(kv[1]))
+		case ShellFish:
+			fmt.Fprintf(&b, "set -gx %s %s;\n", kv[0], fishQuote(kv[1]))
+		default:
+			return "", fmt.Errorf("unsupported shell %s, expected one of %s", shell, strings.Join(supportedShells, ", "))
+		}
+	}
+	return b.String(), nil
+}
+
+func posixQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
+}
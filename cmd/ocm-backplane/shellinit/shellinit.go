@@ -0,0 +1,172 @@
+package shellinit
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/backplane-cli/pkg/login"
+)
+
+// Shells supported by shell-init and env
+const (
+	ShellBash = "bash"
+	ShellZsh  = "zsh"
+	ShellFish = "fish"
+)
+
+var supportedShells = []string{ShellBash, ShellZsh, ShellFish}
+
+const bashInit = `# ocm-backplane shell integration, load it with: eval "$(ocm-backplane shell-init bash)"
+backplane_prompt() {
+  ocm-backplane shell-init prompt 2>/dev/null
+}
+backplane_env() {
+  eval "$(ocm-backplane env --shell bash)"
+}
+export KUBE_PS1_CLUSTER_FUNCTION=backplane_prompt
+source <(ocm-backplane completion bash)
+{{PROMPT}}`
+
+const bashPrompt = `if [[ "$PS1" != *'$(backplane_prompt)'* ]]; then
+  PS1='$(backplane_prompt) '"$PS1"
+fi
+`
+
+const zshInit = `# ocm-backplane shell integration, load it with: eval "$(ocm-backplane shell-init zsh)"
+backplane_prompt() {
+  ocm-backplane shell-init prompt 2>/dev/null
+}
+backplane_env() {
+  eval "$(ocm-backplane env --shell zsh)"
+}
+export KUBE_PS1_CLUSTER_FUNCTION=backplane_prompt
+if (( $+functions[compdef] )); then
+  source <(ocm-backplane completion zsh)
+fi
+{{PROMPT}}`
+
+const zshPrompt = `setopt PROMPT_SUBST
+if [[ "$PROMPT" != *'$(backplane_prompt)'* ]]; then
+  PROMPT='$(backplane_prompt) '"$PROMPT"
+fi
+`
+
+const fishInit = `# ocm-backplane shell integration, load it with: ocm-backplane shell-init fish | source
+function backplane_prompt
+    ocm-backplane shell-init prompt 2>/dev/null
+end
+function backplane_env
+    ocm-backplane env --shell fish | source
+end
+set -gx KUBE_PS1_CLUSTER_FUNCTION backplane_prompt
+ocm-backplane completion fish | source
+{{PROMPT}}`
+
+const fishPrompt = `if not functions -q __backplane_fish_prompt
+    functions -c fish_prompt __backplane_fish_prompt
+    function fish_prompt
+        set -l segment (backplane_prompt)
+        test -n "$segment"; and echo -n "$segment "
+        __backplane_fish_prompt
+    end
+end
+`
+
+// NewShellInitCmd returns the command printing the shell integration of the given shell
+func NewShellInitCmd() *cobra.Command {
+	var noPrompt bool
+
+	cmd := &cobra.Command{
+		Use:   "shell-init bash|zsh|fish",
+		Short: "Print the shell integration: prompt segment, completion and env helper",
+		Long: `Print the shell integration of the given shell, to be evaluated by the shell startup file.
+
+It defines the backplane_prompt function, showing the cluster of the current login, a production marker,
+the elevation status and the time left on the token, and adds it to the prompt. It also sets it as the
+kube-ps1 cluster function, loads the completion of ocm-backplane, completing cluster names from the recent
+logins, and defines the backplane_env function exporting the variables of the current login.`,
+		Example: `  # bash, in ~/.bashrc
+  eval "$(ocm-backplane shell-init bash)"
+  # zsh, in ~/.zshrc after compinit
+  eval "$(ocm-backplane shell-init zsh)"
+  # fish, in ~/.config/fish/config.fish
+  ocm-backplane shell-init fish | source`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgs:         supportedShells,
+		DisableAutoGenTag: true,
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			script, err := shellInitScript(args[0], !noPrompt)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprint(cmd.OutOrStdout(), script)
+			return err
+		},
+	}
+
+	cmd.Flags().BoolVar(
+		&noPrompt,
+		"no-prompt",
+		false,
+		"Do not add the prompt segment to the prompt, e.g. when it is shown through kube-ps1",
+	)
+
+	cmd.AddCommand(newPromptCmd())
+	return cmd
+}
+
+// newPromptCmd returns the command printing the prompt segment of the current login
+func newPromptCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:               "prompt",
+		Short:             "Print the prompt segment of the current login",
+		Long:              "Print the prompt segment of the current login, or nothing when the current kubeconfig context is not a backplane login. It only reads local files.",
+		Args:              cobra.NoArgs,
+		DisableAutoGenTag: true,
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			current, err := login.GetCurrentLogin()
+			if err != nil {
+				// keep the prompt clean outside of backplane logins
+				return nil
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), current.Prompt(time.Now()))
+			return err
+		},
+	}
+}
+
+// shellInitScript returns the shell integration of the shell
+func shellInitScript(shell string, withPrompt bool) (string, error) {
+	var script, prompt string
+	switch shell {
+	case ShellBash:
+		script, prompt = bashInit, bashPrompt
+	case ShellZsh:
+		script, prompt = zshInit, zshPrompt
+	case ShellFish:
+		script, prompt = fishInit, fishPrompt
+	default:
+		return "", fmt.Errorf("unsupported shell %s, expected one of %s", shell, strings.Join(supportedShells, ", "))
+	}
+	if !withPrompt {
+		prompt = ""
+	}
+	return strings.Replace(script, "{{PROMPT}}", prompt, 1), nil
+}
+
+// defaultShell returns the shell of the user, bash when it is not supported
+func defaultShell() string {
+	shell := filepath.Base(os.Getenv("SHELL"))
+	if slices.Contains(supportedShells, shell) {
+		return shell
+	}
+	return ShellBash
+}
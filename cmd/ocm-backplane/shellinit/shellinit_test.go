@@ -0,0 +1,54 @@
+package shellinit
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("shell-init", func() {
+	It("should print the integration of each shell", func() {
+		for _, shell := range supportedShells {
+			cmd := NewShellInitCmd()
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetArgs([]string{shell})
+			Expect(cmd.Execute()).To(Succeed())
+			Expect(out.String()).To(ContainSubstring("backplane_prompt"))
+			Expect(out.String()).To(ContainSubstring("ocm-backplane completion " + shell))
+			Expect(out.String()).To(ContainSubstring("KUBE_PS1_CLUSTER_FUNCTION"))
+		}
+	})
+
+	It("should leave the prompt alone with --no-prompt", func() {
+		withPrompt, err := shellInitScript(ShellBash, true)
+		Expect(err).To(BeNil())
+		Expect(withPrompt).To(ContainSubstring("PS1="))
+
+		withoutPrompt, err := shellInitScript(ShellBash, false)
+		Expect(err).To(BeNil())
+		Expect(withoutPrompt).NotTo(ContainSubstring("PS1="))
+	})
+
+	It("should reject other shells", func() {
+		_, err := shellInitScript("tcsh", true)
+		Expect(err).To(MatchError(ContainSubstring("unsupported shell tcsh")))
+	})
+})
+
+var _ = Describe("env", func() {
+	env := [][2]string{{"KUBECONFIG", "/home/user/.kube/config"}, {"CLUSTERNAME", "it's-a-cluster"}}
+
+	It("should quote the values for posix shells", func() {
+		lines, err := exportLines(ShellZsh, env)
+		Expect(err).To(BeNil())
+		Expect(lines).To(Equal("export KUBECONFIG='/home/user/.kube/config'\nexport CLUSTERNAME='it'\\''s-a-cluster'\n"))
+	})
+
+	It("should quote the values for fish", func() {
+		lines, err := exportLines(ShellFish, env)
+		Expect(err).To(BeNil())
+		Expect(lines).To(Equal("set -gx KUBECONFIG '/home/user/.kube/config';\nset -gx CLUSTERNAME 'it\\'s-a-cluster';\n"))
+	})
+})
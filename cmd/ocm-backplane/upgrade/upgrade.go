@@ -3,6 +3,7 @@ package upgrade
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/openshift/backplane-cli/internal/github"
@@ -11,10 +12,21 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var upgradeArgs struct {
+	version               string
+	channel               string
+	rollback              bool
+	insecureSkipSignature bool
+}
+
 func long() string {
 	return strings.Join([]string{
 		"Upgrades the latest version release based on",
 		"your machine's OS and architecture.",
+		"The release archive is verified against the checksums of the release,",
+		"themselves verified against their signature with the public key built into the release binaries.",
+		"Releases published before the releases were signed can only be installed with --insecure-skip-signature.",
+		"The replaced binary is kept to roll back to with --rollback.",
 	}, " ")
 }
 
@@ -22,6 +34,12 @@ var UpgradeCmd = &cobra.Command{
 	Use:   "upgrade",
 	Short: "Upgrade the current backplane-cli to the latest version",
 	Long:  long(),
+	Example: strings.Join([]string{
+		"  ocm backplane upgrade",
+		"  ocm backplane upgrade --channel prerelease",
+		"  ocm backplane upgrade --version v0.1.40 --insecure-skip-signature",
+		"  ocm backplane upgrade --rollback",
+	}, "\n"),
 
 	RunE: runUpgrade,
 	Args: cobra.ArbitraryArgs,
@@ -29,18 +47,43 @@ var UpgradeCmd = &cobra.Command{
 	SilenceUsage: true,
 }
 
+func init() {
+	flags := UpgradeCmd.Flags()
+	flags.StringVar(&upgradeArgs.version, "version", "", "Install this version, which may be older than the current one, e.g. v0.1.40")
+	flags.StringVar(&upgradeArgs.channel, "channel", upgrade.ChannelStable,
+		fmt.Sprintf("Release channel to upgrade from, one of %s", strings.Join(upgrade.Channels, ", ")))
+	flags.BoolVar(&upgradeArgs.rollback, "rollback", false, "Restore the version replaced by the last upgrade")
+	flags.BoolVar(&upgradeArgs.insecureSkipSignature, "insecure-skip-signature", false,
+		"Install the release without verifying the signature of its checksums, e.g. a release older than the first signed one")
+
+	UpgradeCmd.MarkFlagsMutuallyExclusive("version", "channel", "rollback")
+}
+
 func runUpgrade(cmd *cobra.Command, _ []string) error {
 
 	ctx, cancel := context.WithCancel(cmd.Context())
 	defer cancel()
 
+	if !slices.Contains(upgrade.Channels, upgradeArgs.channel) {
+		return fmt.Errorf("unknown channel %q, expected one of %s", upgradeArgs.channel, strings.Join(upgrade.Channels, ", "))
+	}
+
 	git := github.NewClient()
 
+	upgrade := upgrade.NewCmd(git,
+		upgrade.WithChannel(upgradeArgs.channel),
+		upgrade.WithVersion(upgradeArgs.version),
+		upgrade.WithPublicKey(info.ReleasePublicKey),
+		upgrade.WithSkipSignature(upgradeArgs.insecureSkipSignature),
+	)
+
+	if upgradeArgs.rollback {
+		return upgrade.Rollback()
+	}
+
 	if err := git.CheckConnection(); err != nil {
 		return fmt.Errorf("checking connection to the git server: %w", err)
 	}
 
-	upgrade := upgrade.NewCmd(git)
-
 	return upgrade.UpgradePlugin(ctx, info.Version)
 }
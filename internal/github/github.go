@@ -25,6 +25,7 @@ const (
 	gitHubAPIEndPoint = "https://api.github.com/repos/openshift/backplane-cli"
 	assetTemplateName = "ocm-backplane_%s_%s_%s.tar.gz" // version, GOOS, GOARCH
 	timeout           = 10 * time.Second
+	releasesPerPage   = 30
 )
 
 func NewClient(opts ...ClientOption) *Client {
@@ -61,28 +62,84 @@ func (c *Client) GetLatestVersion(ctx context.Context) (latest upgrade.Release,
 	return githubReleaseResponse, nil
 }
 
+// GetRelease returns the release with the given tag from the github API
+func (c *Client) GetRelease(ctx context.Context, tag string) (release upgrade.Release, err error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	data, err := c.get(
+		ctx,
+		fmt.Sprintf("%s/releases/tags/%s", c.cfg.BaseURL, url.PathEscape(tag)),
+	)
+	if err != nil {
+		return release, err
+	}
+
+	err = json.Unmarshal(data, &release)
+
+	return release, err
+}
+
+// ListReleases returns the most recent releases from the github API, prereleases included
+func (c *Client) ListReleases(ctx context.Context) (releases []upgrade.Release, err error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	data, err := c.get(
+		ctx,
+		fmt.Sprintf("%s/releases?per_page=%d", c.cfg.BaseURL, releasesPerPage),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &releases)
+
+	return releases, err
+}
+
 // GetReleaseArchive returns archive based on the OS type and arc
 func (c *Client) GetReleaseArchive(ctx context.Context, latestVersion upgrade.Release) ([]byte, error) {
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
+	asset, err := c.FindReleaseArchive(latestVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	archiveData, err := c.GetReleaseAsset(ctx, asset)
+	if err != nil {
+		return nil, fmt.Errorf("requesting release archive: %w", err)
+	}
+
+	return archiveData, nil
+}
 
+// FindReleaseArchive returns the archive asset of the release based on the OS type and arc
+func (c *Client) FindReleaseArchive(release upgrade.Release) (upgrade.ReleaseAsset, error) {
 	osConfig := OSConfig{
 		OSType: runtime.GOOS,
 		OSArch: runtime.GOARCH,
 	}
 
-	// Find browser download url based on OS type and arc
-	assetURL, ok := osConfig.FindAssetURL(latestVersion)
-	if !ok {
-		return nil, ErrArchiveNotFound
+	for _, asset := range release.Assets {
+		if osConfig.isMatchingArchive(asset, release.TagName) {
+			return asset, nil
+		}
 	}
 
-	archiveData, err := c.get(ctx, assetURL)
+	return upgrade.ReleaseAsset{}, ErrArchiveNotFound
+}
+
+// GetReleaseAsset downloads an asset of a release
+func (c *Client) GetReleaseAsset(ctx context.Context, asset upgrade.ReleaseAsset) ([]byte, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	data, err := c.get(ctx, asset.DownloadURL)
 	if err != nil {
-		return nil, fmt.Errorf("requesting release archive: %w", err)
+		return nil, fmt.Errorf("requesting release asset %s: %w", asset.Name, err)
 	}
 
-	return archiveData, nil
+	return data, nil
 }
 
 // Get data from the API providing the context and base url
@@ -110,7 +167,7 @@ func (c *Client) get(ctx context.Context, url string) ([]byte, error) {
 	defer func() { _ = res.Body.Close() }()
 
 	if res.StatusCode != http.StatusOK {
-		return nil, ErrRequestFailed
+		return nil, fmt.Errorf("%w: %s", ErrRequestFailed, res.Status)
 	}
 
 	data, err := io.ReadAll(res.Body)
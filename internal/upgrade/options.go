@@ -1,6 +1,7 @@
 package upgrade
 
 import (
+	"bufio"
 	"io"
 
 	"github.com/sirupsen/logrus"
@@ -45,3 +46,39 @@ type WithRepo string
 func (w WithRepo) ConfigureCmd(c *CmdConfig) {
 	c.Repo = string(w)
 }
+
+type WithBinaryPath string
+
+func (w WithBinaryPath) ConfigureCmd(c *CmdConfig) {
+	c.BinaryPath = string(w)
+}
+
+type WithChannel string
+
+func (w WithChannel) ConfigureCmd(c *CmdConfig) {
+	c.Channel = string(w)
+}
+
+type WithVersion string
+
+func (w WithVersion) ConfigureCmd(c *CmdConfig) {
+	c.Version = string(w)
+}
+
+type WithPublicKey string
+
+func (w WithPublicKey) ConfigureCmd(c *CmdConfig) {
+	c.PublicKey = string(w)
+}
+
+type WithSkipSignature bool
+
+func (w WithSkipSignature) ConfigureCmd(c *CmdConfig) {
+	c.SkipSignature = bool(w)
+}
+
+type WithReader struct{ Reader io.Reader }
+
+func (w WithReader) ConfigureCmd(c *CmdConfig) {
+	c.Reader = bufio.NewReader(w.Reader)
+}
@@ -16,10 +16,33 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// Release channels
+const (
+	// ChannelStable follows the latest release
+	ChannelStable = "stable"
+	// ChannelPrerelease follows the highest release, release candidates included
+	ChannelPrerelease = "prerelease"
+)
+
+// Channels are the supported release channels
+var Channels = []string{ChannelStable, ChannelPrerelease}
+
 // Release type defines the tag and its assets
 type Release struct {
-	TagName string         `json:"tag_name"`
-	Assets  []ReleaseAsset `json:"assets"`
+	TagName string `json:"tag_name"`
+	// Body holds the changelog of the release
+	Body   string         `json:"body"`
+	Assets []ReleaseAsset `json:"assets"`
+}
+
+// Asset returns the asset of the release with the given name
+func (r Release) Asset(name string) (ReleaseAsset, bool) {
+	for _, asset := range r.Assets {
+		if asset.Name == name {
+			return asset, true
+		}
+	}
+	return ReleaseAsset{}, false
 }
 
 // ReleaseAsset defines the name and download url
@@ -28,14 +51,20 @@ type ReleaseAsset struct {
 	DownloadURL string `json:"browser_download_url"`
 }
 
-// GitServer defines generic functions for getting the latest release and archive
+// GitServer defines generic functions for getting the releases and their assets
 type GitServer interface {
 	GetLatestVersion(ctx context.Context) (Release, error)
-	GetReleaseArchive(ctx context.Context, release Release) ([]byte, error)
+	GetRelease(ctx context.Context, tag string) (Release, error)
+	ListReleases(ctx context.Context) ([]Release, error)
+	FindReleaseArchive(release Release) (ReleaseAsset, error)
+	GetReleaseAsset(ctx context.Context, asset ReleaseAsset) ([]byte, error)
 }
 
+// Writer replaces the binary, keeping a backup of the replaced one to roll back to
 type Writer interface {
 	Write(path string, data []byte) error
+	// Rollback restores the last backup, it returns the path of the restored backup
+	Rollback(path string) (string, error)
 }
 
 func NewCmd(git GitServer, opts ...CmdOption) *Cmd {
@@ -57,61 +86,169 @@ type Cmd struct {
 	git GitServer
 }
 
-// UpgradePlugin upgrade OS binary based on the latest version
+// UpgradePlugin upgrade OS binary based on the latest version of the channel,
+// or to the pinned version which may be older than the current one
 func (c *Cmd) UpgradePlugin(ctx context.Context, currentVersion string) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	latestVersion, err := c.git.GetLatestVersion(ctx)
+	release, err := c.targetRelease(ctx)
 	if err != nil {
-		return fmt.Errorf("getting latest version for project '%s/%s': %w", c.cfg.Org, c.cfg.Repo, err)
+		return err
 	}
 
-	proceed, err := shouldUpdate(currentVersion, latestVersion)
+	cmp, err := compareVersions(currentVersion, release)
 	if err != nil {
-		return fmt.Errorf("comparing current version to latest: %w", err)
+		return fmt.Errorf("comparing current version to %s: %w", release.TagName, err)
 	}
 
-	if !proceed {
+	if cmp == 0 && c.cfg.Version != "" {
+		_, err := fmt.Fprintf(c.cfg.Out, "Version %s is already installed.\n", release.TagName)
+
+		return err
+	}
+
+	// a pinned version may be older than the current one, the latest one may not
+	downgrade := cmp > 0
+	if cmp == 0 || (downgrade && c.cfg.Version == "") {
 		_, err := fmt.Fprintln(c.cfg.Out, "No upgrade available.")
 
 		return err
 	}
 
-	if confirmed := confirmUpgrade(latestVersion, c); !confirmed {
+	if confirmed := confirmUpgrade(release, downgrade, c); !confirmed {
 		_, err := fmt.Fprintln(c.cfg.Out, "Upgrade cancelled.")
 
 		return err
 	}
 
-	latestBin, err := c.getLatestBinary(ctx, latestVersion)
+	bin, err := c.getBinary(ctx, release)
 	if err != nil {
-		return fmt.Errorf("retrieving latest binary: %w", err)
+		return fmt.Errorf("retrieving binary of %s: %w", release.TagName, err)
 	}
 
-	binPath, err := os.Executable()
+	binPath, err := c.binaryPath()
 	if err != nil {
-		return fmt.Errorf("retrieving the current executable path: %w", err)
+		return err
 	}
 
-	if err := c.cfg.Writer.Write(binPath, latestBin); err != nil {
+	if err := c.cfg.Writer.Write(binPath, bin); err != nil {
 		return fmt.Errorf("writing new binary: %w", err)
 	}
-	successMessage := fmt.Sprintf("Backplane CLI has been upgraded to %s", latestVersion.TagName)
+	action := "upgraded"
+	if downgrade {
+		action = "downgraded"
+	}
+	successMessage := fmt.Sprintf("Backplane CLI has been %s to %s, run 'ocm backplane upgrade --rollback' to restore the previous version", action, release.TagName)
 	_, _ = fmt.Fprintln(c.cfg.Out, successMessage)
 
 	return nil
 }
 
-// getLatestBinary returns the data based on the release version
-func (c *Cmd) getLatestBinary(ctx context.Context, release Release) ([]byte, error) {
+// Rollback restores the binary replaced by the last upgrade
+func (c *Cmd) Rollback() error {
+	binPath, err := c.binaryPath()
+	if err != nil {
+		return err
+	}
+
+	backup, err := c.cfg.Writer.Rollback(binPath)
+	if errors.Is(err, ErrNoBackup) {
+		return fmt.Errorf("no previous version to roll back to, a backup of %q is kept by the upgrades only", binPath)
+	} else if err != nil {
+		return fmt.Errorf("rolling back: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(c.cfg.Out, "Backplane CLI has been rolled back to the binary backed up as %s\n", backup)
+
+	return nil
+}
 
-	rawArc, err := c.git.GetReleaseArchive(ctx, release)
+// targetRelease returns the pinned release, else the latest release of the channel
+func (c *Cmd) targetRelease(ctx context.Context) (Release, error) {
+	if c.cfg.Version != "" {
+		tag := "v" + strings.TrimPrefix(c.cfg.Version, "v")
+		release, err := c.git.GetRelease(ctx, tag)
+		if err != nil {
+			return release, fmt.Errorf("getting release %s for project '%s/%s': %w", tag, c.cfg.Org, c.cfg.Repo, err)
+		}
+
+		return release, nil
+	}
+
+	switch c.cfg.Channel {
+	case ChannelStable:
+		release, err := c.git.GetLatestVersion(ctx)
+		if err != nil {
+			return release, fmt.Errorf("getting latest version for project '%s/%s': %w", c.cfg.Org, c.cfg.Repo, err)
+		}
+
+		return release, nil
+	case ChannelPrerelease:
+		releases, err := c.git.ListReleases(ctx)
+		if err != nil {
+			return Release{}, fmt.Errorf("listing releases for project '%s/%s': %w", c.cfg.Org, c.cfg.Repo, err)
+		}
 
+		return highestRelease(releases)
+	default:
+		return Release{}, fmt.Errorf("unknown channel %q, expected one of %s", c.cfg.Channel, strings.Join(Channels, ", "))
+	}
+}
+
+// highestRelease returns the release with the highest version, release candidates included
+func highestRelease(releases []Release) (Release, error) {
+	var (
+		highest    Release
+		highestVer *semver.Version
+	)
+
+	for _, release := range releases {
+		ver, err := semver.NewVersion(release.TagName)
+		if err != nil {
+			continue
+		}
+		if highestVer == nil || highestVer.LessThan(ver) {
+			highest, highestVer = release, ver
+		}
+	}
+
+	if highestVer == nil {
+		return highest, errors.New("no release found")
+	}
+
+	return highest, nil
+}
+
+func (c *Cmd) binaryPath() (string, error) {
+	if c.cfg.BinaryPath != "" {
+		return c.cfg.BinaryPath, nil
+	}
+
+	binPath, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("retrieving the current executable path: %w", err)
+	}
+
+	return binPath, nil
+}
+
+// getBinary returns the binary of the release, once its archive is verified
+func (c *Cmd) getBinary(ctx context.Context, release Release) ([]byte, error) {
+	asset, err := c.git.FindReleaseArchive(release)
+	if err != nil {
+		return nil, fmt.Errorf("finding release archive for project '%s/%s' at version %q: %w", c.cfg.Org, c.cfg.Repo, release.TagName, err)
+	}
+
+	rawArc, err := c.git.GetReleaseAsset(ctx, asset)
 	if err != nil {
 		return nil, fmt.Errorf("getting release archive for project '%s/%s' at version %q: %w", c.cfg.Org, c.cfg.Repo, release.TagName, err)
 	}
 
+	if err := c.verifyArchive(ctx, release, asset.Name, rawArc); err != nil {
+		return nil, err
+	}
+
 	unzipped, err := gzip.NewReader(bytes.NewBuffer(rawArc))
 	if err != nil {
 		return nil, fmt.Errorf("reading zipped archive: %w", err)
@@ -139,25 +276,31 @@ func (c *Cmd) getLatestBinary(ctx context.Context, release Release) ([]byte, err
 	return res, nil
 }
 
-func shouldUpdate(current string, latest Release) (bool, error) {
+// compareVersions returns -1, 0 or 1 whether the current version is lower than, equal to or higher than the release
+func compareVersions(current string, release Release) (int, error) {
 	curVer, err := semver.NewVersion(current)
 	if err != nil {
-		return false, fmt.Errorf("parsing current version %q: %w", current, err)
+		return 0, fmt.Errorf("parsing current version %q: %w", current, err)
 	}
 
-	latestVer, err := semver.NewVersion(latest.TagName)
+	releaseVer, err := semver.NewVersion(release.TagName)
 	if err != nil {
-		return false, fmt.Errorf("parsing latest version %q: %w", latest, err)
+		return 0, fmt.Errorf("parsing release version %q: %w", release.TagName, err)
 	}
 
-	return curVer.LessThan(latestVer), nil
+	return curVer.Compare(releaseVer), nil
 }
 
-func confirmUpgrade(latest Release, c *Cmd) bool {
+func confirmUpgrade(release Release, downgrade bool, c *Cmd) bool {
 
 	message := fmt.Sprintf(
-		"A newer version %q is available.\nWould you like to upgrade? (y/N)", latest.TagName,
+		"A newer version %q is available.\nWould you like to upgrade? (y/N)", release.TagName,
 	)
+	if downgrade {
+		message = fmt.Sprintf(
+			"Version %q is older than the current version.\nWould you like to downgrade? (y/N)", release.TagName,
+		)
+	}
 	_, _ = fmt.Fprintln(c.cfg.Out, message)
 
 	input, _ := c.cfg.Reader.ReadString('\n')
@@ -174,8 +317,20 @@ type CmdConfig struct {
 	Reader *bufio.Reader
 
 	BinaryName string
+	// BinaryPath is the binary to replace, the current executable by default
+	BinaryPath string
 	Org        string
 	Repo       string
+
+	// Channel is the release channel followed when no version is pinned
+	Channel string
+	// Version pins the release to install
+	Version string
+	// PublicKey verifies the signature of the release checksums, required when set
+	PublicKey string
+	// SkipSignature installs a release without verifying the signature of its checksums,
+	// e.g. an older release published before the releases were signed
+	SkipSignature bool
 }
 
 func (c *CmdConfig) Option(opts ...CmdOption) {
@@ -212,6 +367,10 @@ func (c *CmdConfig) Default() {
 	if c.Repo == "" {
 		c.Repo = "backplane-cli"
 	}
+
+	if c.Channel == "" {
+		c.Channel = ChannelStable
+	}
 }
 
 type CmdOption interface {
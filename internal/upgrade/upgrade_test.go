@@ -0,0 +1,446 @@
+package upgrade_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/openshift/backplane-cli/internal/github"
+	"github.com/openshift/backplane-cli/internal/upgrade"
+)
+
+// releaseServer stands in for the GitHub API, serving the releases and their assets
+type releaseServer struct {
+	*httptest.Server
+
+	latest   string
+	releases map[string]map[string][]byte
+}
+
+func newReleaseServer(t *testing.T, latest string) *releaseServer {
+	t.Helper()
+
+	srv := &releaseServer{latest: latest, releases: map[string]map[string][]byte{}}
+	srv.Server = httptest.NewServer(http.HandlerFunc(srv.serve))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// addRelease publishes a release with the archive of the binary and the checksums of the archive
+func (s *releaseServer) addRelease(t *testing.T, tag string, binary string) {
+	t.Helper()
+
+	name := archiveName(tag)
+	archive := makeArchive(t, "ocm-backplane", binary)
+	sum := sha256.Sum256(archive)
+
+	s.releases[tag] = map[string][]byte{
+		name:                  archive,
+		upgrade.ChecksumsName: []byte(fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), name)),
+	}
+}
+
+func (s *releaseServer) release(tag string) upgrade.Release {
+	release := upgrade.Release{TagName: tag}
+	for name := range s.releases[tag] {
+		release.Assets = append(release.Assets, upgrade.ReleaseAsset{
+			Name:        name,
+			DownloadURL: fmt.Sprintf("%s/download/%s/%s", s.URL, tag, name),
+		})
+	}
+
+	return release
+}
+
+func (s *releaseServer) serve(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+	var body interface{}
+
+	switch {
+	case path == "/releases/latest":
+		body = s.release(s.latest)
+	case path == "/releases":
+		releases := []upgrade.Release{}
+		for tag := range s.releases {
+			releases = append(releases, s.release(tag))
+		}
+		body = releases
+	case strings.HasPrefix(path, "/releases/tags/"):
+		tag := strings.TrimPrefix(path, "/releases/tags/")
+		if _, ok := s.releases[tag]; !ok {
+			http.NotFound(w, r)
+			return
+		}
+		body = s.release(tag)
+	case strings.HasPrefix(path, "/download/"):
+		tag, name, _ := strings.Cut(strings.TrimPrefix(path, "/download/"), "/")
+		data, ok := s.releases[tag][name]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(data)
+		return
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	data, _ := json.Marshal(body)
+	_, _ = w.Write(data)
+}
+
+func archiveName(tag string) string {
+	osName := map[string]string{"linux": "Linux", "darwin": "Darwin", "windows": "Windows"}[runtime.GOOS]
+	arch := runtime.GOARCH
+	if arch == "amd64" {
+		arch = "x86_64"
+	}
+
+	return fmt.Sprintf("ocm-backplane_%s_%s_%s.tar.gz", strings.TrimPrefix(tag, "v"), osName, arch)
+}
+
+func makeArchive(t *testing.T, name string, content string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o755, Size: int64(len(content))}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+// newCmd returns the upgrade of the binary path against the server, answering the confirmation with yes
+func newCmd(srv *releaseServer, binPath string, out *bytes.Buffer, opts ...upgrade.CmdOption) *upgrade.Cmd {
+	git := github.NewClient(
+		github.WithBaseURL(srv.URL),
+		github.WithClient(*srv.Client()),
+	)
+
+	opts = append([]upgrade.CmdOption{
+		upgrade.WithOut{Out: out},
+		upgrade.WithReader{Reader: strings.NewReader("y\n")},
+		upgrade.WithBinaryPath(binPath),
+	}, opts...)
+
+	return upgrade.NewCmd(git, opts...)
+}
+
+func installedBinary(t *testing.T, content string) string {
+	t.Helper()
+
+	binPath := filepath.Join(t.TempDir(), "ocm-backplane")
+	if err := os.WriteFile(binPath, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return binPath
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(data)
+}
+
+func TestUpgradePlugin(t *testing.T) {
+
+	for name, tc := range map[string]struct {
+		Current         string
+		Opts            []upgrade.CmdOption
+		ExpectedBinary  string
+		ExpectedOutput  string
+		ExpectedErr     error
+		ExpectedErrText string
+	}{
+		"upgrades to the latest release": {
+			Current:        "0.1.0",
+			ExpectedBinary: "binary 0.2.0",
+			ExpectedOutput: "has been upgraded to v0.2.0",
+		},
+		"no upgrade when already on the latest release": {
+			Current:        "0.2.0",
+			ExpectedBinary: "current",
+			ExpectedOutput: "No upgrade available.",
+		},
+		"upgrades to the highest prerelease": {
+			Current:        "0.2.0",
+			Opts:           []upgrade.CmdOption{upgrade.WithChannel(upgrade.ChannelPrerelease)},
+			ExpectedBinary: "binary 0.3.0-rc1",
+			ExpectedOutput: "has been upgraded to v0.3.0-rc1",
+		},
+		"downgrades to the pinned version": {
+			Current:        "0.2.0",
+			Opts:           []upgrade.CmdOption{upgrade.WithVersion("0.1.0")},
+			ExpectedBinary: "binary 0.1.0",
+			ExpectedOutput: "Would you like to downgrade?",
+		},
+		"pinned version already installed": {
+			Current:        "0.1.0",
+			Opts:           []upgrade.CmdOption{upgrade.WithVersion("v0.1.0")},
+			ExpectedBinary: "current",
+			ExpectedOutput: "Version v0.1.0 is already installed.",
+		},
+		"unknown pinned version": {
+			Current:         "0.1.0",
+			Opts:            []upgrade.CmdOption{upgrade.WithVersion("v9.9.9")},
+			ExpectedBinary:  "current",
+			ExpectedErr:     github.ErrRequestFailed,
+			ExpectedErrText: "getting release v9.9.9",
+		},
+		"unknown channel": {
+			Current:         "0.1.0",
+			Opts:            []upgrade.CmdOption{upgrade.WithChannel("nightly")},
+			ExpectedBinary:  "current",
+			ExpectedErrText: `unknown channel "nightly"`,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+
+			t.Parallel()
+
+			srv := newReleaseServer(t, "v0.2.0")
+			srv.addRelease(t, "v0.1.0", "binary 0.1.0")
+			srv.addRelease(t, "v0.2.0", "binary 0.2.0")
+			srv.addRelease(t, "v0.3.0-rc1", "binary 0.3.0-rc1")
+
+			binPath := installedBinary(t, "current")
+			var out bytes.Buffer
+
+			err := newCmd(srv, binPath, &out, tc.Opts...).UpgradePlugin(context.Background(), tc.Current)
+
+			if tc.ExpectedErrText == "" && err != nil {
+				t.Errorf("expected err to be nil got %v", err)
+			}
+			if tc.ExpectedErrText != "" && (err == nil || !strings.Contains(err.Error(), tc.ExpectedErrText)) {
+				t.Errorf("expected err contains %q got %v", tc.ExpectedErrText, err)
+			}
+			if tc.ExpectedErr != nil && !errors.Is(err, tc.ExpectedErr) {
+				t.Errorf("expected err to be %v got %v", tc.ExpectedErr, err)
+			}
+			if !strings.Contains(out.String(), tc.ExpectedOutput) {
+				t.Errorf("expected output contains %q got %q", tc.ExpectedOutput, out.String())
+			}
+			if binary := readFile(t, binPath); binary != tc.ExpectedBinary {
+				t.Errorf("expected binary to be %q got %q", tc.ExpectedBinary, binary)
+			}
+		})
+	}
+}
+
+func TestUpgradePluginVerification(t *testing.T) {
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKeyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	sign := func(data []byte) []byte {
+		return []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, data)))
+	}
+
+	for name, tc := range map[string]struct {
+		Tamper        func(files map[string][]byte)
+		PublicKey     string
+		SkipSignature bool
+		ExpectedErr   error
+	}{
+		"checksum verified": {
+			Tamper: func(files map[string][]byte) {},
+		},
+		"archive not matching its checksum": {
+			Tamper: func(files map[string][]byte) {
+				files[archiveName("v0.2.0")] = makeArchive(t, "ocm-backplane", "tampered")
+			},
+			ExpectedErr: upgrade.ErrChecksumMismatch,
+		},
+		"no checksums published": {
+			Tamper: func(files map[string][]byte) {
+				delete(files, upgrade.ChecksumsName)
+			},
+			ExpectedErr: upgrade.ErrChecksumsNotFound,
+		},
+		"no checksum of the archive": {
+			Tamper: func(files map[string][]byte) {
+				files[upgrade.ChecksumsName] = []byte("0123  another.tar.gz\n")
+			},
+			ExpectedErr: upgrade.ErrChecksumsNotFound,
+		},
+		"signature verified": {
+			Tamper: func(files map[string][]byte) {
+				files[upgrade.ChecksumsName+upgrade.SignatureSuffix] = sign(files[upgrade.ChecksumsName])
+			},
+			PublicKey: publicKeyPEM,
+		},
+		"signature verified with a base64 public key": {
+			Tamper: func(files map[string][]byte) {
+				files[upgrade.ChecksumsName+upgrade.SignatureSuffix] = sign(files[upgrade.ChecksumsName])
+			},
+			PublicKey: base64.StdEncoding.EncodeToString(der),
+		},
+		"signature of other checksums": {
+			Tamper: func(files map[string][]byte) {
+				files[upgrade.ChecksumsName+upgrade.SignatureSuffix] = sign([]byte("other checksums"))
+			},
+			PublicKey:   publicKeyPEM,
+			ExpectedErr: upgrade.ErrInvalidSignature,
+		},
+		"no signature published for a build with a public key": {
+			Tamper:      func(files map[string][]byte) {},
+			PublicKey:   publicKeyPEM,
+			ExpectedErr: upgrade.ErrSignatureNotFound,
+		},
+		"no signature published with the signature skipped": {
+			Tamper:        func(files map[string][]byte) {},
+			PublicKey:     publicKeyPEM,
+			SkipSignature: true,
+		},
+		"signature not verified without a public key": {
+			Tamper: func(files map[string][]byte) {
+				files[upgrade.ChecksumsName+upgrade.SignatureSuffix] = []byte("not checked")
+			},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+
+			t.Parallel()
+
+			srv := newReleaseServer(t, "v0.2.0")
+			srv.addRelease(t, "v0.2.0", "binary 0.2.0")
+			tc.Tamper(srv.releases["v0.2.0"])
+
+			binPath := installedBinary(t, "current")
+			var out bytes.Buffer
+
+			cmd := newCmd(srv, binPath, &out, upgrade.WithPublicKey(tc.PublicKey), upgrade.WithSkipSignature(tc.SkipSignature))
+			err := cmd.UpgradePlugin(context.Background(), "0.1.0")
+
+			expectedBinary := "binary 0.2.0"
+			if tc.ExpectedErr != nil {
+				expectedBinary = "current"
+				if !errors.Is(err, tc.ExpectedErr) {
+					t.Errorf("expected err to be %v got %v", tc.ExpectedErr, err)
+				}
+			} else if err != nil {
+				t.Errorf("expected err to be nil got %v", err)
+			}
+			if binary := readFile(t, binPath); binary != expectedBinary {
+				t.Errorf("expected binary to be %q got %q", expectedBinary, binary)
+			}
+		})
+	}
+}
+
+func TestRollback(t *testing.T) {
+
+	t.Run("restores the binary replaced by the upgrade", func(t *testing.T) {
+		srv := newReleaseServer(t, "v0.2.0")
+		srv.addRelease(t, "v0.2.0", "binary 0.2.0")
+
+		binPath := installedBinary(t, "binary 0.1.0")
+		var out bytes.Buffer
+
+		if err := newCmd(srv, binPath, &out).UpgradePlugin(context.Background(), "0.1.0"); err != nil {
+			t.Fatalf("expected err to be nil got %v", err)
+		}
+		if binary := readFile(t, binPath); binary != "binary 0.2.0" {
+			t.Fatalf("expected binary to be upgraded got %q", binary)
+		}
+
+		if err := newCmd(srv, binPath, &out).Rollback(); err != nil {
+			t.Fatalf("expected err to be nil got %v", err)
+		}
+		if binary := readFile(t, binPath); binary != "binary 0.1.0" {
+			t.Errorf("expected binary to be rolled back got %q", binary)
+		}
+		if !strings.Contains(out.String(), "has been rolled back") {
+			t.Errorf("expected output contains the rollback got %q", out.String())
+		}
+
+		// the backup is consumed by the rollback
+		if err := newCmd(srv, binPath, &out).Rollback(); err == nil {
+			t.Errorf("expected err on a second rollback")
+		}
+	})
+
+	t.Run("keeps the last backup only", func(t *testing.T) {
+		binPath := installedBinary(t, "binary 0.1.0")
+		for _, name := range []string{"ocm-backplane_2024.01.01_10:00:00", "ocm-backplane_2025.01.01_10:00:00"} {
+			if err := os.WriteFile(filepath.Join(filepath.Dir(binPath), name), []byte(name), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		writer := upgrade.NewSafeWriter()
+		if err := writer.Write(binPath, []byte("binary 0.2.0")); err != nil {
+			t.Fatalf("expected err to be nil got %v", err)
+		}
+
+		entries, err := os.ReadDir(filepath.Dir(binPath))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 2 {
+			t.Errorf("expected the binary and its last backup got %d files", len(entries))
+		}
+
+		if _, err := writer.Rollback(binPath); err != nil {
+			t.Fatalf("expected err to be nil got %v", err)
+		}
+		if binary := readFile(t, binPath); binary != "binary 0.1.0" {
+			t.Errorf("expected binary to be rolled back got %q", binary)
+		}
+	})
+
+	t.Run("no backup to roll back to", func(t *testing.T) {
+		_, err := upgrade.NewSafeWriter().Rollback(installedBinary(t, "current"))
+		if !errors.Is(err, upgrade.ErrNoBackup) {
+			t.Errorf("expected err to be %v got %v", upgrade.ErrNoBackup, err)
+		}
+	})
+}
@@ -0,0 +1,167 @@
+package upgrade
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// ChecksumsName is the name of the release asset holding the SHA-256 checksums of the archives
+	ChecksumsName = "checksums.txt"
+	// SignatureSuffix is appended to the name of the checksums file to get the name of its signature
+	SignatureSuffix = ".sig"
+)
+
+var (
+	ErrChecksumsNotFound = errors.New("checksums not found")
+	ErrChecksumMismatch  = errors.New("checksum mismatch")
+	ErrInvalidSignature  = errors.New("invalid signature")
+	ErrSignatureNotFound = errors.New("signature not found")
+)
+
+// verifyArchive checks the archive against the checksums of the release,
+// once the checksums are verified against their signature when the release is signed
+func (c *Cmd) verifyArchive(ctx context.Context, release Release, archiveName string, archive []byte) error {
+	checksumsAsset, ok := release.Asset(ChecksumsName)
+	if !ok {
+		return fmt.Errorf("release %s has no %s to verify %s against: %w", release.TagName, ChecksumsName, archiveName, ErrChecksumsNotFound)
+	}
+
+	checksums, err := c.git.GetReleaseAsset(ctx, checksumsAsset)
+	if err != nil {
+		return fmt.Errorf("getting %s of release %s: %w", ChecksumsName, release.TagName, err)
+	}
+
+	if err := c.verifyChecksumsSignature(ctx, release, checksums); err != nil {
+		return err
+	}
+
+	expected, err := findChecksum(checksums, archiveName)
+	if err != nil {
+		return fmt.Errorf("release %s: %w", release.TagName, err)
+	}
+
+	sum := sha256.Sum256(archive)
+	if actual := hex.EncodeToString(sum[:]); !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("%s of release %s has the checksum %s, expected %s: %w", archiveName, release.TagName, actual, expected, ErrChecksumMismatch)
+	}
+
+	c.cfg.Log.Debugf("Verified the checksum of %s", archiveName)
+
+	return nil
+}
+
+// verifyChecksumsSignature checks the signature of the checksums. A build with a release public key
+// requires the signature unless told to skip it, a build without one only warns when the release is signed.
+func (c *Cmd) verifyChecksumsSignature(ctx context.Context, release Release, checksums []byte) error {
+	if c.cfg.SkipSignature {
+		c.cfg.Log.Warnf("The signature of release %s is not verified, only its checksums are verified", release.TagName)
+
+		return nil
+	}
+
+	signatureAsset, ok := release.Asset(ChecksumsName + SignatureSuffix)
+	if c.cfg.PublicKey == "" {
+		if ok {
+			c.cfg.Log.Warnf("Release %s is signed but this build has no release public key, only its checksums are verified", release.TagName)
+		} else {
+			c.cfg.Log.Debugf("Release %s publishes no signature and this build has no release public key, only its checksums are verified", release.TagName)
+		}
+
+		return nil
+	}
+
+	if !ok {
+		return fmt.Errorf("release %s publishes no %s to verify its %s against, releases older than the first signed one "+
+			"can only be installed with --insecure-skip-signature: %w", release.TagName, ChecksumsName+SignatureSuffix, ChecksumsName, ErrSignatureNotFound)
+	}
+
+	signature, err := c.git.GetReleaseAsset(ctx, signatureAsset)
+	if err != nil {
+		return fmt.Errorf("getting %s of release %s: %w", signatureAsset.Name, release.TagName, err)
+	}
+
+	if err := verifySignature(c.cfg.PublicKey, checksums, signature); err != nil {
+		return fmt.Errorf("verifying %s of release %s: %w", ChecksumsName, release.TagName, err)
+	}
+
+	c.cfg.Log.Debugf("Verified the signature of the %s of release %s", ChecksumsName, release.TagName)
+
+	return nil
+}
+
+// findChecksum returns the checksum of the file in the checksums, one '<sha256>  <file name>' per line
+func findChecksum(checksums []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return fields[0], nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("reading %s: %w", ChecksumsName, err)
+	}
+
+	return "", fmt.Errorf("no checksum of %s in %s: %w", name, ChecksumsName, ErrChecksumsNotFound)
+}
+
+// verifySignature checks a signature made by 'cosign sign-blob' with an ECDSA key,
+// or an Ed25519 one. The public key is PEM encoded, or the base64 encoded DER of the PEM body,
+// and the signature is raw or base64 encoded.
+func verifySignature(publicKey string, data []byte, signature []byte) error {
+	der, err := decodePublicKey(publicKey)
+	if err != nil {
+		return err
+	}
+
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return fmt.Errorf("parsing release public key: %w", err)
+	}
+
+	if decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature))); err == nil {
+		signature = decoded
+	}
+
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(data)
+		if !ecdsa.VerifyASN1(key, digest[:], signature) {
+			return ErrInvalidSignature
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, data, signature) {
+			return ErrInvalidSignature
+		}
+	default:
+		return fmt.Errorf("unsupported release public key type %T", key)
+	}
+
+	return nil
+}
+
+func decodePublicKey(publicKey string) ([]byte, error) {
+	if block, _ := pem.Decode([]byte(publicKey)); block != nil {
+		return block.Bytes, nil
+	}
+
+	der, err := base64.StdEncoding.DecodeString(strings.TrimSpace(publicKey))
+	if err != nil {
+		return nil, fmt.Errorf("decoding release public key, expected PEM or base64: %w", err)
+	}
+
+	return der, nil
+}
@@ -4,6 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -42,14 +45,88 @@ func (w *SafeWriter) Write(path string, data []byte) error {
 		return fmt.Errorf("writing to path %q: %w", path, err)
 	}
 
-	if err := os.Remove(backup); err != nil {
-		return fmt.Errorf("cleaning up old binary: %w", err)
+	// the last backup is kept to roll back to
+	if err := w.removeBackups(path, backup); err != nil {
+		return fmt.Errorf("cleaning up old binaries: %w", err)
 	}
 
 	return nil
 }
 
-var ErrNotAFile = errors.New("not a file")
+// Rollback restores the last backup of the path, it returns the path of the restored backup
+func (w *SafeWriter) Rollback(path string) (string, error) {
+	backups, err := w.backups(path)
+	if err != nil {
+		return "", err
+	}
+
+	if len(backups) == 0 {
+		return "", ErrNoBackup
+	}
+
+	backup := backups[len(backups)-1]
+	if err := os.Rename(backup, path); err != nil {
+		return "", fmt.Errorf("restoring backup %q: %w", backup, err)
+	}
+
+	return backup, nil
+}
+
+var (
+	ErrNotAFile = errors.New("not a file")
+	ErrNoBackup = errors.New("no backup")
+)
+
+const backupTimeFormat = "2006.01.02_15:04:05"
+
+// backups returns the backups of the path, from the oldest to the latest
+func (w *SafeWriter) backups(path string) ([]string, error) {
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("listing backups: %w", err)
+	}
+
+	backups := []string{}
+	for _, entry := range entries {
+		suffix, ok := strings.CutPrefix(entry.Name(), base+"_")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		if _, err := time.Parse(backupTimeFormat, suffix); err != nil {
+			continue
+		}
+		backups = append(backups, filepath.Join(dir, entry.Name()))
+	}
+
+	// the time format sorts chronologically
+	sort.Strings(backups)
+
+	return backups, nil
+}
+
+// removeBackups removes the backups of the path but the one to keep
+func (w *SafeWriter) removeBackups(path string, keep string) error {
+	backups, err := w.backups(path)
+	if err != nil {
+		return err
+	}
+
+	for _, backup := range backups {
+		if backup == keep {
+			continue
+		}
+		if err := os.Remove(backup); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
 
 func (w *SafeWriter) backup(path string) (string, error) {
 	if stat, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
@@ -60,7 +137,7 @@ func (w *SafeWriter) backup(path string) (string, error) {
 		return "", ErrNotAFile
 	}
 
-	backup := path + "_" + time.Now().Format("2006.01.02_15:04:05")
+	backup := path + "_" + time.Now().Format(backupTimeFormat)
 
 	if err := os.Rename(path, backup); err != nil {
 		return "", fmt.Errorf("backing up file path: %w", err)
@@ -16,6 +16,9 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// DefaultApprovalDuration is the default maximal period of time during which an access request can stay approved
+const DefaultApprovalDuration = 8 * time.Hour
+
 func getJiraBaseURL() string {
 	bpConfig, err := config.GetBackplaneConfiguration()
 	if err != nil {
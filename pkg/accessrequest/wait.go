@@ -0,0 +1,157 @@
+package accessrequest
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	ocmsdk "github.com/openshift-online/ocm-sdk-go"
+	acctrspv1 "github.com/openshift-online/ocm-sdk-go/accesstransparency/v1"
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/openshift/backplane-cli/pkg/ocm"
+	"github.com/openshift/backplane-cli/pkg/utils"
+)
+
+const (
+	// DefaultWaitInterval is the default period between two checks of an access request while waiting for its approval
+	DefaultWaitInterval = 30 * time.Second
+)
+
+var (
+	// AccessRequestStates are the states of the access requests, by their lower case name
+	AccessRequestStates = map[string]acctrspv1.AccessRequestState{
+		"pending":  acctrspv1.AccessRequestStatePending,
+		"approved": acctrspv1.AccessRequestStateApproved,
+		"denied":   acctrspv1.AccessRequestStateDenied,
+		"expired":  acctrspv1.AccessRequestStateExpired,
+	}
+
+	// sleep is overridden in tests
+	sleep = time.Sleep
+)
+
+// ParseAccessRequestStates returns the access request states of the given case-insensitive names
+func ParseAccessRequestStates(names []string) ([]acctrspv1.AccessRequestState, error) {
+	states := []acctrspv1.AccessRequestState{}
+	for _, name := range names {
+		state, ok := AccessRequestStates[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("unknown access request state '%s', expected one of pending, approved, denied or expired", name)
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+// GetAccessRequestState returns the state of the access request
+func GetAccessRequestState(accessRequest *acctrspv1.AccessRequest) acctrspv1.AccessRequestState {
+	if status := accessRequest.Status(); status != nil && status.State() != "" {
+		return status.State()
+	}
+	return acctrspv1.AccessRequestState("<Undefined>")
+}
+
+// ListAccessRequests returns the access requests of the cluster in the given states, the most recent first
+func ListAccessRequests(ocmConnection *ocmsdk.Connection, clusterID string, states []acctrspv1.AccessRequestState) ([]*acctrspv1.AccessRequest, error) {
+	isEnabled, err := ocm.DefaultOCMInterface.IsClusterAccessProtectionEnabled(ocmConnection, clusterID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine if access protection is enabled or not for cluster '%s': %v", clusterID, err)
+	}
+
+	if !isEnabled {
+		logger.Warnf("access protection is not enabled for cluster '%s', listing its past access requests only", clusterID)
+	}
+
+	return ocm.DefaultOCMInterface.ListClusterAccessRequests(ocmConnection, clusterID, states)
+}
+
+// PrintAccessRequests prints the access requests as a table
+func PrintAccessRequests(accessRequests []*acctrspv1.AccessRequest) {
+	headers := []string{"ID", "STATE", "CREATED", "EXPIRES", "CREATED BY", "JIRA", "REASON"}
+	rows := [][]string{}
+	for _, accessRequest := range accessRequests {
+		state := GetAccessRequestState(accessRequest)
+		expires := ""
+		switch state {
+		case acctrspv1.AccessRequestStatePending:
+			expires = accessRequest.DeadlineAt().Format(time.RFC3339)
+		case acctrspv1.AccessRequestStateApproved:
+			expires = accessRequest.Status().ExpiresAt().Format(time.RFC3339)
+		}
+		rows = append(rows, []string{
+			accessRequest.ID(),
+			string(state),
+			accessRequest.CreatedAt().Format(time.RFC3339),
+			expires,
+			accessRequest.RequestedBy(),
+			accessRequest.InternalSupportCaseId(),
+			accessRequest.Justification(),
+		})
+	}
+	utils.RenderTable(headers, rows)
+}
+
+// WaitForAccessRequest polls the active access request of the cluster until it is approved, denied or expired,
+// and returns it once approved. An accessRequestID restricts the wait to this access request.
+// The wait has no limit when timeout is zero.
+func WaitForAccessRequest(ocmConnection *ocmsdk.Connection, clusterID, accessRequestID string, interval, timeout time.Duration) (*acctrspv1.AccessRequest, error) {
+	if interval <= 0 {
+		interval = DefaultWaitInterval
+	}
+	waited := time.Duration(0)
+	lastState := acctrspv1.AccessRequestState("")
+
+	for {
+		accessRequest, err := ocm.DefaultOCMInterface.GetClusterActiveAccessRequest(ocmConnection, clusterID)
+		if err != nil {
+			// keep waiting over transient OCM failures
+			logger.Warnf("failed to retrieve the active access request of cluster '%s', retrying: %v", clusterID, err)
+		} else {
+			if accessRequest == nil || (accessRequestID != "" && accessRequest.ID() != accessRequestID) {
+				return nil, closedAccessRequestError(ocmConnection, clusterID, accessRequestID)
+			}
+			if accessRequestID == "" {
+				accessRequestID = accessRequest.ID()
+			}
+
+			state := GetAccessRequestState(accessRequest)
+			if state == acctrspv1.AccessRequestStateApproved {
+				fmt.Printf("Access request '%s' has been approved until %s\n", accessRequestID, accessRequest.Status().ExpiresAt())
+				return accessRequest, nil
+			}
+			if state != lastState {
+				fmt.Printf("Access request '%s' is %s, waiting for its approval by the customer (checking every %s, Ctrl+C to stop)\n",
+					accessRequestID, strings.ToLower(string(state)), interval)
+				lastState = state
+			}
+		}
+
+		pause := interval
+		if timeout > 0 {
+			if waited >= timeout {
+				return nil, fmt.Errorf("timed out after %s waiting for the approval of the access request of cluster '%s'", timeout, clusterID)
+			}
+			pause = min(interval, timeout-waited)
+		}
+		sleep(pause)
+		waited += pause
+	}
+}
+
+// closedAccessRequestError returns the error explaining why the awaited access request is no longer active
+func closedAccessRequestError(ocmConnection *ocmsdk.Connection, clusterID, accessRequestID string) error {
+	if accessRequestID == "" {
+		return fmt.Errorf("there is no pending or approved access request for cluster '%s'", clusterID)
+	}
+
+	accessRequests, err := ocm.DefaultOCMInterface.ListClusterAccessRequests(ocmConnection, clusterID, nil)
+	if err == nil {
+		for _, accessRequest := range accessRequests {
+			if accessRequest.ID() == accessRequestID {
+				return fmt.Errorf("access request '%s' has been %s", accessRequestID, strings.ToLower(string(GetAccessRequestState(accessRequest))))
+			}
+		}
+	}
+	return fmt.Errorf("access request '%s' is no longer pending or approved", accessRequestID)
+}
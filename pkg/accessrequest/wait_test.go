@@ -0,0 +1,119 @@
+package accessrequest
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	acctrspv1 "github.com/openshift-online/ocm-sdk-go/accesstransparency/v1"
+	"github.com/openshift/backplane-cli/pkg/ocm"
+	ocmMock "github.com/openshift/backplane-cli/pkg/ocm/mocks"
+)
+
+var _ = Describe("accessrequest lifecycle", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockOcmInterface *ocmMock.MockOCMInterface
+
+		clusterID string
+		pauses    []time.Duration
+	)
+
+	newAccessRequest := func(id string, state acctrspv1.AccessRequestState) *acctrspv1.AccessRequest {
+		accessRequest, err := acctrspv1.NewAccessRequest().ID(id).
+			Status(acctrspv1.NewAccessRequestStatus().State(state).ExpiresAt(time.Now().Add(time.Hour))).Build()
+		Expect(err).To(BeNil())
+		return accessRequest
+	}
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockOcmInterface = ocmMock.NewMockOCMInterface(mockCtrl)
+		ocm.DefaultOCMInterface = mockOcmInterface
+
+		clusterID = "cluster-12345678"
+		pauses = []time.Duration{}
+		sleep = func(d time.Duration) { pauses = append(pauses, d) }
+	})
+
+	AfterEach(func() {
+		sleep = time.Sleep
+		mockCtrl.Finish()
+	})
+
+	Context("parse states", func() {
+		It("should parse the state names", func() {
+			states, err := ParseAccessRequestStates([]string{"Pending", "denied"})
+			Expect(err).To(BeNil())
+			Expect(states).To(Equal([]acctrspv1.AccessRequestState{acctrspv1.AccessRequestStatePending, acctrspv1.AccessRequestStateDenied}))
+		})
+
+		It("should reject unknown states", func() {
+			_, err := ParseAccessRequestStates([]string{"cancelled"})
+			Expect(err).To(MatchError(ContainSubstring("unknown access request state 'cancelled'")))
+		})
+	})
+
+	Context("list access requests", func() {
+		It("should list the access requests even when access protection is disabled", func() {
+			states := []acctrspv1.AccessRequestState{acctrspv1.AccessRequestStateDenied}
+			mockOcmInterface.EXPECT().IsClusterAccessProtectionEnabled(nil, clusterID).Return(false, nil)
+			mockOcmInterface.EXPECT().ListClusterAccessRequests(nil, clusterID, states).
+				Return([]*acctrspv1.AccessRequest{newAccessRequest("req-1", acctrspv1.AccessRequestStateDenied)}, nil)
+
+			accessRequests, err := ListAccessRequests(nil, clusterID, states)
+			Expect(err).To(BeNil())
+			Expect(accessRequests).To(HaveLen(1))
+		})
+	})
+
+	Context("wait for access request", func() {
+		It("should poll until the access request is approved", func() {
+			gomock.InOrder(
+				mockOcmInterface.EXPECT().GetClusterActiveAccessRequest(nil, clusterID).Return(newAccessRequest("req-1", acctrspv1.AccessRequestStatePending), nil),
+				mockOcmInterface.EXPECT().GetClusterActiveAccessRequest(nil, clusterID).Return(nil, errors.New("transient error")),
+				mockOcmInterface.EXPECT().GetClusterActiveAccessRequest(nil, clusterID).Return(newAccessRequest("req-1", acctrspv1.AccessRequestStateApproved), nil),
+			)
+
+			accessRequest, err := WaitForAccessRequest(nil, clusterID, "req-1", time.Second, 0)
+			Expect(err).To(BeNil())
+			Expect(accessRequest.ID()).To(Equal("req-1"))
+			Expect(pauses).To(Equal([]time.Duration{time.Second, time.Second}))
+		})
+
+		It("should report a denied access request", func() {
+			mockOcmInterface.EXPECT().GetClusterActiveAccessRequest(nil, clusterID).Return(nil, nil)
+			mockOcmInterface.EXPECT().ListClusterAccessRequests(nil, clusterID, nil).
+				Return([]*acctrspv1.AccessRequest{newAccessRequest("req-1", acctrspv1.AccessRequestStateDenied)}, nil)
+
+			_, err := WaitForAccessRequest(nil, clusterID, "req-1", time.Second, 0)
+			Expect(err).To(MatchError("access request 'req-1' has been denied"))
+		})
+
+		It("should fail when there is no active access request to wait for", func() {
+			mockOcmInterface.EXPECT().GetClusterActiveAccessRequest(nil, clusterID).Return(nil, nil)
+
+			_, err := WaitForAccessRequest(nil, clusterID, "", time.Second, 0)
+			Expect(err).To(MatchError(ContainSubstring("there is no pending or approved access request")))
+		})
+
+		It("should time out", func() {
+			mockOcmInterface.EXPECT().GetClusterActiveAccessRequest(nil, clusterID).Return(newAccessRequest("req-1", acctrspv1.AccessRequestStatePending), nil).Times(4)
+
+			_, err := WaitForAccessRequest(nil, clusterID, "", time.Minute, 3*time.Minute)
+			Expect(err).To(MatchError(ContainSubstring("timed out after 3m0s")))
+			Expect(pauses).To(HaveLen(3))
+		})
+
+		It("should wait for the whole timeout when it is not a multiple of the interval", func() {
+			mockOcmInterface.EXPECT().GetClusterActiveAccessRequest(nil, clusterID).Return(newAccessRequest("req-1", acctrspv1.AccessRequestStatePending), nil).Times(3)
+
+			_, err := WaitForAccessRequest(nil, clusterID, "", 30*time.Second, 45*time.Second)
+			Expect(err).To(MatchError(ContainSubstring("timed out after 45s")))
+			Expect(pauses).To(Equal([]time.Duration{30 * time.Second, 15 * time.Second}))
+		})
+	})
+})
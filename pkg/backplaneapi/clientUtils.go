@@ -77,20 +77,20 @@ func (s *DefaultClientUtilsImpl) MakeRawBackplaneAPIClientWithAccessTokenWithCon
 func makeRawBackplaneAPIClientWithAccessTokenCustomProxy(server string, accessToken string, proxyURL string) (BackplaneApi.ClientInterface, error) {
 	clientOpts := makeClientOptions(accessToken)
 
+	httpClient := &http.Client{}
 	if proxyURL != "" {
-		httpClient, err := httpDoerWithProxy(proxyURL)
+		var err error
+		httpClient, err = httpDoerWithProxy(proxyURL)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create http client: %w", err)
 		}
 		logger.Debugf("Using backplane Proxy URL: %s\n", proxyURL)
-
-		return BackplaneApi.NewClient(
-			server,
-			clientOpts, BackplaneApi.WithHTTPClient(httpClient),
-		)
 	}
 
-	return BackplaneApi.NewClient(server, clientOpts)
+	return BackplaneApi.NewClient(
+		server,
+		clientOpts, BackplaneApi.WithHTTPClient(newHTTPDoer(httpClient)),
+	)
 }
 
 func (s *DefaultClientUtilsImpl) MakeRawBackplaneAPIClient(base string) (BackplaneApi.ClientInterface, error) {
@@ -104,7 +104,7 @@ func (s *DefaultClientUtilsImpl) MakeRawBackplaneAPIClient(base string) (Backpla
 func (*DefaultClientUtilsImpl) MakeBackplaneAPIClientWithAccessToken(base, accessToken string) (BackplaneApi.ClientWithResponsesInterface, error) {
 	co := makeClientOptions(accessToken)
 
-	return BackplaneApi.NewClientWithResponses(base, co)
+	return BackplaneApi.NewClientWithResponses(base, co, BackplaneApi.WithHTTPClient(newHTTPDoer(&http.Client{})))
 }
 
 func (s *DefaultClientUtilsImpl) MakeBackplaneAPIClient(base string) (BackplaneApi.ClientWithResponsesInterface, error) {
@@ -2,13 +2,29 @@ package backplaneapi
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	BackplaneApi "github.com/openshift/backplane-api/pkg/client"
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/openshift/backplane-cli/pkg/info"
 )
 
 const deprecationMsg = "server indicated that this client is deprecated"
 
 var ErrDeprecation = errors.New(deprecationMsg)
 
+// ErrDeprecatedEndpoint is returned for the responses of deprecated endpoints when the deprecations are strict
+var ErrDeprecatedEndpoint = errors.New("deprecated backplane API endpoint")
+
 func CheckResponseDeprecation(r *http.Response) error {
 	if r.Header.Get("Deprecated-Client") == "true" {
 		return ErrDeprecation
@@ -16,3 +32,155 @@ func CheckResponseDeprecation(r *http.Response) error {
 
 	return nil
 }
+
+// EndpointDeprecation is the deprecation of an endpoint, given by the Deprecation and Sunset headers of its responses
+type EndpointDeprecation struct {
+	Method string
+	Path   string
+	// Deprecation is the date the endpoint is deprecated since, empty when not given
+	Deprecation string
+	// Sunset is the date the endpoint is removed on, empty when not given
+	Sunset string
+	// Link documents the deprecation, empty when not given
+	Link string
+}
+
+func (d EndpointDeprecation) String() string {
+	msg := fmt.Sprintf("backplane API endpoint %s %s is deprecated", d.Method, d.Path)
+	if d.Deprecation != "" {
+		msg += " since " + d.Deprecation
+	}
+	if d.Sunset != "" {
+		msg += ", it will be removed on " + d.Sunset
+	}
+	if d.Link != "" {
+		msg += ", see " + d.Link
+	}
+	return msg
+}
+
+var (
+	// strictDeprecation is set by the --strict-deprecation flag, the environment variable is used when nil
+	strictDeprecation *bool
+
+	// deprecationsMutex guards the deprecations already warned about during the run
+	deprecationsMutex  sync.Mutex
+	warnedDeprecations = map[string]bool{}
+
+	deprecationOutput io.Writer = os.Stderr
+
+	// deprecationLinkPattern matches a link of the Link header documenting a deprecation or a sunset
+	deprecationLinkPattern = regexp.MustCompile(`<([^>]+)>\s*;[^,]*rel="?(?:deprecation|sunset)"?`)
+)
+
+// SetStrictDeprecation makes the requests to deprecated endpoints fail, it is set by the --strict-deprecation flag
+func SetStrictDeprecation(strict bool) {
+	strictDeprecation = &strict
+}
+
+// IsStrictDeprecation tells if the requests to deprecated endpoints fail,
+// as set by the --strict-deprecation flag or else the BACKPLANE_STRICT_DEPRECATION environment variable
+func IsStrictDeprecation() bool {
+	if strictDeprecation != nil {
+		return *strictDeprecation
+	}
+	strict, _ := strconv.ParseBool(os.Getenv(info.BackplaneStrictDeprecationEnvName))
+	return strict
+}
+
+// ResponseEndpointDeprecation returns the deprecation of the endpoint of the response, if any
+func ResponseEndpointDeprecation(r *http.Response) (EndpointDeprecation, bool) {
+	deprecation := strings.TrimSpace(r.Header.Get("Deprecation"))
+	sunset := strings.TrimSpace(r.Header.Get("Sunset"))
+	if (deprecation == "" || deprecation == "false") && sunset == "" {
+		return EndpointDeprecation{}, false
+	}
+
+	endpoint := EndpointDeprecation{
+		Deprecation: formatDeprecationDate(deprecation),
+		Sunset:      formatDeprecationDate(sunset),
+	}
+	if r.Request != nil {
+		endpoint.Method = r.Request.Method
+		endpoint.Path = r.Request.URL.Path
+	}
+	for _, link := range r.Header.Values("Link") {
+		if match := deprecationLinkPattern.FindStringSubmatch(link); match != nil {
+			endpoint.Link = match[1]
+			break
+		}
+	}
+	return endpoint, true
+}
+
+// formatDeprecationDate formats the date of a Deprecation or a Sunset header,
+// given as an HTTP date or as a structured date like @1688169599. The legacy 'Deprecation: true' has no date.
+func formatDeprecationDate(value string) string {
+	if value == "" || value == "true" {
+		return ""
+	}
+	if seconds, err := strconv.ParseInt(strings.TrimPrefix(value, "@"), 10, 64); err == nil && strings.HasPrefix(value, "@") {
+		return time.Unix(seconds, 0).UTC().Format(time.DateOnly)
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return date.UTC().Format(time.DateOnly)
+	}
+	return value
+}
+
+// DeprecationDoer is the middleware of the backplane API clients surfacing the deprecations of the responses.
+// It warns once per run about a deprecated client and about each deprecated endpoint,
+// and fails the requests to deprecated endpoints when the deprecations are strict.
+type DeprecationDoer struct {
+	Doer BackplaneApi.HttpRequestDoer
+}
+
+// NewDeprecationDoer returns a DeprecationDoer sending the requests with the doer
+func NewDeprecationDoer(doer BackplaneApi.HttpRequestDoer) *DeprecationDoer {
+	return &DeprecationDoer{Doer: doer}
+}
+
+func (d *DeprecationDoer) Do(req *http.Request) (*http.Response, error) {
+	rsp, err := d.Doer.Do(req)
+	if err != nil {
+		return rsp, err
+	}
+
+	if errors.Is(CheckResponseDeprecation(rsp), ErrDeprecation) {
+		warnDeprecation("client", fmt.Sprintf("The server indicated that backplane-cli version %s is deprecated. Please update as soon as possible.", info.DefaultInfoService.GetVersion()))
+	}
+
+	endpoint, deprecated := ResponseEndpointDeprecation(rsp)
+	if !deprecated {
+		return rsp, nil
+	}
+
+	if IsStrictDeprecation() {
+		_, _ = io.Copy(io.Discard, rsp.Body)
+		_ = rsp.Body.Close()
+		return nil, fmt.Errorf("%w: %s", ErrDeprecatedEndpoint, endpoint)
+	}
+
+	warnDeprecation(endpoint.Method+" "+endpoint.Path,
+		fmt.Sprintf("The %s. Set --strict-deprecation or %s=true to fail on deprecated endpoints.", endpoint, info.BackplaneStrictDeprecationEnvName))
+
+	return rsp, nil
+}
+
+// warnDeprecation prints the warning unless a warning was already printed for the key during the run
+func warnDeprecation(key string, warning string) {
+	deprecationsMutex.Lock()
+	defer deprecationsMutex.Unlock()
+
+	if warnedDeprecations[key] {
+		logger.Debugln(warning)
+		return
+	}
+	warnedDeprecations[key] = true
+	_, _ = fmt.Fprintf(deprecationOutput, "WARNING: %s\n", warning)
+}
+
+// newHTTPDoer returns the doer of the backplane API clients, sending the requests with the HTTP client
+func newHTTPDoer(httpClient BackplaneApi.HttpRequestDoer) BackplaneApi.HttpRequestDoer {
+	return NewDeprecationDoer(NewRetryDoer(httpClient))
+}
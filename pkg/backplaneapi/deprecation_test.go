@@ -2,6 +2,7 @@ package backplaneapi_test
 
 import (
 	"net/http"
+	"net/url"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -37,4 +38,44 @@ var _ = Describe("backplaneapi/deprecation", func() {
 
 		Expect(err).To(BeNil())
 	})
+
+	Context("ResponseEndpointDeprecation", func() {
+		response := func(header http.Header) *http.Response {
+			return &http.Response{
+				Header:  header,
+				Request: &http.Request{Method: http.MethodGet, URL: &url.URL{Path: "/backplane/login/cluster-id"}},
+			}
+		}
+
+		It("Returns the deprecation and the sunset dates of the endpoint.", func() {
+			deprecation, ok := backplaneapi.ResponseEndpointDeprecation(response(http.Header{
+				"Deprecation": {"@1719792000"},
+				"Sunset":      {"Wed, 01 Jan 2025 00:00:00 GMT"},
+				"Link":        {`<https://example.com/docs/login-v2>; rel="deprecation"; type="text/html"`},
+			}))
+
+			Expect(ok).To(BeTrue())
+			Expect(deprecation).To(Equal(backplaneapi.EndpointDeprecation{
+				Method:      http.MethodGet,
+				Path:        "/backplane/login/cluster-id",
+				Deprecation: "2024-07-01",
+				Sunset:      "2025-01-01",
+				Link:        "https://example.com/docs/login-v2",
+			}))
+			Expect(deprecation.String()).To(Equal("backplane API endpoint GET /backplane/login/cluster-id is deprecated since 2024-07-01, it will be removed on 2025-01-01, see https://example.com/docs/login-v2"))
+		})
+
+		It("Accepts the legacy 'Deprecation: true' header.", func() {
+			deprecation, ok := backplaneapi.ResponseEndpointDeprecation(response(http.Header{"Deprecation": {"true"}}))
+
+			Expect(ok).To(BeTrue())
+			Expect(deprecation.String()).To(Equal("backplane API endpoint GET /backplane/login/cluster-id is deprecated"))
+		})
+
+		It("Returns false when the endpoint is not deprecated.", func() {
+			_, ok := backplaneapi.ResponseEndpointDeprecation(response(http.Header{"Deprecated-Client": {"true"}}))
+
+			Expect(ok).To(BeFalse())
+		})
+	})
 })
@@ -0,0 +1,157 @@
+package backplaneapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	BackplaneApi "github.com/openshift/backplane-api/pkg/client"
+	logger "github.com/sirupsen/logrus"
+)
+
+const (
+	// RequestIDHeader identifies a request in the logs of backplane-cli, the proxy and the backplane API
+	RequestIDHeader = "X-Request-Id"
+
+	defaultMaxRetries = 3
+	defaultBaseDelay  = 500 * time.Millisecond
+	defaultMaxDelay   = 10 * time.Second
+)
+
+// RetryDoer is the HTTP doer of the backplane API clients. It retries the idempotent requests failing transiently,
+// e.g. on a 502 or 503 response during a deployment, with a jittered exponential backoff honouring Retry-After.
+// Every request is given a request ID, logged along with its response.
+type RetryDoer struct {
+	Doer       BackplaneApi.HttpRequestDoer
+	MaxRetries int
+	BaseDelay  time.Duration
+	// MaxDelay caps the backoff and the Retry-After of the responses
+	MaxDelay time.Duration
+}
+
+// sleep waits for the delay unless the context is done first
+var sleep = func(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// NewRetryDoer returns a RetryDoer sending the requests with the doer
+func NewRetryDoer(doer BackplaneApi.HttpRequestDoer) *RetryDoer {
+	return &RetryDoer{
+		Doer:       doer,
+		MaxRetries: defaultMaxRetries,
+		BaseDelay:  defaultBaseDelay,
+		MaxDelay:   defaultMaxDelay,
+	}
+}
+
+func (d *RetryDoer) Do(req *http.Request) (*http.Response, error) {
+	requestID := req.Header.Get(RequestIDHeader)
+	if requestID == "" {
+		requestID = uuid.NewString()
+		req.Header.Set(RequestIDHeader, requestID)
+	}
+	log := logger.WithFields(logger.Fields{"request-id": requestID, "method": req.Method, "url": req.URL.Redacted()})
+
+	// a request whose body cannot be sent again is not retried
+	retryable := isIdempotent(req.Method) && (req.Body == nil || req.Body == http.NoBody || req.GetBody != nil)
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewind the request body: %w", err)
+			}
+			req.Body = body
+		}
+
+		start := time.Now()
+		rsp, err := d.Doer.Do(req)
+		if err != nil {
+			log.Debugf("Backplane API request failed after %s: %v", time.Since(start), err)
+		} else {
+			log.WithField("status", rsp.StatusCode).Debugf("Backplane API responded after %s", time.Since(start))
+		}
+
+		if !retryable || attempt >= d.MaxRetries || !shouldRetry(req.Context(), rsp, err) {
+			return rsp, err
+		}
+
+		delay := d.backoff(attempt, rsp)
+		reason := fmt.Sprint(err)
+		if rsp != nil {
+			reason = rsp.Status
+			_, _ = io.Copy(io.Discard, rsp.Body)
+			_ = rsp.Body.Close()
+		}
+		log.Warnf("Backplane API request failed with %s, retrying in %s (%d/%d)", reason, delay.Round(time.Millisecond), attempt+1, d.MaxRetries)
+
+		if err := sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// isIdempotent tells if a request can be sent again without side effects
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// shouldRetry tells if a request failed transiently
+func shouldRetry(ctx context.Context, rsp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	switch rsp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns the delay before the next attempt: the Retry-After of the response when given,
+// else an exponential delay with a random jitter, both capped by MaxDelay
+func (d *RetryDoer) backoff(attempt int, rsp *http.Response) time.Duration {
+	if rsp != nil {
+		if delay, ok := retryAfter(rsp.Header.Get("Retry-After")); ok {
+			return min(delay, d.MaxDelay)
+		}
+	}
+
+	delay := min(d.BaseDelay<<attempt, d.MaxDelay)
+	// half of the delay is random, to not retry all at once
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// retryAfter parses a Retry-After header, given in seconds or as an HTTP date
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,277 @@
+package backplaneapi
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/backplane-cli/pkg/info"
+)
+
+var _ = Describe("backplaneapi/doer", func() {
+	var (
+		statuses   []int
+		headers    []http.Header
+		bodies     []string
+		requestIDs []string
+		delays     []time.Duration
+		server     *httptest.Server
+		doer       *RetryDoer
+	)
+
+	BeforeEach(func() {
+		statuses, headers, bodies, requestIDs, delays = nil, nil, nil, nil, nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			requestIDs = append(requestIDs, r.Header.Get(RequestIDHeader))
+
+			attempt := len(requestIDs) - 1
+			status := statuses[min(attempt, len(statuses)-1)]
+			if attempt < len(headers) {
+				for key, values := range headers[attempt] {
+					w.Header()[key] = values
+				}
+			}
+			w.WriteHeader(status)
+		}))
+		DeferCleanup(server.Close)
+
+		doer = NewRetryDoer(server.Client())
+
+		originalSleep := sleep
+		sleep = func(_ context.Context, delay time.Duration) error {
+			delays = append(delays, delay)
+			return nil
+		}
+		DeferCleanup(func() { sleep = originalSleep })
+	})
+
+	send := func(method string, body string) *http.Response {
+		var reader io.Reader
+		if body != "" {
+			reader = strings.NewReader(body)
+		}
+		req, err := http.NewRequest(method, server.URL, reader)
+		Expect(err).ToNot(HaveOccurred())
+		rsp, err := doer.Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		return rsp
+	}
+
+	It("retries an idempotent request failing transiently, with the same request ID", func() {
+		statuses = []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK}
+
+		rsp := send(http.MethodGet, "")
+
+		Expect(rsp.StatusCode).To(Equal(http.StatusOK))
+		Expect(requestIDs).To(HaveLen(3))
+		Expect(requestIDs[0]).ToNot(BeEmpty())
+		Expect(requestIDs).To(HaveEach(requestIDs[0]))
+		Expect(delays).To(HaveLen(2))
+	})
+
+	It("does not retry a request which is not idempotent", func() {
+		statuses = []int{http.StatusServiceUnavailable, http.StatusOK}
+
+		rsp := send(http.MethodPost, "{}")
+
+		Expect(rsp.StatusCode).To(Equal(http.StatusServiceUnavailable))
+		Expect(requestIDs).To(HaveLen(1))
+	})
+
+	It("does not retry a request failing for good", func() {
+		statuses = []int{http.StatusNotFound, http.StatusOK}
+
+		rsp := send(http.MethodGet, "")
+
+		Expect(rsp.StatusCode).To(Equal(http.StatusNotFound))
+		Expect(requestIDs).To(HaveLen(1))
+	})
+
+	It("returns the last response once the retries are exhausted", func() {
+		statuses = []int{http.StatusGatewayTimeout}
+
+		rsp := send(http.MethodGet, "")
+
+		Expect(rsp.StatusCode).To(Equal(http.StatusGatewayTimeout))
+		Expect(requestIDs).To(HaveLen(defaultMaxRetries + 1))
+	})
+
+	It("sends the body again on retry", func() {
+		statuses = []int{http.StatusServiceUnavailable, http.StatusOK}
+
+		rsp := send(http.MethodPut, `{"key":"value"}`)
+
+		Expect(rsp.StatusCode).To(Equal(http.StatusOK))
+		Expect(bodies).To(Equal([]string{`{"key":"value"}`, `{"key":"value"}`}))
+	})
+
+	It("honours Retry-After, capped by the maximal delay", func() {
+		statuses = []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusOK}
+		headers = []http.Header{{"Retry-After": {"2"}}, {"Retry-After": {"3600"}}}
+
+		send(http.MethodGet, "")
+
+		Expect(delays).To(Equal([]time.Duration{2 * time.Second, doer.MaxDelay}))
+	})
+
+	It("keeps the request ID given by the caller", func() {
+		statuses = []int{http.StatusOK}
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set(RequestIDHeader, "my-request")
+
+		_, err = doer.Do(req)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requestIDs).To(Equal([]string{"my-request"}))
+	})
+
+	It("stops retrying once the context is done", func() {
+		statuses = []int{http.StatusServiceUnavailable}
+		ctx, cancel := context.WithCancel(context.Background())
+		sleep = func(_ context.Context, _ time.Duration) error {
+			cancel()
+			return ctx.Err()
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = doer.Do(req)
+
+		Expect(err).To(MatchError(context.Canceled))
+		Expect(requestIDs).To(HaveLen(1))
+	})
+
+	Context("backoff", func() {
+		It("grows exponentially with a jitter, up to the maximal delay", func() {
+			for attempt := 0; attempt < 8; attempt++ {
+				delay := min(doer.BaseDelay<<attempt, doer.MaxDelay)
+				Expect(doer.backoff(attempt, nil)).To(And(
+					BeNumerically(">=", delay/2),
+					BeNumerically("<=", delay),
+				))
+			}
+		})
+
+		It("parses Retry-After given as an HTTP date", func() {
+			delay, ok := retryAfter(time.Now().Add(5 * time.Second).UTC().Format(http.TimeFormat))
+
+			Expect(ok).To(BeTrue())
+			Expect(delay).To(BeNumerically("~", 5*time.Second, time.Second))
+		})
+
+		It("ignores an invalid Retry-After", func() {
+			_, ok := retryAfter("soon")
+
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
+
+// doerFunc stands in for the HTTP client of the backplane API clients
+type doerFunc func(req *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+var _ = Describe("backplaneapi/DeprecationDoer", func() {
+	var (
+		header http.Header
+		output *bytes.Buffer
+		doer   *DeprecationDoer
+	)
+
+	BeforeEach(func() {
+		header = http.Header{}
+		output = &bytes.Buffer{}
+		doer = NewDeprecationDoer(doerFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(strings.NewReader("{}")), Request: req}, nil
+		}))
+
+		originalOutput, originalStrict := deprecationOutput, strictDeprecation
+		deprecationOutput, strictDeprecation = output, nil
+		warnedDeprecations = map[string]bool{}
+		GinkgoT().Setenv(info.BackplaneStrictDeprecationEnvName, "")
+		DeferCleanup(func() {
+			deprecationOutput, strictDeprecation = originalOutput, originalStrict
+			warnedDeprecations = map[string]bool{}
+		})
+	})
+
+	send := func(path string) (*http.Response, error) {
+		req, err := http.NewRequest(http.MethodGet, "https://api.example.com"+path, nil)
+		Expect(err).ToNot(HaveOccurred())
+		return doer.Do(req)
+	}
+
+	It("warns once per run about a deprecated endpoint", func() {
+		header.Set("Deprecation", "@1719792000")
+
+		for i := 0; i < 3; i++ {
+			rsp, err := send("/backplane/cluster/cluster-id")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rsp.StatusCode).To(Equal(http.StatusOK))
+		}
+
+		Expect(output.String()).To(Equal("WARNING: The backplane API endpoint GET /backplane/cluster/cluster-id is deprecated since 2024-07-01. " +
+			"Set --strict-deprecation or BACKPLANE_STRICT_DEPRECATION=true to fail on deprecated endpoints.\n"))
+	})
+
+	It("warns once per run about a deprecated client", func() {
+		header.Set("Deprecated-Client", "true")
+
+		_, _ = send("/backplane/cluster/cluster-id")
+		_, _ = send("/backplane/script")
+
+		Expect(strings.Count(output.String(), "is deprecated. Please update as soon as possible.")).To(Equal(1))
+	})
+
+	It("does not warn about an endpoint which is not deprecated", func() {
+		_, err := send("/backplane/cluster/cluster-id")
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(output.String()).To(BeEmpty())
+	})
+
+	It("fails on a deprecated endpoint when strict", func() {
+		header.Set("Sunset", "Wed, 01 Jan 2025 00:00:00 GMT")
+		SetStrictDeprecation(true)
+
+		rsp, err := send("/backplane/cluster/cluster-id")
+
+		Expect(rsp).To(BeNil())
+		Expect(err).To(MatchError(ErrDeprecatedEndpoint))
+		Expect(err.Error()).To(ContainSubstring("it will be removed on 2025-01-01"))
+	})
+
+	It("is strict when set by the environment variable, unless the flag says otherwise", func() {
+		header.Set("Deprecation", "true")
+		GinkgoT().Setenv(info.BackplaneStrictDeprecationEnvName, "true")
+
+		_, err := send("/backplane/cluster/cluster-id")
+		Expect(err).To(MatchError(ErrDeprecatedEndpoint))
+
+		SetStrictDeprecation(false)
+		_, err = send("/backplane/cluster/cluster-id")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("does not fail on a deprecated client when strict", func() {
+		header.Set("Deprecated-Client", "true")
+		SetStrictDeprecation(true)
+
+		_, err := send("/backplane/cluster/cluster-id")
+
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,124 @@
+package backplaneapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	BackplaneApi "github.com/openshift/backplane-api/pkg/client"
+)
+
+// The kinds of the errors of the backplane API, to branch on with errors.Is
+var (
+	ErrNotFound                 = errors.New("not found")
+	ErrUnauthorized             = errors.New("unauthorized")
+	ErrForbidden                = errors.New("forbidden")
+	ErrClusterHibernating       = errors.New("cluster is hibernating")
+	ErrAccessProtectionRequired = errors.New("access protection requires an approved access request")
+	ErrUnavailable              = errors.New("backplane API unavailable")
+)
+
+// The reasons of the backplane API errors refining the kind given by their status code
+const (
+	reasonClusterHibernating       = "ClusterHibernating"
+	reasonAccessProtectionRequired = "AccessProtectionRequired"
+)
+
+// errorBody is the body of an error response of the backplane API, with the reason some responses carry
+type errorBody struct {
+	BackplaneApi.Error
+	Reason *string `json:"reason,omitempty"`
+}
+
+// The maximal length of an unparsable response body kept in the error message
+const maxErrorBodyLength = 200
+
+// APIError is an error response of the backplane API
+type APIError struct {
+	StatusCode int
+	Message    string
+	RequestID  string
+
+	kind error
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("error from backplane: \n Status Code: %d\n Message: %s", e.StatusCode, e.Message)
+	if e.RequestID != "" {
+		msg += fmt.Sprintf("\n Request ID: %s", e.RequestID)
+	}
+	return msg
+}
+
+// Unwrap returns the kind of the error, nil when it is of no known kind
+func (e *APIError) Unwrap() error {
+	return e.kind
+}
+
+// ParseAPIError returns the APIError of a response of the backplane API which is not successful.
+// The message of a body which is not a backplane API error, e.g. an error page of the proxy, is the truncated body.
+func ParseAPIError(rsp *http.Response) error {
+	if rsp == nil {
+		return errors.New("parse err provided nil http response")
+	}
+	defer func() {
+		_ = rsp.Body.Close()
+	}()
+
+	apiErr := &APIError{StatusCode: rsp.StatusCode, Message: rsp.Status, RequestID: rsp.Header.Get(RequestIDHeader)}
+	if apiErr.RequestID == "" && rsp.Request != nil {
+		apiErr.RequestID = rsp.Request.Header.Get(RequestIDHeader)
+	}
+
+	body, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read the response from backplane (status:'%s', code:'%d'): %w", rsp.Status, rsp.StatusCode, err)
+	}
+
+	var reason string
+	var data errorBody
+	if err := json.Unmarshal(body, &data); err == nil {
+		if data.StatusCode != nil {
+			apiErr.StatusCode = *data.StatusCode
+		}
+		if data.Message != nil {
+			apiErr.Message = *data.Message
+		}
+		if data.Reason != nil {
+			reason = *data.Reason
+		}
+	} else if bodyStr := strings.TrimSpace(strings.ReplaceAll(string(body), "\n", " ")); bodyStr != "" {
+		if len(bodyStr) > maxErrorBodyLength {
+			bodyStr = bodyStr[:maxErrorBodyLength] + "..."
+		}
+		apiErr.Message = fmt.Sprintf("%s (body starts with: '%s')", rsp.Status, bodyStr)
+	}
+
+	apiErr.kind = errorKind(apiErr.StatusCode, reason)
+	return apiErr
+}
+
+// errorKind returns the kind of an error from its status code, refined by the reason of the error body
+func errorKind(statusCode int, reason string) error {
+	switch statusCode {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		if reason == reasonAccessProtectionRequired {
+			return ErrAccessProtectionRequired
+		}
+		return ErrForbidden
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return ErrUnavailable
+	}
+
+	if reason == reasonClusterHibernating && statusCode >= http.StatusBadRequest && statusCode < http.StatusInternalServerError {
+		return ErrClusterHibernating
+	}
+	return nil
+}
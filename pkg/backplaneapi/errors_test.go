@@ -0,0 +1,73 @@
+package backplaneapi_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/backplane-cli/pkg/backplaneapi"
+)
+
+var _ = Describe("backplaneapi/errors", func() {
+	response := func(statusCode int, body string) *http.Response {
+		return &http.Response{
+			StatusCode: statusCode,
+			Status:     http.StatusText(statusCode),
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	}
+
+	DescribeTable("ParseAPIError returns the kind of the error",
+		func(statusCode int, body string, kind error) {
+			err := backplaneapi.ParseAPIError(response(statusCode, body))
+
+			var apiErr *backplaneapi.APIError
+			Expect(errors.As(err, &apiErr)).To(BeTrue())
+			if kind == nil {
+				Expect(errors.Unwrap(err)).To(BeNil())
+			} else {
+				Expect(err).To(MatchError(kind))
+			}
+		},
+		Entry("not found", http.StatusNotFound, `{"statusCode": 404, "message": "cluster not found"}`, backplaneapi.ErrNotFound),
+		Entry("unauthorized", http.StatusUnauthorized, `{"statusCode": 401, "message": "invalid token"}`, backplaneapi.ErrUnauthorized),
+		Entry("forbidden", http.StatusForbidden, `{"statusCode": 403, "message": "not allowed"}`, backplaneapi.ErrForbidden),
+		Entry("access protection", http.StatusForbidden, `{"statusCode": 403, "message": "Access Protection is enabled, an approved access request is required", "reason": "AccessProtectionRequired"}`, backplaneapi.ErrAccessProtectionRequired),
+		Entry("hibernating cluster", http.StatusBadRequest, `{"statusCode": 400, "message": "cluster is hibernating", "reason": "ClusterHibernating"}`, backplaneapi.ErrClusterHibernating),
+		Entry("not found mentioning an access request", http.StatusNotFound, `{"statusCode": 404, "message": "access request not found"}`, backplaneapi.ErrNotFound),
+		Entry("forbidden mentioning access protection without a reason", http.StatusForbidden, `{"statusCode": 403, "message": "access protection is enabled"}`, backplaneapi.ErrForbidden),
+		Entry("unavailable", http.StatusServiceUnavailable, `<html>Service Unavailable</html>`, backplaneapi.ErrUnavailable),
+		Entry("other error", http.StatusBadRequest, `{"statusCode": 400, "message": "bad request"}`, nil),
+	)
+
+	It("formats the status code and the message of the backplane API error", func() {
+		err := backplaneapi.ParseAPIError(response(http.StatusNotFound, `{"statusCode": 404, "message": "cluster not found"}`))
+
+		Expect(err.Error()).To(Equal("error from backplane: \n Status Code: 404\n Message: cluster not found"))
+	})
+
+	It("keeps the start of a body which is not a backplane API error", func() {
+		err := backplaneapi.ParseAPIError(response(http.StatusBadGateway, "<html>\n"+strings.Repeat("a", 300)+"</html>"))
+
+		Expect(err.Error()).To(ContainSubstring("Message: Bad Gateway (body starts with: '<html> aaa"))
+		Expect(err.Error()).To(ContainSubstring("...')"))
+	})
+
+	It("includes the request ID", func() {
+		rsp := response(http.StatusNotFound, `{"statusCode": 404, "message": "cluster not found"}`)
+		rsp.Request = &http.Request{Header: http.Header{backplaneapi.RequestIDHeader: {"my-request"}}}
+
+		err := backplaneapi.ParseAPIError(rsp)
+
+		Expect(err.Error()).To(HaveSuffix("\n Request ID: my-request"))
+	})
+
+	It("fails on a nil response", func() {
+		Expect(backplaneapi.ParseAPIError(nil)).To(MatchError(ContainSubstring("nil http response")))
+	})
+})
@@ -2,11 +2,13 @@ package config
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -32,26 +34,42 @@ type AccessRequestsJiraConfiguration struct {
 	ProjectToTransitionsNames map[string]JiraTransitionsNamesForAccessRequests `json:"project-to-transitions-names"`
 }
 
+// JiraLoginProjectConfiguration defines how to find the cluster of the issues of a JIRA project to log into
+type JiraLoginProjectConfiguration struct {
+	// ClusterField is the custom field holding the cluster ID, external ID or name
+	ClusterField string `json:"cluster-field,omitempty"`
+	// ClusterPattern extracts the cluster from the summary or the description when the field is empty,
+	// from the first capture group of the regular expression
+	ClusterPattern string `json:"cluster-pattern,omitempty"`
+}
+
+// JiraLoginProjectsConfiguration maps the JIRA project keys to how to find the cluster of their issues
+type JiraLoginProjectsConfiguration map[string]JiraLoginProjectConfiguration
+
 // BackplaneConfiguration represents the configuration for backplane-cli.
 // Note: Please update the validateConfig function if there are any required keys added.
 type BackplaneConfiguration struct {
-	URL                         string                          `json:"url"`
-	ProxyURL                    *string                         `json:"proxy-url"`
-	AwsProxy                    *string                         `json:"aws-proxy"`
-	SessionDirectory            string                          `json:"session-dir"`
-	AssumeInitialArn            string                          `json:"assume-initial-arn"`
-	ProdEnvName                 string                          `json:"prod-env-name"`
-	PagerDutyAPIKey             string                          `json:"pd-key"`
-	JiraBaseURL                 string                          `json:"jira-base-url"`
-	JiraToken                   string                          `json:"jira-token"`
-	JiraEmail                   string                          `json:"jira-email"`
-	JiraConfigForAccessRequests AccessRequestsJiraConfiguration `json:"jira-config-for-access-requests"`
-	VPNCheckEndpoint            string                          `json:"vpn-check-endpoint"`
-	ProxyCheckEndpoint          string                          `json:"proxy-check-endpoint"`
-	DisplayClusterInfo          bool                            `json:"display-cluster-info"`
-	DisableKubePS1Warning       bool                            `json:"disable-kube-ps1-warning"`
-	Govcloud                    bool                            `json:"govcloud"`
-	SessionDurationMinutes      int                             `json:"session-duration-minutes"`
+	Profile                     string                          `json:"profile,omitempty" config:"-"`
+	OCMEnvironment              string                          `json:"ocm-env,omitempty" config:"string" desc:"OCM environment the settings are for, e.g. production or staging"`
+	URL                         string                          `json:"url" config:"url,deprecated" desc:"Backplane API URL, retrieved from the OCM environment"`
+	ProxyURL                    *string                         `json:"proxy-url" config:"urls" desc:"Squid proxy URLs, the first working one is used"`
+	AwsProxy                    *string                         `json:"aws-proxy" config:"urls" desc:"Proxy URLs of the AWS operations, proxy-url by default"`
+	SessionDirectory            string                          `json:"session-dir" config:"string" desc:"Backplane CLI session directory"`
+	AssumeInitialArn            string                          `json:"assume-initial-arn" config:"string" desc:"ARN of the initial role assumed by the cloud commands"`
+	ProdEnvName                 string                          `json:"prod-env-name" config:"string" desc:"Name of the production OCM environment"`
+	PagerDutyAPIKey             string                          `json:"pd-key" config:"string,secret" desc:"PagerDuty API User Key"`
+	JiraBaseURL                 string                          `json:"jira-base-url" config:"url" desc:"JIRA base URL"`
+	JiraToken                   string                          `json:"jira-token" config:"string,secret" desc:"JIRA token"`
+	JiraEmail                   string                          `json:"jira-email" config:"string" desc:"JIRA email"`
+	JiraConfigForAccessRequests AccessRequestsJiraConfiguration `json:"jira-config-for-access-requests" config:"object" desc:"JIRA projects, issue types and transitions of the access requests"`
+	JiraLoginProjects           JiraLoginProjectsConfiguration  `json:"jira-login-projects" config:"object" desc:"JIRA projects to log in from, with the field or pattern of their cluster"`
+	VPNCheckEndpoint            string                          `json:"vpn-check-endpoint" config:"url" desc:"URL the VPN health check requests"`
+	ProxyCheckEndpoint          string                          `json:"proxy-check-endpoint" config:"url" desc:"URL the proxy health check requests"`
+	DisplayClusterInfo          bool                            `json:"display-cluster-info" config:"bool" desc:"Display the cluster info after login"`
+	DisableKubePS1Warning       bool                            `json:"disable-kube-ps1-warning" config:"bool" desc:"Disable the warning when kube-ps1 is not set up"`
+	Govcloud                    bool                            `json:"govcloud" config:"bool" desc:"Set to true if used in FedRAMP"`
+	SessionDurationMinutes      int                             `json:"session-duration-minutes" config:"int" desc:"Duration of the cloud console sessions in minutes, 15 by default and 60 at most"`
+	DisableUpdateCheck          bool                            `json:"disable-update-check" config:"bool" desc:"Disable the notices of the new backplane-cli releases"`
 }
 
 const (
@@ -61,6 +79,7 @@ const (
 	JiraTokenViperKey                   = "jira-token"
 	JiraEmailViperKey                   = "jira-email"
 	JiraConfigForAccessRequestsKey      = "jira-config-for-access-requests"
+	JiraLoginProjectsKey                = "jira-login-projects"
 	prodEnvNameDefaultValue             = "production"
 	JiraBaseURLDefaultValue             = "https://redhat.atlassian.net"
 	proxyTestTimeout                    = 10 * time.Second
@@ -87,6 +106,13 @@ var JiraConfigForAccessRequestsDefaultValue = AccessRequestsJiraConfiguration{
 	},
 }
 
+// JiraLoginProjectsDefaultValue are the JIRA projects 'login --jira' works with when none is configured
+var JiraLoginProjectsDefaultValue = JiraLoginProjectsConfiguration{
+	"OHSS": {
+		ClusterField: "customfield_10852",
+	},
+}
+
 // GetConfigFilePath returns the Backplane CLI configuration filepath
 func GetConfigFilePath() (string, error) {
 	// Check if user has explicitly defined backplane config path
@@ -137,6 +163,13 @@ func GetBackplaneConfigurationWithConn(ocmConn *ocmsdk.Connection) (bpConfig Bac
 		}
 	}
 
+	// The profile in use overrides the top level settings of the config file
+	if bpConfig.Profile, err = applyProfile(); err != nil {
+		return bpConfig, err
+	}
+	// The OCM environment is optional, it makes sure the settings are used against the right environment
+	bpConfig.OCMEnvironment = viper.GetString(OCMEnvKey)
+
 	if err = validateConfig(); err != nil {
 		logger.Warn(err)
 	}
@@ -208,6 +241,8 @@ func GetBackplaneConfigurationWithConn(ocmConn *ocmsdk.Connection) (bpConfig Bac
 	bpConfig.AssumeInitialArn = viper.GetString(AssumeInitialArnKey)
 	bpConfig.DisplayClusterInfo = viper.GetBool("display-cluster-info")
 	bpConfig.DisableKubePS1Warning = viper.GetBool("disable-kube-ps1-warning")
+	bpConfig.SessionDurationMinutes = viper.GetInt("session-duration-minutes")
+	bpConfig.DisableUpdateCheck = viper.GetBool("disable-update-check")
 
 	// pagerDuty token is optional. Don't even check for FedRAMP
 	if !(bpConfig.Govcloud) {
@@ -254,6 +289,12 @@ func GetBackplaneConfigurationWithConn(ocmConn *ocmsdk.Connection) (bpConfig Bac
 		}
 	}
 
+	// JIRA projects to log in from are optional, the configured ones are added to the default ones
+	bpConfig.JiraLoginProjects, err = getJiraLoginProjects()
+	if err != nil {
+		logger.Warnf("failed to unmarshal '%s' entry as json in '%s' config file: %v", JiraLoginProjectsKey, filePath, err)
+	}
+
 	// Load VPN and Proxy check endpoints from the local backplane configuration file
 	// Don't even check for FedRAMP
 	if !(bpConfig.Govcloud) {
@@ -267,6 +308,32 @@ func GetBackplaneConfigurationWithConn(ocmConn *ocmsdk.Connection) (bpConfig Bac
 	return bpConfig, nil
 }
 
+// getJiraLoginProjects returns the default JIRA projects to log in from, along with the configured ones
+func getJiraLoginProjects() (JiraLoginProjectsConfiguration, error) {
+	projects := JiraLoginProjectsConfiguration{}
+	for key, project := range JiraLoginProjectsDefaultValue {
+		projects[key] = project
+	}
+
+	configured := viper.Get(JiraLoginProjectsKey)
+	if configured == nil {
+		return projects, nil
+	}
+	content, err := json.Marshal(configured)
+	if err != nil {
+		return projects, err
+	}
+	configuredProjects := JiraLoginProjectsConfiguration{}
+	if err := json.Unmarshal(content, &configuredProjects); err != nil {
+		return projects, err
+	}
+	// viper lower cases the keys read from the config file, while JIRA project keys are upper case
+	for key, project := range configuredProjects {
+		projects[strings.ToUpper(key)] = project
+	}
+	return projects, nil
+}
+
 var testProxy = func(ctx context.Context, testURL string, proxyURL url.URL) error {
 	// Try call the test URL via the proxy
 	client := &http.Client{
@@ -383,11 +450,16 @@ loop:
 }
 
 func validateConfig() error {
+	return validateViperConfig(viper.GetViper())
+}
+
+// validateViperConfig checks the required keys of the configuration read by a viper instance
+func validateViperConfig(v *viper.Viper) error {
 
 	// No Proxy used in FedRAMP
-	if !(viper.GetBool("govcloud")) {
+	if !(v.GetBool("govcloud")) {
 		// Validate the proxy url
-		if viper.GetStringSlice("proxy-url") == nil && os.Getenv(info.BackplaneProxyEnvName) == "" {
+		if v.GetStringSlice("proxy-url") == nil && os.Getenv(info.BackplaneProxyEnvName) == "" {
 			return fmt.Errorf("proxy-url must be set explicitly in either config file or via the environment HTTPS_PROXY")
 		}
 	}
@@ -412,6 +484,9 @@ func (config *BackplaneConfiguration) GetBackplaneURLWithConn(ocmConn *ocmsdk.Co
 	if err != nil {
 		return "", err
 	}
+	if err := config.checkOCMEnvironment(ocmEnv); err != nil {
+		return "", err
+	}
 	url, ok := ocmEnv.GetBackplaneURL()
 	if !ok {
 		return "", fmt.Errorf("the requested API endpoint is not available for the OCM environment: %v", ocmEnv.Name())
@@ -426,6 +501,9 @@ func (config *BackplaneConfiguration) GetBackplaneURL() (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if err := config.checkOCMEnvironment(ocmEnv); err != nil {
+		return "", err
+	}
 	url, ok := ocmEnv.GetBackplaneURL()
 	if !ok {
 		return "", fmt.Errorf("the requested API endpoint is not available for the OCM environment: %v", ocmEnv.Name())
@@ -436,4 +436,41 @@ func TestNoAutomaticConfigFetch(t *testing.T) {
 			t.Errorf("expected default ProdEnvName 'production', got %s", config.ProdEnvName)
 		}
 	})
+
+	t.Run("it adds the configured JIRA login projects to the default ones", func(t *testing.T) {
+		viper.Reset()
+
+		tmpDir := t.TempDir()
+		configPath := tmpDir + "/config.json"
+		configContent := `{
+			"jira-login-projects": {
+				"SREP": {"cluster-field": "customfield_12345", "cluster-pattern": "cluster: (\\S+)"}
+			}
+		}`
+		err := os.WriteFile(configPath, []byte(configContent), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Setenv("BACKPLANE_CONFIG", configPath)
+		svr := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("dummy data"))
+		}))
+		defer svr.Close()
+		t.Setenv("BACKPLANE_URL", svr.URL)
+		t.Setenv("HTTPS_PROXY", "example-proxy")
+
+		config, err := GetBackplaneConfiguration()
+		if err != nil {
+			t.Error(err)
+		}
+
+		if config.JiraLoginProjects["OHSS"] != JiraLoginProjectsDefaultValue["OHSS"] {
+			t.Errorf("expected the default OHSS JIRA login project, got %v", config.JiraLoginProjects["OHSS"])
+		}
+		expected := JiraLoginProjectConfiguration{ClusterField: "customfield_12345", ClusterPattern: `cluster: (\S+)`}
+		if config.JiraLoginProjects["SREP"] != expected {
+			t.Errorf("expected the configured SREP JIRA login project %v, got %v", expected, config.JiraLoginProjects["SREP"])
+		}
+	})
 }
@@ -0,0 +1,233 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	logger "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+
+	"github.com/openshift/backplane-cli/pkg/info"
+)
+
+const (
+	// ProfilesKey holds the named profiles, each overriding the top level settings of the config file
+	ProfilesKey = "profiles"
+	// CurrentProfileKey is the profile used when neither --profile nor BACKPLANE_PROFILE is set
+	CurrentProfileKey = "current-profile"
+	// OCMEnvKey is the name of the OCM environment a profile is for, e.g. production, staging or integration
+	OCMEnvKey = "ocm-env"
+)
+
+// profileOverride is the profile given by the --profile flag
+var profileOverride string
+
+// SetProfile overrides the profile used, it is set by the --profile flag
+func SetProfile(name string) {
+	profileOverride = name
+}
+
+// ProfileFlag returns the profile given by the --profile flag, empty when not given
+func ProfileFlag() string {
+	return profileOverride
+}
+
+// ConfigFile is the content of the config file, along with its profiles
+type ConfigFile struct {
+	Path string
+	// Settings are the top level settings, including the profiles
+	Settings map[string]interface{}
+}
+
+// ReadConfigFile reads the config file, an absent file has no settings
+func ReadConfigFile() (*ConfigFile, error) {
+	path, err := GetConfigFilePath()
+	if err != nil {
+		return nil, err
+	}
+	configFile := &ConfigFile{Path: path, Settings: map[string]interface{}{}}
+
+	content, err := os.ReadFile(path) //#nosec G304
+	if os.IsNotExist(err) {
+		return configFile, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, &configFile.Settings); err != nil {
+		return nil, fmt.Errorf("failed to parse the config file %s: %v", path, err)
+	}
+	return configFile, nil
+}
+
+// Write writes the config file back, keeping the case of the profile names
+func (f *ConfigFile) Write() error {
+	content, err := json.MarshalIndent(f.Settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(f.Path, append(content, '\n'), 0600)
+}
+
+// Profiles returns the settings of the profiles by their name
+func (f *ConfigFile) Profiles() map[string]map[string]interface{} {
+	profiles := map[string]map[string]interface{}{}
+	configured, _ := f.Settings[ProfilesKey].(map[string]interface{})
+	for name, settings := range configured {
+		if profile, ok := settings.(map[string]interface{}); ok {
+			profiles[name] = profile
+		}
+	}
+	return profiles
+}
+
+// ProfileNames returns the sorted names of the profiles
+func (f *ConfigFile) ProfileNames() []string {
+	names := []string{}
+	for name := range f.Profiles() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Profile returns the name and the settings of a profile, whose name is case insensitive
+// as 'config set' lower cases the keys of the config file
+func (f *ConfigFile) Profile(name string) (string, map[string]interface{}, error) {
+	for profileName, profile := range f.Profiles() {
+		if strings.EqualFold(profileName, name) {
+			return profileName, profile, nil
+		}
+	}
+	names := f.ProfileNames()
+	if len(names) == 0 {
+		return "", nil, fmt.Errorf("profile %s not found, there is no '%s' in the config file %s", name, ProfilesKey, f.Path)
+	}
+	return "", nil, fmt.Errorf("profile %s not found in the config file %s, expected one of %s", name, f.Path, strings.Join(names, ", "))
+}
+
+// CurrentProfile returns the name of the profile in use: the one given by --profile,
+// else by BACKPLANE_PROFILE, else the current profile of the config file. It is empty when no profile is used.
+func (f *ConfigFile) CurrentProfile() string {
+	if profileOverride != "" {
+		return profileOverride
+	}
+	if name, ok := os.LookupEnv(info.BackplaneProfileEnvName); ok && name != "" {
+		return name
+	}
+	name, _ := f.Settings[CurrentProfileKey].(string)
+	return name
+}
+
+// EffectiveSettings returns the top level settings overridden by the ones of the profile
+func (f *ConfigFile) EffectiveSettings(name string) (map[string]interface{}, error) {
+	_, profile, err := f.Profile(name)
+	if err != nil {
+		return nil, err
+	}
+	settings := map[string]interface{}{}
+	for key, value := range f.Settings {
+		if key != ProfilesKey && key != CurrentProfileKey {
+			settings[key] = value
+		}
+	}
+	for key, value := range profile {
+		settings[key] = value
+	}
+	return settings, nil
+}
+
+// UseProfile makes a profile the current one of the config file
+func UseProfile(name string) (string, error) {
+	configFile, err := ReadConfigFile()
+	if err != nil {
+		return "", err
+	}
+	profileName, _, err := configFile.Profile(name)
+	if err != nil {
+		return "", err
+	}
+	configFile.Settings[CurrentProfileKey] = profileName
+	return profileName, configFile.Write()
+}
+
+// applyProfile merges the settings of the profile in use over the settings read by viper from the config file,
+// the environment variables still take precedence. It returns the name of the profile, empty when none is used.
+func applyProfile() (string, error) {
+	configFile, err := ReadConfigFile()
+	if err != nil {
+		return "", err
+	}
+	name := configFile.CurrentProfile()
+	if name == "" {
+		return "", nil
+	}
+	profileName, profile, err := configFile.Profile(name)
+	if err != nil {
+		return "", err
+	}
+	logger.Debugf("Using the profile %s of the config file %s", profileName, configFile.Path)
+	if err := viper.MergeConfigMap(profile); err != nil {
+		return "", fmt.Errorf("failed to apply the profile %s: %v", profileName, err)
+	}
+	return profileName, nil
+}
+
+// checkOCMEnvironment makes sure the OCM environment is the one of the profile in use,
+// to not use the settings of a profile against another environment
+func (config *BackplaneConfiguration) checkOCMEnvironment(ocmEnv *cmv1.Environment) error {
+	if config.OCMEnvironment == "" || strings.EqualFold(config.OCMEnvironment, ocmEnv.Name()) {
+		return nil
+	}
+	profile := config.Profile
+	if profile == "" {
+		profile = "default"
+	}
+	return fmt.Errorf("the %s profile is for the OCM environment %s but OCM is logged into %s, log into %s with 'ocm login' or use another profile with --profile",
+		profile, config.OCMEnvironment, ocmEnv.Name(), config.OCMEnvironment)
+}
+
+// Get returns the value of a key of the top level settings or of a profile
+func (f *ConfigFile) Get(profile string, key string) (interface{}, bool) {
+	value, ok := f.settingsOf(profile, false)[key]
+	return value, ok
+}
+
+// Set sets a key of the top level settings, or of a profile which is created when it does not exist
+func (f *ConfigFile) Set(profile string, key string, value interface{}) {
+	f.settingsOf(profile, true)[key] = value
+}
+
+// Unset removes a key of the top level settings or of a profile, it returns false when the key is not set
+func (f *ConfigFile) Unset(profile string, key string) bool {
+	settings := f.settingsOf(profile, false)
+	if _, ok := settings[key]; !ok {
+		return false
+	}
+	delete(settings, key)
+	return true
+}
+
+// settingsOf returns the top level settings, or the ones of a profile
+func (f *ConfigFile) settingsOf(profile string, create bool) map[string]interface{} {
+	if profile == "" {
+		return f.Settings
+	}
+	if _, settings, err := f.Profile(profile); err == nil {
+		return settings
+	}
+	settings := map[string]interface{}{}
+	if create {
+		profiles, ok := f.Settings[ProfilesKey].(map[string]interface{})
+		if !ok {
+			profiles = map[string]interface{}{}
+			f.Settings[ProfilesKey] = profiles
+		}
+		profiles[profile] = settings
+	}
+	return settings
+}
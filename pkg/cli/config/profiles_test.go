@@ -0,0 +1,146 @@
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/viper"
+)
+
+const profilesConfigContent = `{
+	"proxy-url": "http://proxy.example.com:3128",
+	"pd-key": "top-level-key",
+	"session-dir": "sessions",
+	"current-profile": "Stage",
+	"profiles": {
+		"Stage": {"ocm-env": "staging", "pd-key": "stage-key"},
+		"production": {"ocm-env": "production", "pd-key": "production-key", "session-dir": "prod-sessions"}
+	}
+}`
+
+// setupProfilesConfig writes the config file with profiles and points the configuration to it
+func setupProfilesConfig(t *testing.T) string {
+	viper.Reset()
+	SetProfile("")
+	t.Cleanup(func() { SetProfile("") })
+
+	configPath := t.TempDir() + "/config.json"
+	if err := os.WriteFile(configPath, []byte(profilesConfigContent), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("BACKPLANE_CONFIG", configPath)
+
+	svr := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("dummy data"))
+	}))
+	t.Cleanup(svr.Close)
+	t.Setenv("BACKPLANE_URL", svr.URL)
+	return configPath
+}
+
+func TestProfiles(t *testing.T) {
+	t.Run("the current profile overrides the top level settings", func(t *testing.T) {
+		setupProfilesConfig(t)
+
+		config, err := GetBackplaneConfiguration()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if config.Profile != "Stage" || config.OCMEnvironment != "staging" {
+			t.Errorf("expected the Stage profile for staging, got %s for %s", config.Profile, config.OCMEnvironment)
+		}
+		if config.PagerDutyAPIKey != "stage-key" {
+			t.Errorf("expected the PagerDuty key of the profile, got %s", config.PagerDutyAPIKey)
+		}
+		if config.SessionDirectory != "sessions" {
+			t.Errorf("expected the top level session directory, got %s", config.SessionDirectory)
+		}
+	})
+
+	t.Run("the --profile flag takes precedence over BACKPLANE_PROFILE and the current profile", func(t *testing.T) {
+		setupProfilesConfig(t)
+		t.Setenv("BACKPLANE_PROFILE", "stage")
+		SetProfile("production")
+
+		config, err := GetBackplaneConfiguration()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if config.Profile != "production" || config.PagerDutyAPIKey != "production-key" || config.SessionDirectory != "prod-sessions" {
+			t.Errorf("expected the settings of the production profile, got %+v", config)
+		}
+	})
+
+	t.Run("the environment variables take precedence over the profile", func(t *testing.T) {
+		setupProfilesConfig(t)
+		t.Setenv("HTTPS_PROXY", "http://env-proxy.example.com:3128")
+
+		config, err := GetBackplaneConfiguration()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if config.ProxyURL == nil || *config.ProxyURL != "http://env-proxy.example.com:3128" {
+			t.Errorf("expected the proxy of the environment, got %v", config.ProxyURL)
+		}
+	})
+
+	t.Run("an unknown profile fails", func(t *testing.T) {
+		setupProfilesConfig(t)
+		SetProfile("integration")
+
+		_, err := GetBackplaneConfiguration()
+		if err == nil || !strings.Contains(err.Error(), "profile integration not found") || !strings.Contains(err.Error(), "expected one of Stage, production") {
+			t.Errorf("expected the unknown profile error, got %v", err)
+		}
+	})
+
+	t.Run("use profile makes a profile current, keeping the case of its name", func(t *testing.T) {
+		setupProfilesConfig(t)
+
+		name, err := UseProfile("PRODUCTION")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name != "production" {
+			t.Errorf("expected the production profile, got %s", name)
+		}
+
+		configFile, err := ReadConfigFile()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if configFile.CurrentProfile() != "production" || len(configFile.ProfileNames()) != 2 {
+			t.Errorf("expected the production profile to be current, got %v", configFile.Settings)
+		}
+	})
+
+	t.Run("the OCM environment must be the one of the profile", func(t *testing.T) {
+		staging, err := cmv1.NewEnvironment().Name("staging").Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		production, err := cmv1.NewEnvironment().Name("production").Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		config := BackplaneConfiguration{Profile: "Stage", OCMEnvironment: "staging"}
+
+		if err := config.checkOCMEnvironment(staging); err != nil {
+			t.Errorf("expected the staging environment to match, got %v", err)
+		}
+		err = config.checkOCMEnvironment(production)
+		if err == nil || err.Error() != "the Stage profile is for the OCM environment staging but OCM is logged into production, log into staging with 'ocm login' or use another profile with --profile" {
+			t.Errorf("expected the environment mismatch error, got %v", err)
+		}
+		if err := (&BackplaneConfiguration{}).checkOCMEnvironment(production); err != nil {
+			t.Errorf("expected no check without OCM environment, got %v", err)
+		}
+	})
+}
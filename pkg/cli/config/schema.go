@@ -0,0 +1,297 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/openshift/backplane-cli/pkg/info"
+)
+
+// The kinds of the values of the config keys, given by the config tag of the BackplaneConfiguration fields
+const (
+	KindString = "string"
+	KindBool   = "bool"
+	KindInt    = "int"
+	KindURL    = "url"
+	// KindURLs is a URL or a list of URLs
+	KindURLs   = "urls"
+	KindObject = "object"
+)
+
+// Key describes a key of the config file
+type Key struct {
+	Name        string
+	Kind        string
+	Description string
+	// Secret values are not displayed
+	Secret bool
+	// Deprecated keys are still read, but should be removed from the config file
+	Deprecated bool
+}
+
+// Severities of the issues found in the config file
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// ValidationIssue is an issue found in the config file
+type ValidationIssue struct {
+	Severity string
+	// Profile is the profile the issue is found in, empty for the top level settings
+	Profile string
+	Key     string
+	Message string
+}
+
+func (i ValidationIssue) String() string {
+	location := i.Key
+	if i.Profile != "" {
+		location = strings.TrimSuffix(fmt.Sprintf("%s.%s.%s", ProfilesKey, i.Profile, i.Key), ".")
+	}
+	if location == "" {
+		return i.Message
+	}
+	return fmt.Sprintf("%s: %s", location, i.Message)
+}
+
+// Schema returns the keys of the config file, derived from the tags of the BackplaneConfiguration fields
+func Schema() []Key {
+	keys := []Key{}
+	configType := reflect.TypeOf(BackplaneConfiguration{})
+	for i := 0; i < configType.NumField(); i++ {
+		field := configType.Field(i)
+		tag := field.Tag.Get("config")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		options := strings.Split(tag, ",")
+		key := Key{Name: name, Kind: options[0], Description: field.Tag.Get("desc")}
+		for _, option := range options[1:] {
+			switch option {
+			case "secret":
+				key.Secret = true
+			case "deprecated":
+				key.Deprecated = true
+			}
+		}
+		keys = append(keys, key)
+	}
+
+	// The profiles are not part of the configuration in use
+	keys = append(keys,
+		Key{Name: CurrentProfileKey, Kind: KindString, Description: "Profile used when neither --profile nor " + info.BackplaneProfileEnvName + " is set"},
+		Key{Name: ProfilesKey, Kind: KindObject, Description: "Profiles overriding the top level settings, by their name"},
+	)
+	return keys
+}
+
+// LookupKey returns the key of the config file with the given name
+func LookupKey(name string) (Key, bool) {
+	for _, key := range Schema() {
+		if key.Name == name {
+			return key, true
+		}
+	}
+	return Key{}, false
+}
+
+// KeyNames returns the names of the keys of the config file
+func KeyNames() []string {
+	names := []string{}
+	for _, key := range Schema() {
+		names = append(names, key.Name)
+	}
+	return names
+}
+
+// Parse converts a value given on the command line to the value of the key in the config file
+func (k Key) Parse(value string) (interface{}, error) {
+	var parsed interface{}
+	switch k.Kind {
+	case KindBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %v", k.Name, err)
+		}
+		parsed = b
+	case KindInt:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %v", k.Name, err)
+		}
+		parsed = n
+	case KindURLs:
+		// a single URL is kept as a string, a comma separated list as a list
+		urls := []interface{}{}
+		for _, u := range strings.Split(value, ",") {
+			urls = append(urls, strings.TrimSpace(u))
+		}
+		parsed = urls
+		if len(urls) == 1 {
+			parsed = urls[0]
+		}
+	case KindObject:
+		var object map[string]interface{}
+		if err := json.Unmarshal([]byte(value), &object); err != nil {
+			return nil, fmt.Errorf("invalid value for %s, expected a JSON object: %v", k.Name, err)
+		}
+		parsed = object
+	default:
+		parsed = value
+	}
+
+	if err := k.Check(parsed); err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
+// Check makes sure a value of the config file is of the kind of the key
+func (k Key) Check(value interface{}) error {
+	switch k.Kind {
+	case KindBool:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("invalid value for %s: expected true or false, got %v", k.Name, value)
+		}
+	case KindInt:
+		if !isInt(value) {
+			return fmt.Errorf("invalid value for %s: expected an integer, got %v", k.Name, value)
+		}
+	case KindURL:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("invalid value for %s: expected a URL, got %v", k.Name, value)
+		}
+		return k.checkURL(s)
+	case KindURLs:
+		switch v := value.(type) {
+		case string:
+			return k.checkURL(v)
+		case []interface{}:
+			for _, item := range v {
+				s, ok := item.(string)
+				if !ok {
+					return fmt.Errorf("invalid value for %s: expected a list of URLs, got %v", k.Name, value)
+				}
+				if err := k.checkURL(s); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("invalid value for %s: expected a URL or a list of URLs, got %v", k.Name, value)
+		}
+	case KindObject:
+		if _, ok := value.(map[string]interface{}); !ok {
+			return fmt.Errorf("invalid value for %s: expected an object, got %v", k.Name, value)
+		}
+	default:
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("invalid value for %s: expected a string, got %v", k.Name, value)
+		}
+	}
+	return nil
+}
+
+func (k Key) checkURL(value string) error {
+	u, err := url.ParseRequestURI(value)
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %v", k.Name, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid value for %s: %s is not an absolute URL", k.Name, value)
+	}
+	return nil
+}
+
+// isInt tells if a value is an integer, JSON numbers being decoded as floats
+func isInt(value interface{}) bool {
+	switch v := value.(type) {
+	case int:
+		return true
+	case float64:
+		return v == math.Trunc(v)
+	}
+	return false
+}
+
+// Validate returns the issues of the config file: the unknown keys, the deprecated ones,
+// the values not of the kind of their key and the unknown current profile.
+// The required keys of the configuration in use are checked too.
+func (f *ConfigFile) Validate() []ValidationIssue {
+	issues := validateSettings("", f.Settings)
+
+	for _, name := range f.ProfileNames() {
+		settings := map[string]interface{}{}
+		for key, value := range f.Profiles()[name] {
+			if key == ProfilesKey || key == CurrentProfileKey {
+				issues = append(issues, ValidationIssue{Severity: SeverityError, Profile: name, Key: key, Message: "cannot be set in a profile"})
+				continue
+			}
+			settings[key] = value
+		}
+		issues = append(issues, validateSettings(name, settings)...)
+	}
+
+	current := f.CurrentProfile()
+	if current != "" {
+		if _, _, err := f.Profile(current); err != nil {
+			issues = append(issues, ValidationIssue{Severity: SeverityError, Key: CurrentProfileKey, Message: err.Error()})
+			return issues
+		}
+	}
+
+	// the settings of the profile in use must hold the required keys
+	settings := f.Settings
+	if current != "" {
+		settings, _ = f.EffectiveSettings(current)
+	}
+	if err := validateRequiredSettings(settings); err != nil {
+		issues = append(issues, ValidationIssue{Severity: SeverityError, Profile: current, Message: err.Error()})
+	}
+	return issues
+}
+
+// validateSettings validates the keys and the values of the top level settings or of a profile
+func validateSettings(profile string, settings map[string]interface{}) []ValidationIssue {
+	issues := []ValidationIssue{}
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		key, ok := LookupKey(name)
+		if !ok {
+			issues = append(issues, ValidationIssue{Severity: SeverityWarning, Profile: profile, Key: name,
+				Message: fmt.Sprintf("unknown key, expected one of %s", strings.Join(KeyNames(), ", "))})
+			continue
+		}
+		if key.Deprecated {
+			issues = append(issues, ValidationIssue{Severity: SeverityWarning, Profile: profile, Key: name, Message: "deprecated key, please remove it"})
+		}
+		if err := key.Check(settings[name]); err != nil {
+			issues = append(issues, ValidationIssue{Severity: SeverityError, Profile: profile, Key: name, Message: strings.TrimPrefix(err.Error(), "invalid value for "+name+": ")})
+		}
+	}
+	return issues
+}
+
+// validateRequiredSettings checks the required keys of the settings in use, as validateConfig does
+func validateRequiredSettings(settings map[string]interface{}) error {
+	v := viper.New()
+	if err := v.MergeConfigMap(settings); err != nil {
+		return err
+	}
+	return validateViperConfig(v)
+}
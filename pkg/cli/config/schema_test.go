@@ -0,0 +1,101 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSchema(t *testing.T) {
+	t.Run("it derives the keys from the BackplaneConfiguration tags", func(t *testing.T) {
+		expected := map[string]Key{
+			"proxy-url":                {Name: "proxy-url", Kind: KindURLs, Description: "Squid proxy URLs, the first working one is used"},
+			"url":                      {Name: "url", Kind: KindURL, Description: "Backplane API URL, retrieved from the OCM environment", Deprecated: true},
+			"pd-key":                   {Name: "pd-key", Kind: KindString, Description: "PagerDuty API User Key", Secret: true},
+			"session-duration-minutes": {Name: "session-duration-minutes", Kind: KindInt, Description: "Duration of the cloud console sessions in minutes, 15 by default and 60 at most"},
+		}
+		for name, want := range expected {
+			key, ok := LookupKey(name)
+			if !ok || !reflect.DeepEqual(key, want) {
+				t.Errorf("expected key %+v, got %+v", want, key)
+			}
+		}
+		if _, ok := LookupKey("profile"); ok {
+			t.Errorf("expected the profile in use to not be a key of the config file")
+		}
+		if _, ok := LookupKey(ProfilesKey); !ok {
+			t.Errorf("expected the profiles to be a key of the config file")
+		}
+	})
+
+	t.Run("it parses and checks the values given on the command line", func(t *testing.T) {
+		tests := []struct {
+			key      string
+			value    string
+			expected interface{}
+			err      string
+		}{
+			{"display-cluster-info", "true", true, ""},
+			{"display-cluster-info", "yes", nil, "invalid value for display-cluster-info"},
+			{"session-duration-minutes", "30", 30, ""},
+			{"session-duration-minutes", "half an hour", nil, "invalid value for session-duration-minutes"},
+			{"vpn-check-endpoint", "https://vpn.example.com/check", "https://vpn.example.com/check", ""},
+			{"vpn-check-endpoint", "vpn.example.com", nil, "invalid value for vpn-check-endpoint"},
+			{"proxy-url", "http://proxy.example.com:3128", "http://proxy.example.com:3128", ""},
+			{"proxy-url", "http://proxy1:3128, http://proxy2:3128", []interface{}{"http://proxy1:3128", "http://proxy2:3128"}, ""},
+			{"aws-proxy", "http://proxy1:3128,proxy2", nil, "invalid value for aws-proxy"},
+			{"jira-login-projects", `{"SREP": {"cluster-field": "customfield_1"}}`, map[string]interface{}{"SREP": map[string]interface{}{"cluster-field": "customfield_1"}}, ""},
+			{"jira-login-projects", `["SREP"]`, nil, "expected a JSON object"},
+			{"jira-email", "jdoe@example.com", "jdoe@example.com", ""},
+		}
+		for _, tt := range tests {
+			key, _ := LookupKey(tt.key)
+			value, err := key.Parse(tt.value)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("%s=%s: expected error %q, got %v", tt.key, tt.value, tt.err, err)
+				}
+				continue
+			}
+			if err != nil || !reflect.DeepEqual(value, tt.expected) {
+				t.Errorf("%s=%s: expected %v, got %v (%v)", tt.key, tt.value, tt.expected, value, err)
+			}
+		}
+	})
+
+	t.Run("it reports the issues of the config file and its profiles", func(t *testing.T) {
+		// t.Setenv restores HTTPS_PROXY once the test unset it
+		t.Setenv("HTTPS_PROXY", "")
+		_ = os.Unsetenv("HTTPS_PROXY")
+		SetProfile("")
+		t.Setenv("BACKPLANE_PROFILE", "")
+
+		configFile := &ConfigFile{Path: "config.json", Settings: map[string]interface{}{
+			"url":                  "https://backplane.example.com",
+			"display-cluster-info": "yes",
+			"pd-keys":              "key",
+			"current-profile":      "stage",
+			"profiles": map[string]interface{}{
+				"stage": map[string]interface{}{"session-duration-minutes": 30.5, "current-profile": "production"},
+			},
+		}}
+
+		issues := []string{}
+		for _, issue := range configFile.Validate() {
+			issues = append(issues, issue.Severity+" "+issue.String())
+		}
+
+		expected := []string{
+			"error display-cluster-info: expected true or false, got yes",
+			"warning pd-keys: unknown key, expected one of " + strings.Join(KeyNames(), ", "),
+			"warning url: deprecated key, please remove it",
+			"error profiles.stage.current-profile: cannot be set in a profile",
+			"error profiles.stage.session-duration-minutes: expected an integer, got 30.5",
+			"error profiles.stage: proxy-url must be set explicitly in either config file or via the environment HTTPS_PROXY",
+		}
+		if !reflect.DeepEqual(issues, expected) {
+			t.Errorf("expected issues\n%s\ngot\n%s", strings.Join(expected, "\n"), strings.Join(issues, "\n"))
+		}
+	})
+}
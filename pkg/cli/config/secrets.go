@@ -0,0 +1,181 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	logger "github.com/sirupsen/logrus"
+	"golang.org/x/term"
+
+	"github.com/openshift/backplane-cli/pkg/info"
+	"github.com/openshift/backplane-cli/pkg/secrets"
+)
+
+const (
+	// SecretRefPrefix prefixes the values of the secret keys kept in a secret store,
+	// e.g. secret-store:keyring/pd-key for the pd-key kept in the keyring
+	SecretRefPrefix = "secret-store:"
+	// StorePlain keeps the secrets in plain text in the config file
+	StorePlain = "plain"
+	// MaskedValue replaces the values of the secret keys when displayed
+	MaskedValue = "********"
+)
+
+// NewSecretStore returns a secret store by its name, overridden in tests
+var NewSecretStore = func(name string) (secrets.Store, error) {
+	switch name {
+	case secrets.StoreKeyring:
+		return secrets.NewKeyringStore(), nil
+	case secrets.StoreFile:
+		dir, err := GetConfigDirectory()
+		if err != nil {
+			return nil, err
+		}
+		return secrets.NewFileStore(dir, secretsPassphrase), nil
+	}
+	return nil, fmt.Errorf("unknown secret store %s, expected one of %s|%s|%s", name, secrets.StoreKeyring, secrets.StoreFile, StorePlain)
+}
+
+var (
+	// promptedPassphrase is the passphrase of the secrets file entered in the terminal, prompted once per command
+	promptedPassphrase string
+	passphraseMutex    sync.Mutex
+)
+
+// secretsPassphrase returns the passphrase of the secrets file given by BACKPLANE_SECRETS_PASSPHRASE,
+// else prompted in the terminal
+func secretsPassphrase() (string, error) {
+	if passphrase := os.Getenv(info.BackplaneSecretsPassphraseEnvName); passphrase != "" {
+		return passphrase, nil
+	}
+
+	passphraseMutex.Lock()
+	defer passphraseMutex.Unlock()
+	if promptedPassphrase != "" {
+		return promptedPassphrase, nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("set %s, or run in a terminal to enter it", info.BackplaneSecretsPassphraseEnvName)
+	}
+	fmt.Fprintf(os.Stderr, "Passphrase of the backplane secrets file %s: ", secrets.FileName)
+	passphrase, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	promptedPassphrase = string(passphrase)
+	return promptedPassphrase, nil
+}
+
+// DefaultSecretStoreName returns the store the secrets are kept in: the one given by BACKPLANE_SECRET_STORE,
+// else the keyring when available, else the encrypted file
+func DefaultSecretStoreName() string {
+	if name := os.Getenv(info.BackplaneSecretStoreEnvName); name != "" {
+		return name
+	}
+	if secrets.KeyringAvailable() {
+		return secrets.StoreKeyring
+	}
+	logger.Debugf("No keyring available, the secrets are kept in the encrypted file %s", secrets.FileName)
+	return secrets.StoreFile
+}
+
+// IsSecretRef tells if a value of the config file is a reference to a secret kept in a secret store
+func IsSecretRef(value string) bool {
+	return strings.HasPrefix(value, SecretRefPrefix)
+}
+
+// parseSecretRef returns the store and the account of a secret reference
+func parseSecretRef(ref string) (secrets.Store, string, error) {
+	storeName, account, ok := strings.Cut(strings.TrimPrefix(ref, SecretRefPrefix), "/")
+	if !ok || account == "" {
+		return nil, "", fmt.Errorf("invalid secret reference %s, expected %s<store>/<account>", ref, SecretRefPrefix)
+	}
+	store, err := NewSecretStore(storeName)
+	if err != nil {
+		return nil, "", err
+	}
+	return store, account, nil
+}
+
+// ResolveSecret returns the value of a secret key, read from its secret store when the config file holds a reference
+func ResolveSecret(value string) (string, error) {
+	if !IsSecretRef(value) {
+		return value, nil
+	}
+	store, account, err := parseSecretRef(value)
+	if err != nil {
+		return "", err
+	}
+	secret, err := store.Get(account)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s from the %s secret store: %v", account, store.Name(), err)
+	}
+	return secret, nil
+}
+
+// StoreSecret keeps the value of a secret key of the top level settings or of a profile in a secret store,
+// it returns the reference to write in the config file instead of the value
+func StoreSecret(storeName string, profile string, key string, value string) (string, error) {
+	if storeName == StorePlain {
+		return value, nil
+	}
+	store, err := NewSecretStore(storeName)
+	if err != nil {
+		return "", err
+	}
+	account := key
+	if profile != "" {
+		account = profile + "/" + key
+	}
+	if err := store.Set(account, value); err != nil {
+		return "", fmt.Errorf("failed to write %s to the %s secret store: %v", account, store.Name(), err)
+	}
+	return SecretRefPrefix + store.Name() + "/" + account, nil
+}
+
+// DeleteSecret removes the secret of a reference from its secret store
+func DeleteSecret(ref string) error {
+	store, account, err := parseSecretRef(ref)
+	if err != nil {
+		return err
+	}
+	if err := store.Delete(account); err != nil && err != secrets.ErrNotFound {
+		return fmt.Errorf("failed to delete %s from the %s secret store: %v", account, store.Name(), err)
+	}
+	return nil
+}
+
+// MaskSecret returns the value to display for a secret key, empty when not set
+func MaskSecret(value string) string {
+	if value == "" {
+		return ""
+	}
+	return MaskedValue
+}
+
+// MaskSecrets returns the settings with the values of their secret keys masked
+func MaskSecrets(settings map[string]interface{}) map[string]interface{} {
+	masked := map[string]interface{}{}
+	for name, value := range settings {
+		if key, ok := LookupKey(name); ok && key.Secret {
+			if s, isString := value.(string); isString {
+				value = MaskSecret(s)
+			}
+		}
+		masked[name] = value
+	}
+	return masked
+}
+
+// GetPagerDutyAPIKey returns the PagerDuty API key, read from its secret store if needed
+func (config *BackplaneConfiguration) GetPagerDutyAPIKey() (string, error) {
+	return ResolveSecret(config.PagerDutyAPIKey)
+}
+
+// GetJiraToken returns the JIRA token, read from its secret store if needed
+func (config *BackplaneConfiguration) GetJiraToken() (string, error) {
+	return ResolveSecret(config.JiraToken)
+}
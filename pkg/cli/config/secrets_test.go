@@ -0,0 +1,82 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+
+	"github.com/openshift/backplane-cli/pkg/secrets"
+)
+
+func TestSecrets(t *testing.T) {
+	t.Setenv("BACKPLANE_SECRETS_PASSPHRASE", "my-passphrase")
+	workFactor := secrets.ScryptWorkFactor
+	secrets.ScryptWorkFactor = 10
+	t.Cleanup(func() { secrets.ScryptWorkFactor = workFactor })
+
+	t.Run("it keeps the secrets in the store and resolves their reference", func(t *testing.T) {
+		t.Setenv("BACKPLANE_CONFIG", t.TempDir()+"/config.json")
+
+		ref, err := StoreSecret("file", "stage", "pd-key", "my-pd-key")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ref != "secret-store:file/stage/pd-key" {
+			t.Errorf("expected the reference to the secret, got %s", ref)
+		}
+
+		config := BackplaneConfiguration{PagerDutyAPIKey: ref, JiraToken: "plain-token"}
+		if apiKey, err := config.GetPagerDutyAPIKey(); err != nil || apiKey != "my-pd-key" {
+			t.Errorf("expected the PagerDuty key of the store, got %s (%v)", apiKey, err)
+		}
+		if token, err := config.GetJiraToken(); err != nil || token != "plain-token" {
+			t.Errorf("expected the plain text JIRA token, got %s (%v)", token, err)
+		}
+
+		if err := DeleteSecret(ref); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ResolveSecret(ref); err == nil || !strings.Contains(err.Error(), "failed to read stage/pd-key from the file secret store") {
+			t.Errorf("expected the deleted secret to not be found, got %v", err)
+		}
+	})
+
+	t.Run("it keeps the secrets in plain text with the plain store", func(t *testing.T) {
+		ref, err := StoreSecret(StorePlain, "", "jira-token", "my-token")
+		if err != nil || ref != "my-token" {
+			t.Errorf("expected the plain text token, got %s (%v)", ref, err)
+		}
+	})
+
+	t.Run("it uses the keyring when available unless BACKPLANE_SECRET_STORE is set", func(t *testing.T) {
+		keyring.MockInit()
+		// t.Setenv restores BACKPLANE_SECRET_STORE once the test unset it
+		t.Setenv("BACKPLANE_SECRET_STORE", "")
+		_ = os.Unsetenv("BACKPLANE_SECRET_STORE")
+		if name := DefaultSecretStoreName(); name != "keyring" {
+			t.Errorf("expected the keyring store, got %s", name)
+		}
+		t.Setenv("BACKPLANE_SECRET_STORE", "file")
+		if name := DefaultSecretStoreName(); name != "file" {
+			t.Errorf("expected the file store, got %s", name)
+		}
+	})
+
+	t.Run("it rejects invalid references and stores", func(t *testing.T) {
+		if _, err := ResolveSecret("secret-store:keyring"); err == nil || !strings.Contains(err.Error(), "invalid secret reference") {
+			t.Errorf("expected an invalid reference error, got %v", err)
+		}
+		if _, err := ResolveSecret("secret-store:vault/pd-key"); err == nil || !strings.Contains(err.Error(), "unknown secret store vault") {
+			t.Errorf("expected an unknown store error, got %v", err)
+		}
+	})
+
+	t.Run("it masks the secrets", func(t *testing.T) {
+		masked := MaskSecrets(map[string]interface{}{"pd-key": "my-pd-key", "jira-token": "", "jira-email": "jdoe@example.com"})
+		if masked["pd-key"] != MaskedValue || masked["jira-token"] != "" || masked["jira-email"] != "jdoe@example.com" {
+			t.Errorf("expected the secrets to be masked, got %v", masked)
+		}
+	})
+}
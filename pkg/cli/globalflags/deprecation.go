@@ -0,0 +1,45 @@
+package globalflags
+
+import (
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/backplane-cli/pkg/backplaneapi"
+	"github.com/openshift/backplane-cli/pkg/info"
+)
+
+type strictDeprecationFlag bool
+
+// String returns whether the deprecations are strict
+func (s *strictDeprecationFlag) String() string {
+	return strconv.FormatBool(bool(*s))
+}
+
+// Set makes the requests to deprecated backplane API endpoints fail
+func (s *strictDeprecationFlag) Set(value string) error {
+	strict, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+	*s = strictDeprecationFlag(strict)
+	backplaneapi.SetStrictDeprecation(strict)
+	return nil
+}
+
+// Type defines strict deprecation type
+func (s *strictDeprecationFlag) Type() string {
+	return "bool"
+}
+
+// AddStrictDeprecationFlag add Persistent strict deprecation flag
+func AddStrictDeprecationFlag(cmd *cobra.Command) {
+	var strict strictDeprecationFlag
+	strictFlag := cmd.PersistentFlags().VarPF(
+		&strict,
+		"strict-deprecation",
+		"",
+		"Fail on the responses of deprecated backplane API endpoints, e.g. in CI, overrides the "+info.BackplaneStrictDeprecationEnvName+" environment variable",
+	)
+	strictFlag.NoOptDefVal = "true"
+}
@@ -0,0 +1,37 @@
+package globalflags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/backplane-cli/pkg/cli/config"
+	"github.com/openshift/backplane-cli/pkg/info"
+)
+
+type profileFlag string
+
+// String returns the profile name
+func (p *profileFlag) String() string {
+	return string(*p)
+}
+
+// Set updates the profile the configuration is read from
+func (p *profileFlag) Set(value string) error {
+	*p = profileFlag(value)
+	config.SetProfile(value)
+	return nil
+}
+
+// Type defines profile type
+func (p *profileFlag) Type() string {
+	return "string"
+}
+
+// AddProfileFlag add Persistent profile flag
+func AddProfileFlag(cmd *cobra.Command) {
+	var profile profileFlag
+	cmd.PersistentFlags().Var(
+		&profile,
+		"profile",
+		"Profile of the config file to use, overrides the "+info.BackplaneProfileEnvName+" environment variable and the current profile of the config file",
+	)
+}
@@ -0,0 +1,172 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/openshift/backplane-cli/cmd/ocm-backplane/login"
+	"github.com/openshift/backplane-cli/pkg/ocm"
+)
+
+const (
+	// The kubeconfig of the session pointing to the kubeconfig of the active cluster
+	// when the session is logged into several clusters
+	activeKubeConfigName = "kubeconfig"
+)
+
+// relatedCluster is a cluster the session is logged into along with the session cluster
+type relatedCluster struct {
+	// The prefix of the env variables and the suffix of the helper switching to the cluster
+	Kind        string
+	Description string
+	ID          string
+	Name        string
+}
+
+// initRelatedClusters finds the management and service clusters of the session cluster,
+// and the namespaces of the session cluster on its management cluster
+func (e *BackplaneSession) initRelatedClusters() error {
+	mcID, mcName, isHostedControlPlane, err := ocm.DefaultOCMInterface.GetManagingCluster(e.Options.ClusterID)
+	if err != nil {
+		return err
+	}
+	e.Options.ManagementClusterID = mcID
+	e.Options.ManagementClusterName = mcName
+	fmt.Printf("Management cluster ID: %v, Name: %v\n", mcID, mcName)
+
+	namespaces, err := login.ListNamespaces(e.Options.ClusterID, isHostedControlPlane)
+	if err != nil {
+		return err
+	}
+	e.Options.Namespaces = namespaces
+
+	if !isHostedControlPlane {
+		if e.Options.GlobalOpts.Service {
+			return fmt.Errorf("service clusters are only available for hosted control plane clusters")
+		}
+		return nil
+	}
+
+	scID, scName, err := ocm.DefaultOCMInterface.GetServiceCluster(e.Options.ClusterID)
+	if err != nil {
+		return err
+	}
+	e.Options.ServiceClusterID = scID
+	e.Options.ServiceClusterName = scName
+	fmt.Printf("Service cluster ID: %v, Name: %v\n", scID, scName)
+	// The namespace of the hosted cluster manifestworks on the service cluster
+	e.Options.Namespaces["MC_NAME"] = mcName
+	return nil
+}
+
+// relatedClusters returns the session cluster and the clusters it is logged into along with it
+func (e *BackplaneSession) relatedClusters() []relatedCluster {
+	if e.Options.ManagementClusterID == "" {
+		return nil
+	}
+	clusters := []relatedCluster{
+		{Kind: "hc", Description: "hosted cluster", ID: e.Options.ClusterID, Name: e.Options.ClusterName},
+		{Kind: "mc", Description: "management cluster", ID: e.Options.ManagementClusterID, Name: e.Options.ManagementClusterName},
+	}
+	if e.Options.ServiceClusterID != "" {
+		clusters = append(clusters, relatedCluster{
+			Kind: "sc", Description: "service cluster", ID: e.Options.ServiceClusterID, Name: e.Options.ServiceClusterName,
+		})
+	}
+	return clusters
+}
+
+// activeCluster returns the cluster the session starts on: the service or management cluster when asked for
+func (e *BackplaneSession) activeCluster(clusters []relatedCluster) relatedCluster {
+	kind := "hc"
+	if e.Options.GlobalOpts != nil && e.Options.GlobalOpts.Service && e.Options.ServiceClusterID != "" {
+		kind = "sc"
+	} else if e.Options.GlobalOpts != nil && e.Options.GlobalOpts.Manager {
+		kind = "mc"
+	}
+	for _, cluster := range clusters {
+		if cluster.Kind == kind {
+			return cluster
+		}
+	}
+	return clusters[0]
+}
+
+// clusterKubeConfigPath returns the kubeconfig the login of the cluster is saved to
+func (e *BackplaneSession) clusterKubeConfigPath(clusterID string) string {
+	return filepath.Join(e.Path, clusterID, "config")
+}
+
+// relatedClustersEnv returns the env variables of the related clusters and of the cluster namespaces
+func (e *BackplaneSession) relatedClustersEnv(clusters []relatedCluster) string {
+	env := ""
+	for _, cluster := range clusters {
+		prefix := strings.ToUpper(cluster.Kind)
+		env += prefix + "_KUBECONFIG=" + e.clusterKubeConfigPath(cluster.ID) + "\n"
+		env += prefix + "_CLUSTERID=" + cluster.ID + "\n"
+		env += prefix + "_CLUSTERNAME=" + cluster.Name + "\n"
+	}
+
+	keys := make([]string, 0, len(e.Options.Namespaces))
+	for key := range e.Options.Namespaces {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		env += key + "=" + e.Options.Namespaces[key] + "\n"
+	}
+	return env
+}
+
+// createClusterHelpers points the session kubeconfig to the active cluster, and creates the helpers
+// switching between the clusters. They are rewritten each time as the clusters may change.
+func (e *BackplaneSession) createClusterHelpers(clusters []relatedCluster) error {
+	activeKubeConfig := filepath.Join(e.Path, activeKubeConfigName)
+	for _, kind := range []string{"hc", "mc", "sc"} {
+		if err := os.Remove(filepath.Join(e.binPath(), "use-"+kind)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	if err := os.Remove(activeKubeConfig); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if len(clusters) == 0 {
+		return nil
+	}
+
+	active := e.activeCluster(clusters)
+	if err := os.Symlink(e.clusterKubeConfigPath(active.ID), activeKubeConfig); err != nil {
+		return fmt.Errorf("can't link the session kubeconfig: %v", err)
+	}
+	for _, cluster := range clusters {
+		helper := fmt.Sprintf(`#!/bin/bash
+
+set -euo pipefail
+
+ln -sfn %q %q
+echo "Switched to the %s %s (%s)"
+`, e.clusterKubeConfigPath(cluster.ID), activeKubeConfig, cluster.Description, cluster.Name, cluster.ID)
+		if err := e.createBin("use-"+cluster.Kind, helper); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loginRelatedClusters logs into the clusters related to the session cluster
+func (e *BackplaneSession) loginRelatedClusters(clusters []relatedCluster, loginCluster func(clusterID string) error) error {
+	for _, cluster := range clusters {
+		if cluster.ID == e.Options.ClusterID {
+			continue
+		}
+		fmt.Printf("Logging into the %s %s\n", cluster.Description, cluster.Name)
+		if err := loginCluster(cluster.ID); err != nil {
+			return fmt.Errorf("error occurred when login to the %s %v", cluster.Description, err)
+		}
+	}
+	return nil
+}
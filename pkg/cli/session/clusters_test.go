@@ -0,0 +1,142 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/backplane-cli/pkg/cli/globalflags"
+	"github.com/openshift/backplane-cli/pkg/ocm"
+	ocmMock "github.com/openshift/backplane-cli/pkg/ocm/mocks"
+)
+
+var _ = Describe("Backplane Session related clusters", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockOcmInterface *ocmMock.MockOCMInterface
+
+		globalOpts globalflags.GlobalOptions
+		options    Options
+		bpSession  BackplaneSession
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockOcmInterface = ocmMock.NewMockOCMInterface(mockCtrl)
+		ocm.DefaultOCMInterface = mockOcmInterface
+
+		setupTestHome()
+
+		globalOpts = globalflags.GlobalOptions{}
+		options = Options{
+			Alias:       "my-session",
+			ClusterID:   "hc123",
+			ClusterName: "my-hc",
+			GlobalOpts:  &globalOpts,
+		}
+		bpSession = BackplaneSession{Options: &options}
+		Expect(bpSession.initSessionPath()).To(Succeed())
+
+		env, _ := cmv1.NewEnvironment().Name("integration").Build()
+		cluster, _ := cmv1.NewCluster().ID("hc123").DomainPrefix("my-hc").Build()
+		mockOcmInterface.EXPECT().GetOCMEnvironment().Return(env, nil).AnyTimes()
+		mockOcmInterface.EXPECT().GetClusterInfoByID("hc123").Return(cluster, nil).AnyTimes()
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("for a hosted cluster", func() {
+		BeforeEach(func() {
+			mockOcmInterface.EXPECT().GetManagingCluster("hc123").Return("mc123", "my-mc", true, nil).Times(1)
+			mockOcmInterface.EXPECT().GetServiceCluster("hc123").Return("sc123", "my-sc", nil).Times(1)
+		})
+
+		It("should export the clusters and the namespaces, starting on the management cluster", func() {
+			globalOpts.Manager = true
+			Expect(bpSession.initRelatedClusters()).To(Succeed())
+			Expect(bpSession.Setup()).To(Succeed())
+
+			env, err := readSessionEnv(bpSession.Path)
+			Expect(err).To(BeNil())
+			Expect(env).To(HaveKeyWithValue("KUBECONFIG", filepath.Join(bpSession.Path, activeKubeConfigName)))
+			Expect(env).To(HaveKeyWithValue("CLUSTERID", "hc123"))
+			Expect(env).To(HaveKeyWithValue("HC_KUBECONFIG", filepath.Join(bpSession.Path, "hc123", "config")))
+			Expect(env).To(HaveKeyWithValue("MC_KUBECONFIG", filepath.Join(bpSession.Path, "mc123", "config")))
+			Expect(env).To(HaveKeyWithValue("SC_CLUSTERNAME", "my-sc"))
+			Expect(env).To(HaveKeyWithValue("HC_NAMESPACE", "ocm-int-hc123"))
+			Expect(env).To(HaveKeyWithValue("HCP_NAMESPACE", "ocm-int-hc123-my-hc"))
+			Expect(env).To(HaveKeyWithValue("KLUSTERLET_NS", "klusterlet-hc123"))
+			Expect(env).To(HaveKeyWithValue("MC_NAME", "my-mc"))
+
+			target, err := os.Readlink(filepath.Join(bpSession.Path, activeKubeConfigName))
+			Expect(err).To(BeNil())
+			Expect(target).To(Equal(filepath.Join(bpSession.Path, "mc123", "config")))
+			for _, helper := range []string{"use-hc", "use-mc", "use-sc"} {
+				Expect(filepath.Join(bpSession.Path, "bin", helper)).To(BeAnExistingFile())
+			}
+			useSC, err := os.ReadFile(filepath.Join(bpSession.Path, "bin", "use-sc"))
+			Expect(err).To(BeNil())
+			Expect(string(useSC)).To(ContainSubstring(filepath.Join(bpSession.Path, "sc123", "config")))
+		})
+
+		It("should log into the management and service clusters after the hosted cluster", func() {
+			globalOpts.Service = true
+			Expect(bpSession.initRelatedClusters()).To(Succeed())
+
+			logins := []string{}
+			Expect(bpSession.loginRelatedClusters(bpSession.relatedClusters(), func(clusterID string) error {
+				logins = append(logins, clusterID)
+				return nil
+			})).To(Succeed())
+			Expect(logins).To(Equal([]string{"mc123", "sc123"}))
+			Expect(bpSession.activeCluster(bpSession.relatedClusters()).ID).To(Equal("sc123"))
+		})
+	})
+
+	It("should only use the hive shard of a classic cluster", func() {
+		mockOcmInterface.EXPECT().GetManagingCluster("hc123").Return("hive123", "my-hive", false, nil).Times(1)
+		globalOpts.Manager = true
+		Expect(bpSession.initRelatedClusters()).To(Succeed())
+		Expect(bpSession.Setup()).To(Succeed())
+
+		env, err := readSessionEnv(bpSession.Path)
+		Expect(err).To(BeNil())
+		Expect(env).To(HaveKeyWithValue("HIVE_NS", "uhc-int-hc123"))
+		Expect(env).To(HaveKeyWithValue("MC_CLUSTERID", "hive123"))
+		Expect(env).NotTo(HaveKey("SC_CLUSTERID"))
+		Expect(filepath.Join(bpSession.Path, "bin", "use-sc")).NotTo(BeAnExistingFile())
+	})
+
+	It("should refuse the service cluster of a classic cluster", func() {
+		mockOcmInterface.EXPECT().GetManagingCluster("hc123").Return("hive123", "my-hive", false, nil).Times(1)
+		globalOpts.Service = true
+		Expect(bpSession.initRelatedClusters()).To(MatchError(ContainSubstring("only available for hosted control plane clusters")))
+	})
+
+	It("should drop the helpers of a session resumed without the related clusters", func() {
+		mockOcmInterface.EXPECT().GetManagingCluster("hc123").Return("hive123", "my-hive", false, nil).Times(1)
+		globalOpts.Manager = true
+		Expect(bpSession.initRelatedClusters()).To(Succeed())
+		Expect(bpSession.Setup()).To(Succeed())
+
+		resumed := BackplaneSession{Options: &Options{
+			Alias:       "my-session",
+			ClusterID:   "hc123",
+			ClusterName: "my-hc",
+			GlobalOpts:  &globalflags.GlobalOptions{},
+		}, Path: bpSession.Path}
+		Expect(resumed.Setup()).To(Succeed())
+
+		Expect(filepath.Join(bpSession.Path, "bin", "use-mc")).NotTo(BeAnExistingFile())
+		env, err := readSessionEnv(bpSession.Path)
+		Expect(err).To(BeNil())
+		Expect(env).To(HaveKeyWithValue("KUBECONFIG", filepath.Join(bpSession.Path, "hc123", "config")))
+		Expect(env).NotTo(HaveKey("MC_CLUSTERID"))
+	})
+})
@@ -0,0 +1,134 @@
+package session
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// exportMetadata describes the exported session
+type exportMetadata struct {
+	Alias       string    `json:"alias"`
+	ClusterID   string    `json:"cluster_id,omitempty"`
+	ClusterName string    `json:"cluster_name,omitempty"`
+	ExportedAt  time.Time `json:"exported_at"`
+	Recordings  []string  `json:"recordings"`
+}
+
+// ExportFilename returns the default name of the export of the session of the given alias
+func ExportFilename(alias string, at time.Time) string {
+	return fmt.Sprintf("session-%s-%s.tar.gz", alias, at.UTC().Format("20060102T150405Z"))
+}
+
+// Export writes the transcripts, the history and the cluster metadata of the session of the given alias
+// as a gzipped tarball
+func Export(alias string, w io.Writer) error {
+	sessionPath, err := GetSessionPath(alias)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(sessionPath); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("session %s does not exist", alias)
+		}
+		return err
+	}
+
+	recordings, err := listRecordings(sessionPath)
+	if err != nil {
+		return err
+	}
+	env, err := readSessionEnv(sessionPath)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	metadata := exportMetadata{
+		Alias:       alias,
+		ClusterID:   env["CLUSTERID"],
+		ClusterName: env["CLUSTERNAME"],
+		ExportedAt:  now.UTC(),
+		Recordings:  []string{},
+	}
+	for _, r := range recordings {
+		metadata.Recordings = append(metadata.Recordings, filepath.Join(recordingsDirectory, filepath.Base(r)))
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	content, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeExportFile(tw, "metadata.json", content, now); err != nil {
+		return err
+	}
+
+	files := append([]string{filepath.Join(sessionPath, ".history")}, recordings...)
+	for _, path := range files {
+		content, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return err
+		}
+		name, err := filepath.Rel(sessionPath, path)
+		if err != nil {
+			return err
+		}
+		if err := writeExportFile(tw, filepath.ToSlash(strings.TrimPrefix(name, ".")), content, now); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// readSessionEnv returns the variables of the .ocenv file of the session
+func readSessionEnv(sessionPath string) (map[string]string, error) {
+	env := map[string]string{}
+	file, err := os.Open(filepath.Join(sessionPath, ".ocenv"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return env, nil
+		}
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if key, value, ok := strings.Cut(scanner.Text(), "="); ok {
+			env[strings.TrimSpace(key)] = value
+		}
+	}
+	return env, scanner.Err()
+}
+
+// writeExportFile adds a regular file to the tarball
+func writeExportFile(tw *tar.Writer, name string, content []byte, modTime time.Time) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(content)),
+		ModTime: modTime,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := tw.Write(content)
+	return err
+}
@@ -0,0 +1,287 @@
+package session
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"github.com/creack/pty"
+	"golang.org/x/term"
+)
+
+const (
+	// The directory under the session directory holding the transcripts
+	recordingsDirectory = "recordings"
+
+	// The extension of asciinema transcripts
+	recordingExtension = ".cast"
+
+	// The default width and height of a transcript when the terminal size is unknown
+	defaultRecordingWidth  = 80
+	defaultRecordingHeight = 24
+)
+
+// castHeader is the header line of an asciinema v2 transcript
+type castHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// castWriter writes the output written to it as events of an asciinema v2 transcript
+type castWriter struct {
+	mu      sync.Mutex
+	w       io.Writer
+	start   time.Time
+	pending []byte
+}
+
+// newCastWriter writes the transcript header and returns the writer of its events
+func newCastWriter(w io.Writer, header castHeader, start time.Time) (*castWriter, error) {
+	header.Version = 2
+	header.Timestamp = start.Unix()
+	content, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fmt.Fprintf(w, "%s\n", content); err != nil {
+		return nil, err
+	}
+	return &castWriter{w: w, start: start}, nil
+}
+
+// Write records p as an output event, holding back an incomplete trailing UTF-8 sequence until the next write
+func (c *castWriter) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data := append(c.pending, p...)
+	cut := len(data)
+	// a rune is at most utf8.UTFMax bytes, so only the tail can hold an incomplete one
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if !utf8.FullRune(data[i:]) {
+				cut = i
+			}
+			break
+		}
+	}
+	c.pending = append([]byte(nil), data[cut:]...)
+	if cut == 0 {
+		return len(p), nil
+	}
+
+	if err := c.writeEvent(time.Since(c.start), data[:cut]); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// writeEvent writes a single output event
+func (c *castWriter) writeEvent(at time.Duration, data []byte) error {
+	event, err := json.Marshal([]interface{}{at.Seconds(), "o", string(data)})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(c.w, "%s\n", event)
+	return err
+}
+
+// Flush writes the output held back by the writer
+func (c *castWriter) Flush() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.pending) == 0 {
+		return nil
+	}
+	err := c.writeEvent(time.Since(c.start), c.pending)
+	c.pending = nil
+	return err
+}
+
+// recordingsPath returns the directory of the session transcripts
+func (e *BackplaneSession) recordingsPath() string {
+	return filepath.Join(e.Path, recordingsDirectory)
+}
+
+// runRecorded runs the shell command in a pseudo terminal and records its output into a new transcript
+func (e *BackplaneSession) runRecorded(cmd *exec.Cmd) error {
+	if err := os.MkdirAll(e.recordingsPath(), 0750); err != nil {
+		return err
+	}
+	start := time.Now()
+	transcriptPath := filepath.Join(e.recordingsPath(), start.UTC().Format("20060102T150405Z")+recordingExtension)
+	transcript, err := os.OpenFile(transcriptPath, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("can't create transcript %s: %v", transcriptPath, err)
+	}
+	defer func() { _ = transcript.Close() }()
+
+	width, height := defaultRecordingWidth, defaultRecordingHeight
+	stdinFd := int(os.Stdin.Fd()) //nolint:gosec
+	if w, h, err := term.GetSize(stdinFd); err == nil {
+		width, height = w, h
+	}
+	recorder, err := newCastWriter(transcript, castHeader{
+		Width:  width,
+		Height: height,
+		Title:  fmt.Sprintf("Backplane session %s", e.Options.Alias),
+		Env:    map[string]string{"SHELL": cmd.Path, "TERM": os.Getenv("TERM")},
+	}, start)
+	if err != nil {
+		return err
+	}
+
+	ptmx, err := pty.StartWithSize(cmd, &pty.Winsize{Cols: uint16(width), Rows: uint16(height)}) //nolint:gosec
+	if err != nil {
+		return err
+	}
+	defer func() { _ = ptmx.Close() }()
+
+	// follow the size of the terminal
+	resize := make(chan os.Signal, 1)
+	signal.Notify(resize, syscall.SIGWINCH)
+	defer func() { signal.Stop(resize); close(resize) }()
+	go func() {
+		for range resize {
+			_ = pty.InheritSize(os.Stdin, ptmx)
+		}
+	}()
+
+	if term.IsTerminal(stdinFd) {
+		oldState, err := term.MakeRaw(stdinFd)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = term.Restore(stdinFd, oldState) }()
+	}
+
+	fmt.Printf("Recording session to %s\r\n", transcriptPath)
+	go func() { _, _ = io.Copy(ptmx, os.Stdin) }()
+	// the copy ends with an error once the shell exits and the pty closes
+	_, _ = io.Copy(io.MultiWriter(os.Stdout, recorder), ptmx)
+
+	if err := recorder.Flush(); err != nil {
+		return err
+	}
+	return cmd.Wait()
+}
+
+// listRecordings returns the transcripts of the session directory, the oldest first
+func listRecordings(sessionPath string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(sessionPath, recordingsDirectory))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+	recordings := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), recordingExtension) {
+			recordings = append(recordings, filepath.Join(sessionPath, recordingsDirectory, entry.Name()))
+		}
+	}
+	// transcript names are timestamps, so the name order is the chronological order
+	sort.Strings(recordings)
+	return recordings, nil
+}
+
+// ReplayOptions define how a transcript is replayed
+type ReplayOptions struct {
+	// Speed multiplies the replay speed
+	Speed float64
+	// IdleTimeLimit caps the pauses of the replay, no limit when zero
+	IdleTimeLimit time.Duration
+}
+
+// Replay replays the latest transcript of the session of the given alias to out
+func Replay(alias string, out io.Writer, opts ReplayOptions) error {
+	sessionPath, err := GetSessionPath(alias)
+	if err != nil {
+		return err
+	}
+	recordings, err := listRecordings(sessionPath)
+	if err != nil {
+		return err
+	}
+	if len(recordings) == 0 {
+		return fmt.Errorf("no transcript found for session %s, record one with 'session --record %s'", alias, alias)
+	}
+
+	transcript, err := os.Open(recordings[len(recordings)-1])
+	if err != nil {
+		return err
+	}
+	defer func() { _ = transcript.Close() }()
+	return replayTranscript(transcript, out, opts, time.Sleep)
+}
+
+// replayTranscript writes the output events of the asciinema v2 transcript to out with their timing
+func replayTranscript(transcript io.Reader, out io.Writer, opts ReplayOptions, sleep func(time.Duration)) error {
+	if opts.Speed <= 0 {
+		opts.Speed = 1
+	}
+
+	scanner := bufio.NewScanner(transcript)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	if !scanner.Scan() {
+		return fmt.Errorf("the transcript is empty")
+	}
+	var header castHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return fmt.Errorf("invalid transcript header: %v", err)
+	}
+	if header.Version != 2 {
+		return fmt.Errorf("unsupported transcript version %d", header.Version)
+	}
+
+	last := 0.0
+	for scanner.Scan() {
+		var event []interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return fmt.Errorf("invalid transcript event: %v", err)
+		}
+		if len(event) != 3 {
+			return fmt.Errorf("invalid transcript event %s", scanner.Text())
+		}
+		at, okAt := event[0].(float64)
+		kind, okKind := event[1].(string)
+		data, okData := event[2].(string)
+		if !okAt || !okKind || !okData {
+			return fmt.Errorf("invalid transcript event %s", scanner.Text())
+		}
+		if kind != "o" {
+			continue
+		}
+
+		pause := time.Duration((at - last) / opts.Speed * float64(time.Second))
+		if opts.IdleTimeLimit > 0 && pause > opts.IdleTimeLimit {
+			pause = opts.IdleTimeLimit
+		}
+		if pause > 0 {
+			sleep(pause)
+		}
+		last = at
+
+		if _, err := io.WriteString(out, data); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,162 @@
+package session
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/backplane-cli/pkg/info"
+)
+
+var _ = Describe("Backplane Session transcripts", func() {
+	Context("recording", func() {
+		It("should write an asciinema v2 header and output events", func() {
+			var transcript bytes.Buffer
+			recorder, err := newCastWriter(&transcript, castHeader{Width: 100, Height: 30, Title: "test"}, time.Now())
+			Expect(err).To(BeNil())
+
+			_, err = recorder.Write([]byte("hello\r\n"))
+			Expect(err).To(BeNil())
+
+			lines := strings.Split(strings.TrimSpace(transcript.String()), "\n")
+			Expect(lines).To(HaveLen(2))
+
+			var header castHeader
+			Expect(json.Unmarshal([]byte(lines[0]), &header)).To(Succeed())
+			Expect(header.Version).To(Equal(2))
+			Expect(header.Width).To(Equal(100))
+
+			var event []interface{}
+			Expect(json.Unmarshal([]byte(lines[1]), &event)).To(Succeed())
+			Expect(event[1]).To(Equal("o"))
+			Expect(event[2]).To(Equal("hello\r\n"))
+		})
+
+		It("should not split multi-byte characters across events", func() {
+			var transcript bytes.Buffer
+			recorder, err := newCastWriter(&transcript, castHeader{}, time.Now())
+			Expect(err).To(BeNil())
+
+			euro := []byte("€")
+			_, err = recorder.Write(append([]byte("a"), euro[:2]...))
+			Expect(err).To(BeNil())
+			_, err = recorder.Write(euro[2:])
+			Expect(err).To(BeNil())
+			Expect(recorder.Flush()).To(Succeed())
+
+			lines := strings.Split(strings.TrimSpace(transcript.String()), "\n")
+			Expect(lines).To(HaveLen(3))
+			var first, second []interface{}
+			Expect(json.Unmarshal([]byte(lines[1]), &first)).To(Succeed())
+			Expect(json.Unmarshal([]byte(lines[2]), &second)).To(Succeed())
+			Expect(first[2]).To(Equal("a"))
+			Expect(second[2]).To(Equal("€"))
+		})
+	})
+
+	Context("replaying", func() {
+		transcript := `{"version":2,"width":80,"height":24,"timestamp":1700000000}
+[0.5,"o","first "]
+[1.0,"i","ignored"]
+[10.5,"o","second"]
+`
+
+		It("should write the output with its timing", func() {
+			var out bytes.Buffer
+			pauses := []time.Duration{}
+			err := replayTranscript(strings.NewReader(transcript), &out, ReplayOptions{Speed: 2}, func(d time.Duration) {
+				pauses = append(pauses, d)
+			})
+			Expect(err).To(BeNil())
+			Expect(out.String()).To(Equal("first second"))
+			Expect(pauses).To(Equal([]time.Duration{250 * time.Millisecond, 5 * time.Second}))
+		})
+
+		It("should cap the idle time", func() {
+			var out bytes.Buffer
+			pauses := []time.Duration{}
+			err := replayTranscript(strings.NewReader(transcript), &out, ReplayOptions{IdleTimeLimit: time.Second}, func(d time.Duration) {
+				pauses = append(pauses, d)
+			})
+			Expect(err).To(BeNil())
+			Expect(pauses).To(Equal([]time.Duration{500 * time.Millisecond, time.Second}))
+		})
+
+		It("should reject other transcript versions", func() {
+			err := replayTranscript(strings.NewReader(`{"version":1}`), io.Discard, ReplayOptions{}, func(time.Duration) {})
+			Expect(err).To(MatchError(ContainSubstring("unsupported transcript version")))
+		})
+	})
+
+	Context("exporting", func() {
+		var sessionPath string
+
+		BeforeEach(func() {
+			home := GinkgoT().TempDir()
+			GinkgoT().Setenv("HOME", home)
+			GinkgoT().Setenv(info.BackplaneConfigPathEnvName, filepath.Join(home, "config.json"))
+			GinkgoT().Setenv(info.BackplaneURLEnvName, "https://api.integration.backplane.example.com")
+
+			var err error
+			sessionPath, err = GetSessionPath("my-session")
+			Expect(err).To(BeNil())
+			Expect(sessionPath).To(Equal(filepath.Join(home, info.BackplaneDefaultSessionDirectory, "my-session")))
+
+			Expect(os.MkdirAll(filepath.Join(sessionPath, recordingsDirectory), 0750)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(sessionPath, ".history"), []byte("oc get pods\n"), 0600)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(sessionPath, ".ocenv"), []byte("CLUSTERID=test123\nCLUSTERNAME=my-cluster\n"), 0600)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(sessionPath, recordingsDirectory, "20240101T090000Z.cast"), []byte(`{"version":2}`), 0600)).To(Succeed())
+		})
+
+		It("should bundle the transcripts, history and cluster metadata", func() {
+			var archive bytes.Buffer
+			Expect(Export("my-session", &archive)).To(Succeed())
+
+			gz, err := gzip.NewReader(&archive)
+			Expect(err).To(BeNil())
+			tr := tar.NewReader(gz)
+			files := map[string]string{}
+			for {
+				header, err := tr.Next()
+				if err == io.EOF {
+					break
+				}
+				Expect(err).To(BeNil())
+				content, _ := io.ReadAll(tr)
+				files[header.Name] = string(content)
+			}
+
+			Expect(files).To(HaveKeyWithValue("history", "oc get pods\n"))
+			Expect(files).To(HaveKey("recordings/20240101T090000Z.cast"))
+
+			var metadata exportMetadata
+			Expect(json.Unmarshal([]byte(files["metadata.json"]), &metadata)).To(Succeed())
+			Expect(metadata.ClusterID).To(Equal("test123"))
+			Expect(metadata.ClusterName).To(Equal("my-cluster"))
+			Expect(metadata.Recordings).To(Equal([]string{"recordings/20240101T090000Z.cast"}))
+		})
+
+		It("should replay the latest transcript", func() {
+			Expect(os.WriteFile(filepath.Join(sessionPath, recordingsDirectory, "20240102T090000Z.cast"),
+				[]byte("{\"version\":2}\n[0,\"o\",\"latest\"]\n"), 0600)).To(Succeed())
+
+			var out bytes.Buffer
+			Expect(Replay("my-session", &out, ReplayOptions{})).To(Succeed())
+			Expect(out.String()).To(Equal("latest"))
+		})
+
+		It("should fail for an unknown session", func() {
+			Expect(Export("unknown", io.Discard)).To(MatchError(ContainSubstring("does not exist")))
+			Expect(Replay("unknown", io.Discard, ReplayOptions{})).To(MatchError(ContainSubstring("no transcript found")))
+		})
+	})
+})
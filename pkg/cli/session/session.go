@@ -13,9 +13,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/backplane-cli/cmd/ocm-backplane/login"
-	"github.com/openshift/backplane-cli/pkg/cli/config"
 	"github.com/openshift/backplane-cli/pkg/cli/globalflags"
-	"github.com/openshift/backplane-cli/pkg/info"
 	"github.com/openshift/backplane-cli/pkg/ocm"
 )
 
@@ -40,11 +38,30 @@ type BackplaneSession struct {
 type Options struct {
 	DeleteSession bool
 
+	// Start the session from scratch, deleting the existing session directory
+	Fresh bool
+
+	// Record the session transcript
+	Record bool
+
+	// The session templates to apply, every template of the templates directory when empty
+	Templates []string
+
 	Alias string
 
 	ClusterID   string
 	ClusterName string
 
+	// The management and service clusters the session logs into along with the cluster,
+	// with --manager and --service
+	ManagementClusterID   string
+	ManagementClusterName string
+	ServiceClusterID      string
+	ServiceClusterName    string
+
+	// The namespaces of the cluster on its management cluster, keyed by the name of their env variable
+	Namespaces map[string]string
+
 	GlobalOpts *globalflags.GlobalOptions
 }
 
@@ -70,6 +87,15 @@ func (e *BackplaneSession) RunCommand(cmd *cobra.Command, args []string) error {
 	clusterKey := e.Options.Alias
 	if e.Options.ClusterID != "" {
 		clusterKey = e.Options.ClusterID
+	} else {
+		// resume the existing session of the alias on the cluster it was set up for
+		savedClusterID, err := e.savedClusterID()
+		if err != nil {
+			return fmt.Errorf("could not read the existing session %s. error: %w", e.Options.Alias, err)
+		}
+		if savedClusterID != "" {
+			clusterKey = savedClusterID
+		}
 	}
 
 	clusterID, clusterName, err := ocm.DefaultOCMInterface.GetTargetCluster(clusterKey)
@@ -78,31 +104,18 @@ func (e *BackplaneSession) RunCommand(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid cluster Id %s. error: %w", clusterKey, err)
 	}
 
-	if e.Options.GlobalOpts.Manager {
-		clusterID, clusterName, _, err = ocm.DefaultOCMInterface.GetManagingCluster(clusterID)
-
-		e.Options.Alias = clusterID
-		if err != nil {
-			return err
-		}
-
-		fmt.Printf("Switching to management cluster ID: %v, Name: %v\n", clusterID, clusterName)
-	}
+	// set cluster options
+	e.Options.ClusterName = clusterName
+	e.Options.ClusterID = clusterID
 
-	if e.Options.GlobalOpts.Service {
-		clusterID, clusterName, err = ocm.DefaultOCMInterface.GetServiceCluster(clusterID)
-		e.Options.Alias = clusterID
+	// Keep the hosted cluster as the session cluster, and log into its management and service clusters as well
+	if e.Options.GlobalOpts.Manager || e.Options.GlobalOpts.Service {
+		err = e.initRelatedClusters()
 		if err != nil {
-			return err
+			return fmt.Errorf("could not find the clusters related to %s. error: %v", clusterID, err)
 		}
-
-		fmt.Printf("Switching to service cluster ID: %v, Name: %v\n", clusterID, clusterName)
 	}
 
-	// set cluster options
-	e.Options.ClusterName = clusterName
-	e.Options.ClusterID = clusterID
-
 	err = e.initSessionPath()
 	if err != nil {
 		return fmt.Errorf("could not init session path. error: %w", err)
@@ -135,27 +148,44 @@ func (e *BackplaneSession) RunCommand(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// Setup initialize the session environment
+// Setup initialize the session environment, resuming the existing session unless a fresh one is requested
 func (e *BackplaneSession) Setup() error {
-	// Delete session if exist
-	err := e.Delete()
-	if err != nil {
-		return fmt.Errorf("error deleting session. error: %v", err)
+	if e.Options.Fresh {
+		// Delete session if exist
+		err := e.Delete()
+		if err != nil {
+			return fmt.Errorf("error deleting session. error: %v", err)
+		}
 	}
 
+	_, err := os.Stat(e.Path)
+	resumed := err == nil
 	err = e.ensureEnvDir()
 	if err != nil {
 		return fmt.Errorf("error validating env directory. error: %v", err)
 	}
 
 	e.printSessionHeader()
+	if resumed {
+		fmt.Printf("Resuming Backplane session %s, use --fresh to start from scratch\n", e.Options.Alias)
+	}
+
+	bins, templatesEnv, err := e.renderTemplates()
+	if err != nil {
+		return fmt.Errorf("error rendering session templates. error: %v", err)
+	}
 
 	// Create session Bins
-	err = e.createBins()
+	err = e.createBins(bins)
 	if err != nil {
 		return fmt.Errorf("error creating bins. error: %v", err)
 	}
 
+	err = e.createClusterHelpers(e.relatedClusters())
+	if err != nil {
+		return fmt.Errorf("error creating cluster helpers. error: %v", err)
+	}
+
 	// Creating history files
 	err = e.createHistoryFile()
 	if err != nil {
@@ -163,11 +193,16 @@ func (e *BackplaneSession) Setup() error {
 	}
 
 	// Validating env variables
-	err = e.ensureEnvVariables()
+	err = e.ensureEnvVariables(templatesEnv)
 	if err != nil {
 		return fmt.Errorf("error setting env vars. error: %v", err)
 	}
 
+	err = e.touchMetadata()
+	if err != nil {
+		return fmt.Errorf("error saving session metadata. error: %v", err)
+	}
+
 	return nil
 }
 
@@ -196,11 +231,15 @@ func (e *BackplaneSession) Start() error {
 			line := scanner.Text()
 			cmd.Env = append(cmd.Env, line)
 		}
-		cmd.Stdin = os.Stdin
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
 		cmd.Dir = e.Path
-		err = cmd.Run()
+		if e.Options.Record {
+			err = e.runRecorded(cmd)
+		} else {
+			cmd.Stdin = os.Stdin
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+			err = cmd.Run()
+		}
 		if err != nil {
 			return fmt.Errorf("error while running cmd. %v", err)
 		}
@@ -233,47 +272,34 @@ func (e *BackplaneSession) ensureEnvDir() error {
 	return nil
 }
 
-// ensureEnvDir initialize session env vars
-func (e *BackplaneSession) ensureEnvVariables() error {
+// ensureEnvVariables initialize session env vars, rewriting them so a resumed session uses the current login.
+// The variables of the session templates are added last so they can use the session ones.
+func (e *BackplaneSession) ensureEnvVariables(templatesEnv string) error {
 	envContent := `
 HISTFILE=` + e.Path + `/.history
 PATH=` + e.Path + `/bin:` + os.Getenv("PATH") + `
 `
 
 	if e.Options.ClusterID != "" {
-		clusterEnvContent := "KUBECONFIG=" + filepath.Join(e.Path, e.Options.ClusterID, "config") + "\n"
+		kubeConfig := e.clusterKubeConfigPath(e.Options.ClusterID)
+		clusters := e.relatedClusters()
+		if len(clusters) > 0 {
+			// switched between the clusters by the use-* helpers
+			kubeConfig = filepath.Join(e.Path, activeKubeConfigName)
+		}
+		clusterEnvContent := "KUBECONFIG=" + kubeConfig + "\n"
 		clusterEnvContent = clusterEnvContent + "CLUSTERID=" + e.Options.ClusterID + "\n"
 		clusterEnvContent = clusterEnvContent + "CLUSTERNAME=" + e.Options.ClusterName + "\n"
+		clusterEnvContent = clusterEnvContent + e.relatedClustersEnv(clusters)
 		envContent = envContent + clusterEnvContent
 	}
-	dirEnvFile, err := e.ensureFile(e.Path + "/.ocenv")
+	envContent = envContent + templatesEnv
+	err := os.WriteFile(filepath.Join(e.Path, ".ocenv"), []byte(envContent), 0600)
 	if err != nil {
 		return err
 	}
-	_, err = dirEnvFile.WriteString(envContent)
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer func(dirEnvFile *os.File) {
-		_ = dirEnvFile.Close()
-	}(dirEnvFile)
 
-	zshEnvFile, err := e.ensureFile(e.Path + "/.zshenv")
-	if err != nil {
-		return err
-	}
-	_, err = zshEnvFile.WriteString("source .ocenv")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer func(dirEnvFile *os.File) {
-		err := dirEnvFile.Close()
-		if err != nil {
-			fmt.Println("Error while calling dirEnvFile.Close(): ", err.Error())
-			return
-		}
-	}(dirEnvFile)
-	return nil
+	return os.WriteFile(filepath.Join(e.Path, ".zshenv"), []byte("source .ocenv"), 0600)
 }
 
 // createHistoryFile create .history file inside the session folder
@@ -283,6 +309,10 @@ func (e *BackplaneSession) createHistoryFile() error {
 	if err != nil {
 		return err
 	}
+	if scriptFile == nil {
+		// keep the history of a resumed session
+		return nil
+	}
 	defer func(scriptFile *os.File) {
 		err := scriptFile.Close()
 		if err != nil {
@@ -293,27 +323,39 @@ func (e *BackplaneSession) createHistoryFile() error {
 	return nil
 }
 
+// renderTemplates renders the builtin session template and the templates of the config directory
+func (e *BackplaneSession) renderTemplates() (bins map[string]string, env string, err error) {
+	templatesDir, err := GetTemplatesDirectory()
+	if err != nil {
+		return nil, "", err
+	}
+	templates, err := loadTemplates(templatesDir, e.Options.Templates)
+	if err != nil {
+		return nil, "", err
+	}
+	data := &TemplateData{
+		Alias:       e.Options.Alias,
+		SessionPath: e.Path,
+		ClusterID:   e.Options.ClusterID,
+		ClusterName: e.Options.ClusterName,
+	}
+	bins, env = renderTemplates(append([]sessionTemplate{builtinTemplate}, templates...), data)
+	return bins, env, nil
+}
+
 // createBins create bins inside the session folder bin dir
-func (e *BackplaneSession) createBins() error {
+func (e *BackplaneSession) createBins(bins map[string]string) error {
 	if _, err := os.Stat(e.binPath()); errors.Is(err, os.ErrNotExist) {
 		err := os.Mkdir(e.binPath(), 0750)
 		if err != nil {
 			log.Fatal(err)
 		}
 	}
-	err := e.createBin("ocd", "ocm describe cluster "+e.Options.ClusterID)
-	if err != nil {
-		return err
-	}
-	ocb := `
-#!/bin/bash
-
-set -euo pipefail
-
-`
-	err = e.createBin("ocb", ocb)
-	if err != nil {
-		return err
+	for name, content := range bins {
+		err := e.createBin(name, content)
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -325,6 +367,10 @@ func (e *BackplaneSession) createBin(cmd string, content string) error {
 	if err != nil {
 		return err
 	}
+	if scriptFile == nil {
+		// keep the bins of a resumed session, they may have been edited
+		return nil
+	}
 	defer func(scriptFile *os.File) {
 		err := scriptFile.Close()
 		if err != nil {
@@ -343,7 +389,8 @@ func (e *BackplaneSession) createBin(cmd string, content string) error {
 	return nil
 }
 
-// ensureFile check the existence of file in session path
+// ensureFile check the existence of file in session path, and creates it when missing.
+// The returned file is nil when the file already exists.
 func (e *BackplaneSession) ensureFile(filename string) (file *os.File, err error) {
 	filename = filepath.Clean(filename)
 	if _, err := os.Stat(filename); errors.Is(err, os.ErrNotExist) {
@@ -364,23 +411,11 @@ func (e *BackplaneSession) binPath() string {
 func (e *BackplaneSession) initSessionPath() error {
 
 	if e.Path == "" {
-		bpConfig, err := config.GetBackplaneConfiguration()
-		if err != nil {
-			return err
-		}
-		sessionDir := info.BackplaneDefaultSessionDirectory
-
-		// Get the session directory name via config
-		if bpConfig.SessionDirectory != "" {
-			sessionDir = bpConfig.SessionDirectory
-		}
-
-		userHomeDir, err := os.UserHomeDir()
+		sessionsDir, err := GetSessionsDirectory()
 		if err != nil {
 			return err
 		}
-
-		e.Path = filepath.Join(userHomeDir, sessionDir, e.Options.Alias)
+		e.Path = filepath.Join(sessionsDir, e.Options.Alias)
 	}
 
 	// Add Alias to the path
@@ -411,6 +446,13 @@ func (e *BackplaneSession) initClusterLogin(cmd *cobra.Command) error {
 		if err != nil {
 			return fmt.Errorf("error occurred when login to the cluster %v", err)
 		}
+
+		err = e.loginRelatedClusters(e.relatedClusters(), func(clusterID string) error {
+			return login.LoginCmd.RunE(cmd, []string{clusterID})
+		})
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
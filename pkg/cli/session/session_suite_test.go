@@ -2,11 +2,14 @@ package session
 
 import (
 	"io"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	"github.com/openshift/backplane-cli/pkg/info"
 )
 
 func TestIt(t *testing.T) {
@@ -18,3 +21,12 @@ func MakeIoReader(s string) io.ReadCloser {
 	r := io.NopCloser(strings.NewReader(s))
 	return r
 }
+
+// setupTestHome points the home, the backplane configuration and the backplane URL of the test to a temporary home it returns
+func setupTestHome() string {
+	home := GinkgoT().TempDir()
+	GinkgoT().Setenv("HOME", home)
+	GinkgoT().Setenv(info.BackplaneConfigPathEnvName, filepath.Join(home, "config.json"))
+	GinkgoT().Setenv(info.BackplaneURLEnvName, "https://api.integration.backplane.example.com")
+	return home
+}
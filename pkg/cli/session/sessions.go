@@ -0,0 +1,178 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/openshift/backplane-cli/pkg/cli/config"
+	"github.com/openshift/backplane-cli/pkg/info"
+)
+
+const (
+	// The file under the session directory describing the session
+	metadataFileName = ".session.json"
+)
+
+// Metadata describes a session, and is updated each time the session is used
+type Metadata struct {
+	Alias       string    `json:"alias"`
+	ClusterID   string    `json:"cluster_id,omitempty"`
+	ClusterName string    `json:"cluster_name,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastUsed    time.Time `json:"last_used"`
+}
+
+// GetSessionsDirectory returns the directory holding every session, based on the user config
+func GetSessionsDirectory() (string, error) {
+	bpConfig, err := config.GetBackplaneConfiguration()
+	if err != nil {
+		return "", err
+	}
+	sessionDir := info.BackplaneDefaultSessionDirectory
+
+	// Get the session directory name via config
+	if bpConfig.SessionDirectory != "" {
+		sessionDir = bpConfig.SessionDirectory
+	}
+
+	userHomeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(userHomeDir, sessionDir), nil
+}
+
+// GetSessionPath returns the directory of the session of the given alias
+func GetSessionPath(alias string) (string, error) {
+	if alias == "" {
+		return "", fmt.Errorf("session alias is empty")
+	}
+	if alias == "." || alias == ".." || strings.ContainsAny(alias, `/\`) {
+		return "", fmt.Errorf("invalid session alias %s", alias)
+	}
+	e := &BackplaneSession{Options: &Options{Alias: alias}}
+	if err := e.initSessionPath(); err != nil {
+		return "", err
+	}
+	return e.Path, nil
+}
+
+// touchMetadata records the session cluster and the time it is used
+func (e *BackplaneSession) touchMetadata() error {
+	now := time.Now()
+	metadata, err := readMetadata(e.Path)
+	if err != nil {
+		return err
+	}
+	if metadata.CreatedAt.IsZero() {
+		metadata.CreatedAt = now
+	}
+	metadata.Alias = e.Options.Alias
+	metadata.ClusterID = e.Options.ClusterID
+	metadata.ClusterName = e.Options.ClusterName
+	metadata.LastUsed = now
+
+	content, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(e.Path, metadataFileName), content, 0600)
+}
+
+// readMetadata returns the metadata of the session directory. Sessions created before metadata existed
+// savedClusterID returns the cluster the existing session of the alias was set up for, empty without such session
+func (e *BackplaneSession) savedClusterID() (string, error) {
+	if err := e.initSessionPath(); err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(e.Path); errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	metadata, err := readMetadata(e.Path)
+	if err != nil {
+		return "", err
+	}
+	return metadata.ClusterID, nil
+}
+
+// are described from their environment file and directory.
+func readMetadata(sessionPath string) (Metadata, error) {
+	metadata := Metadata{Alias: filepath.Base(sessionPath)}
+	content, err := os.ReadFile(filepath.Join(sessionPath, metadataFileName))
+	if err == nil {
+		if err := json.Unmarshal(content, &metadata); err != nil {
+			logger.Warnf("ignoring the invalid metadata of session %s: %v", metadata.Alias, err)
+		}
+		return metadata, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return metadata, err
+	}
+
+	env, err := readSessionEnv(sessionPath)
+	if err != nil {
+		return metadata, err
+	}
+	metadata.ClusterID = env["CLUSTERID"]
+	metadata.ClusterName = env["CLUSTERNAME"]
+	if stat, err := os.Stat(sessionPath); err == nil {
+		metadata.LastUsed = stat.ModTime()
+	}
+	return metadata, nil
+}
+
+// ListSessions returns the existing sessions, the most recently used first
+func ListSessions() ([]Metadata, error) {
+	sessionsDir, err := GetSessionsDirectory()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(sessionsDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []Metadata{}, nil
+		}
+		return nil, err
+	}
+
+	sessions := []Metadata{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		metadata, err := readMetadata(filepath.Join(sessionsDir, entry.Name()))
+		if err != nil {
+			logger.Warnf("cannot read session %s: %v", entry.Name(), err)
+			continue
+		}
+		sessions = append(sessions, metadata)
+	}
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].LastUsed.After(sessions[j].LastUsed)
+	})
+	return sessions, nil
+}
+
+// RemoveSession deletes the session of the given alias
+func RemoveSession(alias string) error {
+	sessionPath, err := GetSessionPath(alias)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(sessionPath); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("session %s does not exist", alias)
+		}
+		return err
+	}
+	return os.RemoveAll(sessionPath)
+}
@@ -0,0 +1,98 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/backplane-cli/pkg/cli/globalflags"
+	"github.com/openshift/backplane-cli/pkg/info"
+)
+
+var _ = Describe("Backplane Session resume", func() {
+	var (
+		sessionsDir string
+		options     Options
+		bpSession   BackplaneSession
+	)
+
+	BeforeEach(func() {
+		home := setupTestHome()
+		sessionsDir = filepath.Join(home, info.BackplaneDefaultSessionDirectory)
+
+		options = Options{
+			Alias:       "my-session",
+			ClusterID:   "test123",
+			ClusterName: "my-cluster",
+			GlobalOpts:  &globalflags.GlobalOptions{},
+		}
+		bpSession = BackplaneSession{Options: &options}
+		Expect(bpSession.initSessionPath()).To(Succeed())
+		Expect(bpSession.Setup()).To(Succeed())
+	})
+
+	It("should resume the session, keeping its history and bins", func() {
+		Expect(os.WriteFile(filepath.Join(bpSession.Path, ".history"), []byte("oc get nodes\n"), 0600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(bpSession.Path, "bin", "ocb"), []byte("#!/bin/bash\necho custom\n"), 0600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(bpSession.Path, "notes.md"), []byte("notes"), 0600)).To(Succeed())
+
+		options.ClusterName = "renamed-cluster"
+		Expect(bpSession.Setup()).To(Succeed())
+
+		history, err := os.ReadFile(filepath.Join(bpSession.Path, ".history"))
+		Expect(err).To(BeNil())
+		Expect(string(history)).To(Equal("oc get nodes\n"))
+		ocb, err := os.ReadFile(filepath.Join(bpSession.Path, "bin", "ocb"))
+		Expect(err).To(BeNil())
+		Expect(string(ocb)).To(ContainSubstring("custom"))
+		Expect(filepath.Join(bpSession.Path, "notes.md")).To(BeAnExistingFile())
+
+		env, err := readSessionEnv(bpSession.Path)
+		Expect(err).To(BeNil())
+		Expect(env["CLUSTERNAME"]).To(Equal("renamed-cluster"))
+	})
+
+	It("should start from scratch with fresh", func() {
+		Expect(os.WriteFile(filepath.Join(bpSession.Path, ".history"), []byte("oc get nodes\n"), 0600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(bpSession.Path, "notes.md"), []byte("notes"), 0600)).To(Succeed())
+
+		options.Fresh = true
+		Expect(bpSession.Setup()).To(Succeed())
+
+		history, err := os.ReadFile(filepath.Join(bpSession.Path, ".history"))
+		Expect(err).To(BeNil())
+		Expect(history).To(BeEmpty())
+		Expect(filepath.Join(bpSession.Path, "notes.md")).NotTo(BeAnExistingFile())
+	})
+
+	It("should list the sessions, the most recently used first", func() {
+		// a session created before metadata existed
+		oldPath := filepath.Join(sessionsDir, "old-session")
+		Expect(os.MkdirAll(oldPath, 0750)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(oldPath, ".ocenv"), []byte("CLUSTERID=old123\nCLUSTERNAME=old-cluster\n"), 0600)).To(Succeed())
+		past := time.Now().Add(-24 * time.Hour)
+		Expect(os.Chtimes(oldPath, past, past)).To(Succeed())
+
+		sessions, err := ListSessions()
+		Expect(err).To(BeNil())
+		Expect(sessions).To(HaveLen(2))
+		Expect(sessions[0].Alias).To(Equal("my-session"))
+		Expect(sessions[0].ClusterID).To(Equal("test123"))
+		Expect(sessions[0].ClusterName).To(Equal("my-cluster"))
+		Expect(sessions[1].Alias).To(Equal("old-session"))
+		Expect(sessions[1].ClusterID).To(Equal("old123"))
+		Expect(sessions[1].LastUsed.Unix()).To(Equal(past.Unix()))
+	})
+
+	It("should remove a session", func() {
+		Expect(RemoveSession("my-session")).To(Succeed())
+		Expect(bpSession.Path).NotTo(BeADirectory())
+
+		Expect(RemoveSession("my-session")).To(MatchError(ContainSubstring("does not exist")))
+		Expect(RemoveSession("..")).To(MatchError(ContainSubstring("invalid session alias")))
+		Expect(sessionsDir).To(BeADirectory())
+	})
+})
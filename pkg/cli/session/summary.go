@@ -0,0 +1,109 @@
+package session
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ansiEscapePattern matches the terminal escape sequences of the transcripts
+var ansiEscapePattern = regexp.MustCompile(`\x1b(\[[0-9;?]*[ -/]*[@-~]|\][^\x07\x1b]*(\x07|\x1b\\)|[()][0-9A-Za-z]|[=>])`)
+
+// CurrentSessionPath returns the directory of the session the shell runs in, found from its HISTFILE
+func CurrentSessionPath() (string, error) {
+	histFile := os.Getenv("HISTFILE")
+	if histFile == "" || filepath.Base(histFile) != ".history" {
+		return "", fmt.Errorf("not running in a backplane session")
+	}
+	sessionPath := filepath.Dir(histFile)
+	if _, err := os.Stat(filepath.Join(sessionPath, ".ocenv")); err != nil {
+		return "", fmt.Errorf("not running in a backplane session")
+	}
+	return sessionPath, nil
+}
+
+// ReadHistory returns the last commands of the history of the session, all of them when limit is zero
+func ReadHistory(sessionPath string, limit int) ([]string, error) {
+	content, err := os.ReadFile(filepath.Join(sessionPath, ".history"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	commands := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		command := scanner.Text()
+		// zsh extended history lines are prefixed with ': <timestamp>:<duration>;'
+		if strings.HasPrefix(command, ": ") {
+			if _, after, ok := strings.Cut(command, ";"); ok {
+				command = after
+			}
+		}
+		// bash timestamps are comment lines
+		if strings.TrimSpace(command) == "" || strings.HasPrefix(command, "#") {
+			continue
+		}
+		commands = append(commands, command)
+	}
+	if limit > 0 && len(commands) > limit {
+		commands = commands[len(commands)-limit:]
+	}
+	return commands, scanner.Err()
+}
+
+// SummarizeTranscript returns when the latest transcript of the session was recorded, how long it lasts
+// and its last output lines without the terminal escape sequences
+func SummarizeTranscript(sessionPath string, lines int) (string, error) {
+	recordings, err := listRecordings(sessionPath)
+	if err != nil {
+		return "", err
+	}
+	if len(recordings) == 0 {
+		return "", fmt.Errorf("no transcript found in session %s, record one with 'session --record'", filepath.Base(sessionPath))
+	}
+	recording := recordings[len(recordings)-1]
+
+	content, err := os.ReadFile(filepath.Clean(recording))
+	if err != nil {
+		return "", err
+	}
+	var header castHeader
+	if err := json.Unmarshal(bytes.SplitN(content, []byte("\n"), 2)[0], &header); err != nil {
+		return "", fmt.Errorf("invalid transcript header: %v", err)
+	}
+
+	// replaying without pauses measures the transcript duration
+	var output bytes.Buffer
+	var duration time.Duration
+	err = replayTranscript(bytes.NewReader(content), &output, ReplayOptions{}, func(pause time.Duration) { duration += pause })
+	if err != nil {
+		return "", err
+	}
+
+	outputLines := []string{}
+	for _, line := range strings.Split(ansiEscapePattern.ReplaceAllString(output.String(), ""), "\n") {
+		// keep what a terminal displays of the lines rewritten with carriage returns
+		if i := strings.LastIndex(strings.TrimRight(line, "\r"), "\r"); i >= 0 {
+			line = line[i+1:]
+		}
+		if line = strings.TrimRight(line, "\r \t"); line != "" {
+			outputLines = append(outputLines, line)
+		}
+	}
+	if lines > 0 && len(outputLines) > lines {
+		outputLines = outputLines[len(outputLines)-lines:]
+	}
+
+	return fmt.Sprintf("Transcript %s recorded at %s, lasting %s, last output lines:\n%s",
+		filepath.Base(recording), time.Unix(header.Timestamp, 0).UTC().Format(time.RFC3339), duration.Round(time.Second), strings.Join(outputLines, "\n")), nil
+}
@@ -0,0 +1,63 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Backplane Session summary", func() {
+	var sessionPath string
+
+	BeforeEach(func() {
+		sessionPath = GinkgoT().TempDir()
+	})
+
+	It("should find the current session from its history file", func() {
+		Expect(os.WriteFile(filepath.Join(sessionPath, ".ocenv"), []byte(""), 0600)).To(Succeed())
+		GinkgoT().Setenv("HISTFILE", filepath.Join(sessionPath, ".history"))
+
+		path, err := CurrentSessionPath()
+		Expect(err).To(BeNil())
+		Expect(path).To(Equal(sessionPath))
+
+		GinkgoT().Setenv("HISTFILE", filepath.Join(GinkgoT().TempDir(), ".bash_history"))
+		_, err = CurrentSessionPath()
+		Expect(err).To(MatchError("not running in a backplane session"))
+	})
+
+	It("should read the last commands of the bash and zsh histories", func() {
+		history := "#1700000000\noc get pods\n: 1700000001:0;oc get nodes\n\noc adm top nodes\n"
+		Expect(os.WriteFile(filepath.Join(sessionPath, ".history"), []byte(history), 0600)).To(Succeed())
+
+		commands, err := ReadHistory(sessionPath, 2)
+		Expect(err).To(BeNil())
+		Expect(commands).To(Equal([]string{"oc get nodes", "oc adm top nodes"}))
+
+		commands, err = ReadHistory(sessionPath, 0)
+		Expect(err).To(BeNil())
+		Expect(commands).To(HaveLen(3))
+	})
+
+	It("should summarize the latest transcript", func() {
+		Expect(os.MkdirAll(filepath.Join(sessionPath, recordingsDirectory), 0750)).To(Succeed())
+		transcript := `{"version":2,"width":80,"height":24,"timestamp":1700000000}
+[0.5,"o","\u001b[32m$ \u001b[0moc get nodes\r\n"]
+[2.5,"o","NAME     STATUS\r\nnode-1   Ready\r\n"]
+[64.1,"o","progress 10%\rprogress 100%\r\n"]
+`
+		Expect(os.WriteFile(filepath.Join(sessionPath, recordingsDirectory, "20231114T221320Z.cast"), []byte(transcript), 0600)).To(Succeed())
+
+		summary, err := SummarizeTranscript(sessionPath, 3)
+		Expect(err).To(BeNil())
+		Expect(summary).To(ContainSubstring("Transcript 20231114T221320Z.cast recorded at 2023-11-14T22:13:20Z, lasting 1m4s"))
+		Expect(summary).To(HaveSuffix("NAME     STATUS\nnode-1   Ready\nprogress 100%"))
+	})
+
+	It("should fail to summarize a session without transcript", func() {
+		_, err := SummarizeTranscript(sessionPath, 3)
+		Expect(err).To(MatchError(ContainSubstring("no transcript found")))
+	})
+})
@@ -0,0 +1,243 @@
+package session
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/openshift/backplane-cli/pkg/cli/config"
+	"github.com/openshift/backplane-cli/pkg/ocm"
+)
+
+const (
+	// The directory under the backplane config directory holding the session templates
+	templatesDirectory = "session-templates"
+
+	// The directory of a template holding the scripts installed in the session bin dir
+	templateBinDirectory = "bin"
+
+	// The file of a template holding the environment variables added to the session
+	templateEnvFile = "env"
+)
+
+// sessionTemplate holds the scripts and environment snippet added to every session
+type sessionTemplate struct {
+	Name string
+	// Bins maps the script names to their content
+	Bins map[string]string
+	Env  string
+}
+
+// builtinTemplate is always applied before the templates of the config directory, which can override its bins
+var builtinTemplate = sessionTemplate{
+	Name: "builtin",
+	Bins: map[string]string{
+		"ocd": "ocm describe cluster {{ .ClusterID }}",
+		"ocb": `
+#!/bin/bash
+
+set -euo pipefail
+
+`,
+	},
+}
+
+// TemplateData is the data available to the session templates.
+// The OCM lookups only happen when a template uses them.
+type TemplateData struct {
+	Alias       string
+	SessionPath string
+	ClusterID   string
+	ClusterName string
+
+	cluster           *cmv1.Cluster
+	managementID      string
+	managementName    string
+	managementFetched bool
+}
+
+// InfraID returns the infrastructure ID of the cluster
+func (d *TemplateData) InfraID() (string, error) {
+	if d.cluster == nil {
+		cluster, err := ocm.DefaultOCMInterface.GetClusterInfoByID(d.ClusterID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get the cluster %s: %v", d.ClusterID, err)
+		}
+		d.cluster = cluster
+	}
+	return d.cluster.InfraID(), nil
+}
+
+// ManagementClusterID returns the ID of the cluster managing the cluster:
+// its management cluster when it is hosted, its hive shard otherwise
+func (d *TemplateData) ManagementClusterID() (string, error) {
+	if err := d.fetchManagementCluster(); err != nil {
+		return "", err
+	}
+	return d.managementID, nil
+}
+
+// ManagementClusterName returns the name of the cluster managing the cluster
+func (d *TemplateData) ManagementClusterName() (string, error) {
+	if err := d.fetchManagementCluster(); err != nil {
+		return "", err
+	}
+	return d.managementName, nil
+}
+
+func (d *TemplateData) fetchManagementCluster() error {
+	if d.managementFetched {
+		return nil
+	}
+	id, name, _, err := ocm.DefaultOCMInterface.GetManagingCluster(d.ClusterID)
+	if err != nil {
+		return fmt.Errorf("failed to get the management cluster of %s: %v", d.ClusterID, err)
+	}
+	d.managementID, d.managementName, d.managementFetched = id, name, true
+	return nil
+}
+
+// GetTemplatesDirectory returns the directory holding the session templates
+func GetTemplatesDirectory() (string, error) {
+	configDir, err := config.GetConfigDirectory()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, templatesDirectory), nil
+}
+
+// loadTemplates reads the templates of the directory, sorted by name.
+// Only the templates of the given names are read when names is not empty.
+func loadTemplates(dir string, names []string) ([]sessionTemplate, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			entries = nil
+		} else {
+			return nil, err
+		}
+	}
+
+	found := map[string]bool{}
+	templates := []sessionTemplate{}
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		if len(names) > 0 && !slices.Contains(names, entry.Name()) {
+			continue
+		}
+		tmpl, err := loadTemplate(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("can't read session template %s: %v", entry.Name(), err)
+		}
+		found[entry.Name()] = true
+		templates = append(templates, tmpl)
+	}
+	for _, name := range names {
+		if !found[name] {
+			return nil, fmt.Errorf("session template %s not found in %s", name, dir)
+		}
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// loadTemplate reads the bins and environment snippet of the template directory
+func loadTemplate(dir string) (sessionTemplate, error) {
+	tmpl := sessionTemplate{Name: filepath.Base(dir), Bins: map[string]string{}}
+
+	bins, err := os.ReadDir(filepath.Join(dir, templateBinDirectory))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return tmpl, err
+	}
+	for _, bin := range bins {
+		if bin.IsDir() {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, templateBinDirectory, bin.Name()))
+		if err != nil {
+			return tmpl, err
+		}
+		tmpl.Bins[bin.Name()] = string(content)
+	}
+
+	env, err := os.ReadFile(filepath.Join(dir, templateEnvFile))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return tmpl, err
+	}
+	tmpl.Env = string(env)
+	return tmpl, nil
+}
+
+// renderTemplates renders the templates in order, a later template overriding the bins of an earlier one.
+// A template failing to render is skipped with a warning so it doesn't prevent the session from starting.
+func renderTemplates(templates []sessionTemplate, data *TemplateData) (bins map[string]string, env string) {
+	bins = map[string]string{}
+	envs := []string{}
+	for _, tmpl := range templates {
+		renderedBins, renderedEnv, err := renderTemplate(tmpl, data)
+		if err != nil {
+			logger.Warnf("skipping session template %s: %v", tmpl.Name, err)
+			continue
+		}
+		for name, content := range renderedBins {
+			bins[name] = content
+		}
+		if renderedEnv != "" {
+			envs = append(envs, renderedEnv)
+		}
+	}
+	return bins, strings.Join(envs, "")
+}
+
+// renderTemplate renders the bins and the environment snippet of a template
+func renderTemplate(tmpl sessionTemplate, data *TemplateData) (map[string]string, string, error) {
+	bins := map[string]string{}
+	for name, content := range tmpl.Bins {
+		rendered, err := render(name, content, data)
+		if err != nil {
+			return nil, "", err
+		}
+		bins[name] = rendered
+	}
+
+	rendered, err := render(templateEnvFile, tmpl.Env, data)
+	if err != nil {
+		return nil, "", err
+	}
+	// keep the variable assignments only, the env file is both sourced by the shell and read line by line
+	env := ""
+	for _, line := range strings.Split(rendered, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !strings.Contains(line, "=") {
+			return nil, "", fmt.Errorf("invalid env line %q, expected KEY=VALUE", line)
+		}
+		env += line + "\n"
+	}
+	return bins, env, nil
+}
+
+func render(name, content string, data *TemplateData) (string, error) {
+	t, err := template.New(name).Option("missingkey=error").Parse(content)
+	if err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	if err := t.Execute(&out, data); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
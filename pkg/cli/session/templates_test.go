@@ -0,0 +1,125 @@
+package session
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/backplane-cli/pkg/cli/globalflags"
+	"github.com/openshift/backplane-cli/pkg/ocm"
+	ocmMock "github.com/openshift/backplane-cli/pkg/ocm/mocks"
+)
+
+var _ = Describe("Backplane Session templates", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockOcmInterface *ocmMock.MockOCMInterface
+
+		templatesDir string
+		options      Options
+		bpSession    BackplaneSession
+	)
+
+	writeTemplateFile := func(name, content string) {
+		path := filepath.Join(templatesDir, name)
+		Expect(os.MkdirAll(filepath.Dir(path), 0750)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockOcmInterface = ocmMock.NewMockOCMInterface(mockCtrl)
+		ocm.DefaultOCMInterface = mockOcmInterface
+
+		home := setupTestHome()
+		templatesDir = filepath.Join(home, templatesDirectory)
+
+		options = Options{
+			Alias:       "my-session",
+			ClusterID:   "test123",
+			ClusterName: "my-cluster",
+			GlobalOpts:  &globalflags.GlobalOptions{},
+		}
+		bpSession = BackplaneSession{Options: &options}
+		Expect(bpSession.initSessionPath()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("should install the builtin bins without templates", func() {
+		Expect(bpSession.Setup()).To(Succeed())
+
+		ocd, err := os.ReadFile(filepath.Join(bpSession.Path, "bin", "ocd"))
+		Expect(err).To(BeNil())
+		Expect(string(ocd)).To(Equal("ocm describe cluster test123"))
+		Expect(filepath.Join(bpSession.Path, "bin", "ocb")).To(BeAnExistingFile())
+	})
+
+	It("should render the bins and env of every template", func() {
+		writeTemplateFile("sre/bin/mg", "#!/bin/bash\noc adm must-gather --dest-dir={{ .SessionPath }}/must-gather-{{ .ClusterName }}\n")
+		writeTemplateFile("sre/env", "# exported namespaces\nINFRA_ID={{ .InfraID }}\n\nMC={{ .ManagementClusterName }}\n")
+		writeTemplateFile("team/bin/ocd", "#!/bin/bash\nocm describe cluster {{ .ClusterID }} --json\n")
+
+		cluster, _ := cmv1.NewCluster().ID("test123").InfraID("my-cluster-abc12").Build()
+		mockOcmInterface.EXPECT().GetClusterInfoByID("test123").Return(cluster, nil).Times(1)
+		mockOcmInterface.EXPECT().GetManagingCluster("test123").Return("mc123", "my-mc", true, nil).Times(1)
+
+		Expect(bpSession.Setup()).To(Succeed())
+
+		mg, err := os.ReadFile(filepath.Join(bpSession.Path, "bin", "mg"))
+		Expect(err).To(BeNil())
+		Expect(string(mg)).To(ContainSubstring("--dest-dir=" + bpSession.Path + "/must-gather-my-cluster"))
+		stat, err := os.Stat(filepath.Join(bpSession.Path, "bin", "mg"))
+		Expect(err).To(BeNil())
+		Expect(stat.Mode().Perm()).To(Equal(os.FileMode(0700)))
+
+		ocd, err := os.ReadFile(filepath.Join(bpSession.Path, "bin", "ocd"))
+		Expect(err).To(BeNil())
+		Expect(string(ocd)).To(ContainSubstring("--json"))
+
+		env, err := readSessionEnv(bpSession.Path)
+		Expect(err).To(BeNil())
+		Expect(env).To(HaveKeyWithValue("INFRA_ID", "my-cluster-abc12"))
+		Expect(env).To(HaveKeyWithValue("MC", "my-mc"))
+		Expect(env).To(HaveKeyWithValue("CLUSTERID", "test123"))
+		Expect(env).NotTo(HaveKey("# exported namespaces"))
+	})
+
+	It("should only apply the selected templates", func() {
+		writeTemplateFile("sre/env", "TEAM=sre\n")
+		writeTemplateFile("team/env", "TEAM=team\n")
+		options.Templates = []string{"team"}
+
+		Expect(bpSession.Setup()).To(Succeed())
+
+		env, err := readSessionEnv(bpSession.Path)
+		Expect(err).To(BeNil())
+		Expect(env).To(HaveKeyWithValue("TEAM", "team"))
+
+		options.Templates = []string{"unknown"}
+		Expect(bpSession.Setup()).To(MatchError(ContainSubstring("session template unknown not found")))
+	})
+
+	It("should skip a template failing to render", func() {
+		writeTemplateFile("broken/bin/mc", "ssh {{ .ManagementClusterID }}\n")
+		writeTemplateFile("broken/env", "BROKEN=true\n")
+		writeTemplateFile("typo/env", "NAME={{ .ClusterNmae }}\n")
+		mockOcmInterface.EXPECT().GetManagingCluster("test123").Return("", "", false, errors.New("not found")).Times(1)
+
+		Expect(bpSession.Setup()).To(Succeed())
+
+		Expect(filepath.Join(bpSession.Path, "bin", "mc")).NotTo(BeAnExistingFile())
+		env, err := readSessionEnv(bpSession.Path)
+		Expect(err).To(BeNil())
+		Expect(env).NotTo(HaveKey("BROKEN"))
+		Expect(env).NotTo(HaveKey("NAME"))
+		Expect(filepath.Join(bpSession.Path, "bin", "ocd")).To(BeAnExistingFile())
+	})
+})
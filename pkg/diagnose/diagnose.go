@@ -0,0 +1,423 @@
+package diagnose
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	logger "github.com/sirupsen/logrus"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/openshift/backplane-cli/pkg/cli/config"
+	"github.com/openshift/backplane-cli/pkg/healthcheck"
+	"github.com/openshift/backplane-cli/pkg/info"
+	"github.com/openshift/backplane-cli/pkg/ocm"
+	"github.com/openshift/backplane-cli/pkg/utils"
+)
+
+const (
+	// Redacted replaces the secrets in the bundle
+	Redacted = "REDACTED"
+	// RunLogName is the file of the bundle holding the debug logs of the diagnose run,
+	// the earlier commands are not logged to disk
+	RunLogName = "diagnose-run.log"
+)
+
+var (
+	// secretKeyPattern matches the configuration keys holding secrets
+	secretKeyPattern = regexp.MustCompile(`(?i)(token|key|secret|password|credential)`)
+	// jwtPattern matches the bearer tokens, e.g. the OCM ones
+	jwtPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	// backplaneClusterPattern matches the server of the clusters logged into through backplane
+	backplaneClusterPattern = regexp.MustCompile(utils.ClusterIDRegexp)
+
+	// Seams overridden in tests
+	getConfigFilePath   = config.GetConfigFilePath
+	getBackplaneConfig  = config.GetBackplaneConfiguration
+	getKubeConfigPath   = func() string { return clientcmd.NewDefaultPathOptions().GetDefaultFilename() }
+	runHealthChecks     = func(ctx context.Context) ([]healthcheck.Result, error) { return healthcheck.RunChecks(ctx, nil, "") }
+	lookPath            = exec.LookPath
+	execCommand         = exec.CommandContext
+	containerEngineInfo = defaultContainerEngineInfo
+)
+
+// Bundle is the diagnostics support asks for, with the secrets redacted
+type Bundle struct {
+	CreatedAt time.Time
+	// Files are the content of the files of the bundle, by their name
+	Files map[string][]byte
+	// Errors are why the items which could not be collected failed, by the name of their file
+	Errors map[string]string
+
+	names   []string
+	secrets []string
+}
+
+// bundleMetadata is the content of metadata.json
+type bundleMetadata struct {
+	CreatedAt time.Time         `json:"createdAt"`
+	Files     []string          `json:"files"`
+	Errors    map[string]string `json:"errors,omitempty"`
+}
+
+// versionInfo is the content of version.json
+type versionInfo struct {
+	Version   string `json:"version"`
+	GoVersion string `json:"goVersion"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+}
+
+// kubeContext is a kubeconfig context created by backplane, without its credentials
+type kubeContext struct {
+	Name      string `json:"name"`
+	Current   bool   `json:"current"`
+	Cluster   string `json:"cluster"`
+	Server    string `json:"server"`
+	ProxyURL  string `json:"proxyURL,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	User      string `json:"user"`
+}
+
+// Collect gathers the diagnostics, keeping going past the items which cannot be collected.
+// The debug logs emitted meanwhile are part of the bundle, as the logs of the diagnose run only.
+func Collect(ctx context.Context) *Bundle {
+	b := &Bundle{
+		CreatedAt: time.Now().UTC(),
+		Files:     map[string][]byte{},
+		Errors:    map[string]string{},
+	}
+
+	logs := captureDebugLogs()
+
+	b.add("version.json", func() (interface{}, error) {
+		return versionInfo{
+			Version:   info.DefaultInfoService.GetVersion(),
+			GoVersion: runtime.Version(),
+			OS:        runtime.GOOS,
+			Arch:      runtime.GOARCH,
+		}, nil
+	})
+	b.add("config.json", b.collectConfigFile)
+	b.add("config-effective.json", b.collectEffectiveConfig)
+	b.add("ocm-environment.json", collectOCMEnvironment)
+	b.add("kubeconfig-contexts.json", collectKubeContexts)
+	b.addRaw("container-engine.txt", func() ([]byte, error) { return containerEngineInfo(ctx) })
+	b.add("healthcheck.json", func() (interface{}, error) {
+		results, err := runHealthChecks(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return struct {
+			Passed bool                 `json:"passed"`
+			Checks []healthcheck.Result `json:"checks"`
+		}{healthcheck.Passed(results), results}, nil
+	})
+
+	b.addRaw(RunLogName, func() ([]byte, error) { return logs.stop(), nil })
+
+	return b
+}
+
+// add collects a JSON file of the bundle
+func (b *Bundle) add(name string, collect func() (interface{}, error)) {
+	b.addRaw(name, func() ([]byte, error) {
+		value, err := collect()
+		if err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(value, "", "  ")
+	})
+}
+
+// addRaw collects a file of the bundle, and redacts the secrets known so far
+func (b *Bundle) addRaw(name string, collect func() ([]byte, error)) {
+	content, err := collect()
+	if err != nil {
+		logger.Debugf("Failed to collect %s: %v", name, err)
+		b.Errors[name] = b.redact(err.Error())
+		return
+	}
+	b.Files[name] = []byte(b.redact(string(content)))
+	b.names = append(b.names, name)
+}
+
+// redact replaces the known secrets and the bearer tokens of a text
+func (b *Bundle) redact(text string) string {
+	for _, secret := range b.secrets {
+		text = strings.ReplaceAll(text, secret, Redacted)
+	}
+	return jwtPattern.ReplaceAllString(text, Redacted)
+}
+
+// rememberSecrets keeps the secrets of a redacted configuration, to redact them from the other files too
+func (b *Bundle) rememberSecrets(secrets []string) {
+	for _, secret := range secrets {
+		// short values would redact too much, e.g. booleans
+		if len(secret) >= 6 {
+			b.secrets = append(b.secrets, secret)
+		}
+	}
+}
+
+// collectConfigFile returns the config file, with its secrets redacted
+func (b *Bundle) collectConfigFile() (interface{}, error) {
+	path, err := getConfigFilePath()
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(path) //#nosec G304
+	if err != nil {
+		return nil, err
+	}
+	var configFile interface{}
+	if err := json.Unmarshal(content, &configFile); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %v", path, err)
+	}
+	redacted, secrets := RedactValue("", configFile)
+	b.rememberSecrets(secrets)
+	return map[string]interface{}{"path": path, "content": redacted}, nil
+}
+
+// collectEffectiveConfig returns the configuration in use, along with the default and the OCM provided values
+func (b *Bundle) collectEffectiveConfig() (interface{}, error) {
+	bpConfig, err := getBackplaneConfig()
+	if err != nil {
+		return nil, err
+	}
+	content, err := json.Marshal(bpConfig)
+	if err != nil {
+		return nil, err
+	}
+	var effective interface{}
+	if err := json.Unmarshal(content, &effective); err != nil {
+		return nil, err
+	}
+	redacted, secrets := RedactValue("", effective)
+	b.rememberSecrets(secrets)
+	return redacted, nil
+}
+
+func collectOCMEnvironment() (interface{}, error) {
+	env, err := ocm.DefaultOCMInterface.GetOCMEnvironment()
+	if err != nil {
+		return nil, err
+	}
+	var content bytes.Buffer
+	if err := cmv1.MarshalEnvironment(env, &content); err != nil {
+		return nil, err
+	}
+	return json.RawMessage(content.Bytes()), nil
+}
+
+// collectKubeContexts returns the kubeconfig contexts of the clusters logged into through backplane
+func collectKubeContexts() (interface{}, error) {
+	kubeConfig, err := clientcmd.LoadFromFile(getKubeConfigPath())
+	if err != nil {
+		return nil, err
+	}
+
+	contexts := []kubeContext{}
+	for name, kubeCtx := range kubeConfig.Contexts {
+		cluster, ok := kubeConfig.Clusters[kubeCtx.Cluster]
+		if !ok || !backplaneClusterPattern.MatchString(cluster.Server) {
+			continue
+		}
+		contexts = append(contexts, kubeContext{
+			Name:      name,
+			Current:   name == kubeConfig.CurrentContext,
+			Cluster:   kubeCtx.Cluster,
+			Server:    cluster.Server,
+			ProxyURL:  redactURL(cluster.ProxyURL),
+			Namespace: kubeCtx.Namespace,
+			User:      kubeCtx.AuthInfo,
+		})
+	}
+	return contexts, nil
+}
+
+// defaultContainerEngineInfo returns the version and the info of the container engine the console would use
+func defaultContainerEngineInfo(ctx context.Context) ([]byte, error) {
+	engines := []string{"podman", "docker"}
+	if engine, ok := os.LookupEnv(healthcheck.EnvContainerEngine); ok && engine != "" {
+		engines = []string{engine}
+	}
+
+	for _, engine := range engines {
+		if _, err := lookPath(engine); err != nil {
+			continue
+		}
+		var output bytes.Buffer
+		for _, args := range [][]string{{"version"}, {"info"}} {
+			cmdCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+			out, err := execCommand(cmdCtx, engine, args...).CombinedOutput()
+			cancel()
+			fmt.Fprintf(&output, "$ %s %s\n%s\n", engine, strings.Join(args, " "), out)
+			if err != nil {
+				fmt.Fprintf(&output, "error: %v\n\n", err)
+			}
+		}
+		return output.Bytes(), nil
+	}
+	return nil, fmt.Errorf("cannot find %s in PATH", strings.Join(engines, " or "))
+}
+
+// RedactValue redacts the values of the secret keys of a decoded JSON value, and the passwords of its URLs.
+// It returns the redacted value along with the secrets it redacted.
+func RedactValue(key string, value interface{}) (interface{}, []string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redacted := map[string]interface{}{}
+		secrets := []string{}
+		for k, item := range v {
+			redactedItem, itemSecrets := RedactValue(k, item)
+			redacted[k] = redactedItem
+			secrets = append(secrets, itemSecrets...)
+		}
+		return redacted, secrets
+	case []interface{}:
+		redacted := []interface{}{}
+		secrets := []string{}
+		for _, item := range v {
+			redactedItem, itemSecrets := RedactValue(key, item)
+			redacted = append(redacted, redactedItem)
+			secrets = append(secrets, itemSecrets...)
+		}
+		return redacted, secrets
+	case string:
+		if v != "" && secretKeyPattern.MatchString(key) {
+			return Redacted, []string{v}
+		}
+		return redactURL(v), nil
+	default:
+		return v, nil
+	}
+}
+
+// redactURL redacts the password of a URL, e.g. of a proxy
+func redactURL(value string) string {
+	u, err := url.Parse(value)
+	if err != nil || u.User == nil {
+		return value
+	}
+	if _, hasPassword := u.User.Password(); hasPassword {
+		u.User = url.UserPassword(u.User.Username(), Redacted)
+	}
+	return u.String()
+}
+
+// WriteArchive writes the bundle as a gzipped tarball, along with a metadata.json listing its files and errors
+func (b *Bundle) WriteArchive(w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	metadata, err := json.MarshalIndent(bundleMetadata{CreatedAt: b.CreatedAt, Files: b.names, Errors: b.Errors}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeTarFile(tw, "metadata.json", metadata, b.CreatedAt); err != nil {
+		return err
+	}
+	for _, name := range b.names {
+		if err := writeTarFile(tw, name, b.Files[name], b.CreatedAt); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// Names returns the names of the collected files, in collection order
+func (b *Bundle) Names() []string {
+	return append([]string{}, b.names...)
+}
+
+// writeTarFile adds a regular file to the tarball
+func writeTarFile(tw *tar.Writer, name string, content []byte, modTime time.Time) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(content)),
+		ModTime: modTime,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := tw.Write(content)
+	return err
+}
+
+// debugLogCapture keeps the debug logs, while the terminal still gets the logs of the configured level only
+type debugLogCapture struct {
+	mu        sync.Mutex
+	buffer    bytes.Buffer
+	out       io.Writer
+	level     logger.Level
+	formatter logger.Formatter
+	hooks     logger.LevelHooks
+}
+
+func (c *debugLogCapture) Levels() []logger.Level {
+	return logger.AllLevels
+}
+
+func (c *debugLogCapture) Fire(entry *logger.Entry) error {
+	line, err := c.formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.buffer.Write(line)
+	if entry.Level <= c.level {
+		_, _ = c.out.Write(line)
+	}
+	return nil
+}
+
+// captureDebugLogs starts capturing the debug logs of the standard logger
+func captureDebugLogs() *debugLogCapture {
+	std := logger.StandardLogger()
+	capture := &debugLogCapture{
+		out:       std.Out,
+		level:     std.GetLevel(),
+		formatter: &logger.TextFormatter{DisableColors: true, FullTimestamp: true},
+		hooks:     logger.LevelHooks{},
+	}
+	for level, hooks := range std.Hooks {
+		capture.hooks[level] = hooks
+	}
+
+	std.SetOutput(io.Discard)
+	std.SetLevel(logger.DebugLevel)
+	std.AddHook(capture)
+	return capture
+}
+
+// stop restores the standard logger and returns the captured logs
+func (c *debugLogCapture) stop() []byte {
+	std := logger.StandardLogger()
+	std.ReplaceHooks(c.hooks)
+	std.SetLevel(c.level)
+	std.SetOutput(c.out)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]byte{}, c.buffer.Bytes()...)
+}
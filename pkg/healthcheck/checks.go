@@ -0,0 +1,306 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift/backplane-cli/internal/github"
+	"github.com/openshift/backplane-cli/pkg/cli/config"
+	"github.com/openshift/backplane-cli/pkg/ocm"
+	"github.com/openshift/backplane-cli/pkg/utils"
+)
+
+const (
+	CheckVPN             = "vpn"
+	CheckProxy           = "proxy"
+	CheckBackplaneDNS    = "backplane-dns"
+	CheckBackplaneAPI    = "backplane-api"
+	CheckOCMToken        = "ocm-token"
+	CheckGitHub          = "github"
+	CheckContainerEngine = "container-engine"
+	CheckOCVersion       = "oc-version"
+	CheckOCMVersion      = "ocm-version"
+
+	// EnvContainerEngine is the container engine to use for the console, as for the console command
+	EnvContainerEngine = "CONTAINER_ENGINE"
+
+	OutputTable = "table"
+	OutputJSON  = "json"
+)
+
+var (
+	// LookPathFunc and ExecCommandFunc find and run the command line tools, overridden in tests
+	LookPathFunc    = exec.LookPath
+	ExecCommandFunc = exec.CommandContext
+	// LookupCNAMEFunc resolves the canonical name of the backplane host, overridden in tests
+	LookupCNAMEFunc = net.DefaultResolver.LookupCNAME
+	// GetLatestReleaseFunc fetches the latest release of backplane-cli, overridden in tests
+	GetLatestReleaseFunc = func(ctx context.Context) (string, error) {
+		release, err := github.NewClient().GetLatestVersion(ctx)
+		return release.TagName, err
+	}
+)
+
+// NewDefaultRegistry returns the registry of the health checks of the local environment
+func NewDefaultRegistry() *Registry {
+	return newRegistry("")
+}
+
+// NewClusterRegistry returns the registry of the health checks of the local environment,
+// along with the checks of each hop of the path to the given cluster
+func NewClusterRegistry(clusterKey string) *Registry {
+	return newRegistry(clusterKey)
+}
+
+func newRegistry(clusterKey string) *Registry {
+	// the backplane API and the clusters are reached through the proxy found working by the proxy check
+	proxyURL := ""
+
+	checks := []Check{
+		{
+			Name:        CheckVPN,
+			Description: "VPN interface is up and the internal VPN check endpoint is reachable",
+			Hint:        "Connect to the VPN, and check 'vpn-check-endpoint' in the config file",
+			Run: func(ctx context.Context) (string, error) {
+				return "", CheckVPNConnectivity(NetInterfaces, HTTPClients)
+			},
+		},
+		{
+			Name:        CheckProxy,
+			Description: "The configured proxy reaches the proxy check endpoint",
+			DependsOn:   []string{CheckVPN},
+			Hint:        "Check 'proxy-url' and 'proxy-check-endpoint' in the config file, or the HTTPS_PROXY environment variable",
+			Run: func(ctx context.Context) (string, error) {
+				var err error
+				proxyURL, err = CheckProxyConnectivity(HTTPClients)
+				return proxyURL, err
+			},
+		},
+		{
+			Name:        CheckBackplaneDNS,
+			Description: "The backplane API host name resolves",
+			Timeout:     5 * time.Second,
+			Hint:        "Check the DNS servers in use, the backplane API host name is resolved by the VPN ones",
+			Run:         checkBackplaneDNS,
+		},
+		{
+			Name:        CheckBackplaneAPI,
+			Description: "The backplane API is reachable through the proxy",
+			DependsOn:   []string{CheckProxy, CheckBackplaneDNS},
+			Timeout:     30 * time.Second,
+			Hint:        "Check the status of the backplane API, and 'url' in the config file or the BACKPLANE_URL environment variable",
+			Run: func(ctx context.Context) (string, error) {
+				return "", CheckBackplaneAPIConnectivity(HTTPClients, proxyURL)
+			},
+		},
+		{
+			Name:        CheckOCMToken,
+			Description: "The OCM token is valid",
+			Hint:        "Log into OCM again with 'ocm login --use-auth-code --url $ENV'",
+			Run:         checkOCMToken,
+		},
+		{
+			Name:        CheckGitHub,
+			Description: "GitHub is reachable to check for and download upgrades",
+			Hint:        "'ocm backplane upgrade' needs to reach api.github.com, check the network access to GitHub",
+			Run: func(ctx context.Context) (string, error) {
+				latest, err := GetLatestReleaseFunc(ctx)
+				if err != nil {
+					return "", fmt.Errorf("failed to fetch the latest release: %v", err)
+				}
+				return fmt.Sprintf("latest release %s", latest), nil
+			},
+		},
+		{
+			Name:        CheckContainerEngine,
+			Description: "A container engine is available to run the console",
+			Hint:        "'ocm backplane console' needs podman or docker: install one, start its machine or daemon, or set CONTAINER_ENGINE",
+			Run:         checkContainerEngine,
+		},
+		{
+			Name:        CheckOCVersion,
+			Description: "The OpenShift CLI is installed",
+			Hint:        "Install oc from https://mirror.openshift.com/pub/openshift-v4/clients/ocp/stable/ and add it to the PATH",
+			Run: func(ctx context.Context) (string, error) {
+				return commandVersion(ctx, "oc", "version", "--client")
+			},
+		},
+		{
+			Name:        CheckOCMVersion,
+			Description: "The OCM CLI is installed",
+			Hint:        "Install ocm from https://github.com/openshift-online/ocm-cli/releases and add it to the PATH",
+			Run: func(ctx context.Context) (string, error) {
+				return commandVersion(ctx, "ocm", "version")
+			},
+		},
+	}
+	if clusterKey != "" {
+		checks = append(checks, clusterChecks(clusterKey, &proxyURL)...)
+	}
+
+	registry := NewRegistry()
+	for _, check := range checks {
+		if err := registry.Register(check); err != nil {
+			// the default checks are registered in dependency order
+			panic(err)
+		}
+	}
+	return registry
+}
+
+// RunChecks runs the given checks of the default registry, all of them when none is given.
+// With a cluster, the checks of the path to the cluster run by default, along with the checks they depend on.
+// The backplane configuration is loaded once for all the checks running in parallel.
+func RunChecks(ctx context.Context, names []string, clusterKey string) ([]Result, error) {
+	getConfig := GetConfigFunc
+	defer func() { GetConfigFunc = getConfig }()
+
+	var once sync.Once
+	var bpConfig config.BackplaneConfiguration
+	var configErr error
+	GetConfigFunc = func() (config.BackplaneConfiguration, error) {
+		once.Do(func() { bpConfig, configErr = getConfig() })
+		return bpConfig, configErr
+	}
+
+	if clusterKey != "" && len(names) == 0 {
+		names = ClusterCheckNames
+	}
+	return newRegistry(clusterKey).Run(ctx, names)
+}
+
+// checkBackplaneDNS resolves the canonical name of the backplane API host
+func checkBackplaneDNS(ctx context.Context) (string, error) {
+	bpConfig, err := GetConfigFunc()
+	if err != nil {
+		return "", fmt.Errorf("failed to get backplane configuration: %v", err)
+	}
+	backplaneURL, err := url.Parse(bpConfig.URL)
+	if err != nil || backplaneURL.Hostname() == "" {
+		return "", fmt.Errorf("invalid backplane URL '%s'", bpConfig.URL)
+	}
+
+	cname, err := LookupCNAMEFunc(ctx, backplaneURL.Hostname())
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %v", backplaneURL.Hostname(), err)
+	}
+	return fmt.Sprintf("%s -> %s", backplaneURL.Hostname(), strings.TrimSuffix(cname, ".")), nil
+}
+
+// checkOCMToken makes sure an OCM token can be obtained, and tells when it expires
+func checkOCMToken(ctx context.Context) (string, error) {
+	token, err := ocm.DefaultOCMInterface.GetOCMAccessToken()
+	if err != nil {
+		return "", err
+	}
+	if token == nil || *token == "" {
+		return "", fmt.Errorf("empty OCM token")
+	}
+
+	expiry, err := utils.GetExpiryFromJWT(*token)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the expiry of the OCM token: %v", err)
+	}
+	if time.Now().After(expiry) {
+		return "", fmt.Errorf("the OCM token expired at %s", expiry.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("token of %s valid until %s", utils.GetUsernameFromJWT(*token), expiry.Format(time.RFC3339)), nil
+}
+
+// checkContainerEngine makes sure the container engine the console would use answers
+func checkContainerEngine(ctx context.Context) (string, error) {
+	engines := []string{"podman", "docker"}
+	if engine, ok := os.LookupEnv(EnvContainerEngine); ok && engine != "" {
+		engines = []string{engine}
+	}
+
+	for _, engine := range engines {
+		if _, err := LookPathFunc(engine); err != nil {
+			continue
+		}
+		version, err := commandVersion(ctx, engine, "version", "--format", "{{.Client.Version}}")
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s %s", engine, version), nil
+	}
+	return "", fmt.Errorf("cannot find %s in PATH", strings.Join(engines, " or "))
+}
+
+// commandVersion returns the first line printed by the version command of a tool
+func commandVersion(ctx context.Context, name string, args ...string) (string, error) {
+	if _, err := LookPathFunc(name); err != nil {
+		return "", fmt.Errorf("cannot find %s in PATH", name)
+	}
+	output, err := ExecCommandFunc(ctx, name, args...).CombinedOutput()
+	firstLine := strings.TrimSpace(strings.SplitN(strings.TrimSpace(string(output)), "\n", 2)[0])
+	if err != nil {
+		if firstLine != "" {
+			return "", fmt.Errorf("'%s %s' failed: %v: %s", name, strings.Join(args, " "), err, firstLine)
+		}
+		return "", fmt.Errorf("'%s %s' failed: %v", name, strings.Join(args, " "), err)
+	}
+	return firstLine, nil
+}
+
+// Passed tells whether every check passed
+func Passed(results []Result) bool {
+	for _, result := range results {
+		if result.Status != StatusPassed {
+			return false
+		}
+	}
+	return true
+}
+
+// PrintResults prints the results as a table along with the hints of the failed checks, or as JSON
+func PrintResults(results []Result, output string) error {
+	switch output {
+	case OutputJSON:
+		report, err := json.MarshalIndent(struct {
+			Passed bool     `json:"passed"`
+			Checks []Result `json:"checks"`
+		}{Passed(results), results}, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(report))
+		return nil
+	case OutputTable, "":
+		headers := []string{"CHECK", "STATUS", "LATENCY", "DETAIL"}
+		rows := [][]string{}
+		for _, result := range results {
+			detail := result.Detail
+			if result.Error != "" {
+				detail = result.Error
+			}
+			latency := "-"
+			if result.Status != StatusSkipped {
+				latency = fmt.Sprintf("%dms", result.LatencyMs)
+			}
+			rows = append(rows, []string{result.Name, strings.ToUpper(string(result.Status)), latency, detail})
+		}
+		utils.RenderTable(headers, rows)
+
+		hints := []string{}
+		for _, result := range results {
+			if result.Status == StatusFailed && result.Hint != "" {
+				hints = append(hints, fmt.Sprintf("  %s: %s", result.Name, result.Hint))
+			}
+		}
+		if len(hints) > 0 {
+			fmt.Printf("\nHints:\n%s\n", strings.Join(hints, "\n"))
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %s, expected one of %s|%s", output, OutputTable, OutputJSON)
+	}
+}
@@ -0,0 +1,233 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	acctrspv1 "github.com/openshift-online/ocm-sdk-go/accesstransparency/v1"
+	BackplaneApi "github.com/openshift/backplane-api/pkg/client"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openshift/backplane-cli/pkg/accessrequest"
+	"github.com/openshift/backplane-cli/pkg/backplaneapi"
+	"github.com/openshift/backplane-cli/pkg/ocm"
+	"github.com/openshift/backplane-cli/pkg/utils"
+)
+
+const (
+	CheckClusterResolve           = "cluster-resolve"
+	CheckClusterHibernation       = "cluster-hibernation"
+	CheckClusterAccessProtection  = "cluster-access-protection"
+	CheckClusterLogin             = "cluster-login"
+	CheckClusterProxy             = "cluster-proxy"
+	CheckClusterSelfSubjectReview = "cluster-self-subject-review"
+)
+
+var (
+	// ClusterCheckNames are the checks of each hop of the path to a cluster
+	ClusterCheckNames = []string{
+		CheckClusterResolve,
+		CheckClusterHibernation,
+		CheckClusterAccessProtection,
+		CheckClusterLogin,
+		CheckClusterProxy,
+		CheckClusterSelfSubjectReview,
+	}
+
+	// NewKubeClientFunc creates the client of the cluster behind the backplane proxy, overridden in tests
+	NewKubeClientFunc = func(config *rest.Config) (kubernetes.Interface, error) {
+		return kubernetes.NewForConfig(config)
+	}
+)
+
+// clusterPath is what the checks of the path to a cluster learn hop after hop
+type clusterPath struct {
+	clusterKey  string
+	clusterID   string
+	accessToken string
+	// clusterURL is the backplane proxy URL of the cluster
+	clusterURL string
+	// proxyURL is the working proxy found by the proxy check
+	proxyURL *string
+}
+
+// clusterChecks returns the checks of each hop of the path to the cluster, which run one after the other
+func clusterChecks(clusterKey string, proxyURL *string) []Check {
+	path := &clusterPath{clusterKey: clusterKey, proxyURL: proxyURL}
+	return []Check{
+		{
+			Name:        CheckClusterResolve,
+			Description: "OCM resolves the cluster ID, external ID or name",
+			DependsOn:   []string{CheckOCMToken},
+			Hint:        "Check the cluster ID, external ID or name, and that the cluster belongs to the current OCM environment",
+			Run:         path.resolve,
+		},
+		{
+			Name:        CheckClusterHibernation,
+			Description: "The cluster is not hibernating",
+			DependsOn:   []string{CheckClusterResolve},
+			Hint:        fmt.Sprintf("Resume the cluster first, see 'ocm describe cluster %s'", clusterKey),
+			Run:         path.checkHibernation,
+		},
+		{
+			Name:        CheckClusterAccessProtection,
+			Description: "An approved access request grants access to the cluster when its access protection is enabled",
+			DependsOn:   []string{CheckClusterResolve},
+			Hint:        fmt.Sprintf("Create an access request and wait for its approval with 'ocm backplane accessrequest create --cluster-id %s --wait'", clusterKey),
+			Run:         path.checkAccessProtection,
+		},
+		{
+			Name:        CheckClusterLogin,
+			Description: "The backplane API returns the proxy URL of the cluster",
+			DependsOn:   []string{CheckBackplaneAPI, CheckClusterHibernation, CheckClusterAccessProtection},
+			Timeout:     30 * time.Second,
+			Hint:        "Check that the cluster is managed by backplane and that your OCM account has access to it",
+			Run:         path.login,
+		},
+		{
+			Name:        CheckClusterProxy,
+			Description: "The cluster API answers through the backplane proxy URL",
+			DependsOn:   []string{CheckClusterLogin},
+			Timeout:     30 * time.Second,
+			Hint:        "The backplane proxy cannot reach the cluster API, check the status of the cluster and of its API server",
+			Run:         path.checkProxy,
+		},
+		{
+			Name:        CheckClusterSelfSubjectReview,
+			Description: "The cluster authenticates the OCM token",
+			DependsOn:   []string{CheckClusterProxy},
+			Timeout:     30 * time.Second,
+			Hint:        "The cluster does not authenticate you, check the backplane configuration of the cluster",
+			Run:         path.selfSubjectReview,
+		},
+	}
+}
+
+// resolve finds the cluster in OCM
+func (p *clusterPath) resolve(ctx context.Context) (string, error) {
+	clusterID, clusterName, err := ocm.DefaultOCMInterface.GetTargetCluster(p.clusterKey)
+	if err != nil {
+		return "", err
+	}
+	p.clusterID = clusterID
+	return fmt.Sprintf("%s (%s)", clusterID, clusterName), nil
+}
+
+func (p *clusterPath) checkHibernation(ctx context.Context) (string, error) {
+	isHibernating, err := ocm.DefaultOCMInterface.IsClusterHibernating(p.clusterID)
+	if err != nil {
+		return "", fmt.Errorf("failed to check if the cluster is hibernating: %v", err)
+	}
+	if isHibernating {
+		return "", fmt.Errorf("cluster %s is hibernating", p.clusterID)
+	}
+	return "not hibernating", nil
+}
+
+func (p *clusterPath) checkAccessProtection(ctx context.Context) (string, error) {
+	ocmConnection, err := ocm.DefaultOCMInterface.SetupOCMConnection()
+	if err != nil {
+		return "", fmt.Errorf("failed to create OCM connection: %v", err)
+	}
+	isEnabled, err := ocm.DefaultOCMInterface.IsClusterAccessProtectionEnabled(ocmConnection, p.clusterID)
+	if err != nil {
+		return "", fmt.Errorf("unable to determine if access protection is enabled: %v", err)
+	}
+	if !isEnabled {
+		return "access protection disabled", nil
+	}
+
+	accessRequest, err := ocm.DefaultOCMInterface.GetClusterActiveAccessRequest(ocmConnection, p.clusterID)
+	if err != nil {
+		return "", fmt.Errorf("unable to retrieve the active access request: %v", err)
+	}
+	if accessRequest == nil {
+		return "", fmt.Errorf("access protection is enabled and there is no access request")
+	}
+	state := accessrequest.GetAccessRequestState(accessRequest)
+	if state != acctrspv1.AccessRequestStateApproved {
+		return "", fmt.Errorf("access protection is enabled and access request '%s' is %s", accessRequest.ID(), strings.ToLower(string(state)))
+	}
+	return fmt.Sprintf("access request '%s' approved until %s", accessRequest.ID(), accessRequest.Status().ExpiresAt().Format(time.RFC3339)), nil
+}
+
+// login asks the backplane API for the proxy URL of the cluster
+func (p *clusterPath) login(ctx context.Context) (string, error) {
+	bpConfig, err := GetConfigFunc()
+	if err != nil {
+		return "", fmt.Errorf("failed to get backplane configuration: %v", err)
+	}
+	accessToken, err := ocm.DefaultOCMInterface.GetOCMAccessToken()
+	if err != nil {
+		return "", err
+	}
+	p.accessToken = *accessToken
+
+	client, err := backplaneapi.DefaultClientUtils.MakeRawBackplaneAPIClientWithAccessToken(bpConfig.URL, p.accessToken)
+	if err != nil {
+		return "", fmt.Errorf("unable to create backplane api client: %v", err)
+	}
+	resp, err := client.LoginCluster(ctx, p.clusterID)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", utils.TryPrintAPIError(resp, false)
+	}
+	loginResp, err := BackplaneApi.ParseLoginClusterResponse(resp)
+	if err != nil || loginResp.JSON200 == nil || loginResp.JSON200.ProxyUri == nil {
+		return "", fmt.Errorf("unable to parse response body from backplane: \n Status Code: %d", resp.StatusCode)
+	}
+
+	p.clusterURL = bpConfig.URL + *loginResp.JSON200.ProxyUri
+	return p.clusterURL, nil
+}
+
+// kubeClient returns the client of the cluster behind the backplane proxy, through the working proxy
+func (p *clusterPath) kubeClient() (kubernetes.Interface, error) {
+	config := &rest.Config{
+		Host:        p.clusterURL,
+		BearerToken: p.accessToken,
+	}
+	if p.proxyURL != nil && *p.proxyURL != "" {
+		proxyURL, err := url.Parse(*p.proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %v", err)
+		}
+		config.Proxy = http.ProxyURL(proxyURL)
+	}
+	return NewKubeClientFunc(config)
+}
+
+// checkProxy gets the version of the cluster through the backplane proxy URL
+func (p *clusterPath) checkProxy(ctx context.Context) (string, error) {
+	client, err := p.kubeClient()
+	if err != nil {
+		return "", err
+	}
+	version, err := client.Discovery().ServerVersion()
+	if err != nil {
+		return "", fmt.Errorf("failed to get the version of the cluster through %s: %v", p.clusterURL, err)
+	}
+	return fmt.Sprintf("Kubernetes %s", version.GitVersion), nil
+}
+
+// selfSubjectReview makes sure the cluster authenticates the OCM token
+func (p *clusterPath) selfSubjectReview(ctx context.Context) (string, error) {
+	client, err := p.kubeClient()
+	if err != nil {
+		return "", err
+	}
+	review, err := client.AuthenticationV1().SelfSubjectReviews().Create(ctx, &authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to create a SelfSubjectReview: %v", err)
+	}
+	return fmt.Sprintf("authenticated as %s", review.Status.UserInfo.Username), nil
+}
@@ -0,0 +1,208 @@
+package healthcheck_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	acctrspv1 "github.com/openshift-online/ocm-sdk-go/accesstransparency/v1"
+	"go.uber.org/mock/gomock"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/openshift/backplane-cli/pkg/backplaneapi"
+	backplaneapiMock "github.com/openshift/backplane-cli/pkg/backplaneapi/mocks"
+	"github.com/openshift/backplane-cli/pkg/cli/config"
+	"github.com/openshift/backplane-cli/pkg/client/mocks"
+	"github.com/openshift/backplane-cli/pkg/healthcheck"
+	healthcheckMock "github.com/openshift/backplane-cli/pkg/healthcheck/mocks"
+	"github.com/openshift/backplane-cli/pkg/ocm"
+	ocmMock "github.com/openshift/backplane-cli/pkg/ocm/mocks"
+)
+
+var _ = Describe("Cluster health checks", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockInterfaces   *healthcheckMock.MockNetworkInterface
+		mockHTTPClient   *healthcheckMock.MockHTTPClient
+		mockOcmInterface *ocmMock.MockOCMInterface
+		mockClientUtil   *backplaneapiMock.MockClientUtils
+		mockClient       *mocks.MockClientInterface
+
+		proxyServer *httptest.Server
+		restConfig  *rest.Config
+
+		originalGetConfigFunc            func() (config.BackplaneConfiguration, error)
+		originalGetVPNCheckEndpointFunc  func() (string, error)
+		originalGetProxyTestEndpointFunc func() (string, error)
+		originalLookupCNAMEFunc          func(ctx context.Context, host string) (string, error)
+		originalNewKubeClientFunc        func(config *rest.Config) (kubernetes.Interface, error)
+		originalDefaultTransport         http.RoundTripper
+
+		clusterID   = "1a2b3c4d5e6f7g8h9i0j1k2l3m4n5o6p"
+		accessToken string
+	)
+
+	resultsByName := func(results []healthcheck.Result) map[string]healthcheck.Result {
+		byName := map[string]healthcheck.Result{}
+		for _, result := range results {
+			byName[result.Name] = result
+		}
+		return byName
+	}
+
+	BeforeEach(func() {
+		var err error
+		accessToken, err = jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"username": "jdoe",
+			"exp":      time.Now().Add(time.Hour).Unix(),
+		}).SignedString([]byte("secret"))
+		Expect(err).To(BeNil())
+
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockInterfaces = healthcheckMock.NewMockNetworkInterface(mockCtrl)
+		mockHTTPClient = healthcheckMock.NewMockHTTPClient(mockCtrl)
+		mockOcmInterface = ocmMock.NewMockOCMInterface(mockCtrl)
+		mockClientUtil = backplaneapiMock.NewMockClientUtils(mockCtrl)
+		mockClient = mocks.NewMockClientInterface(mockCtrl)
+		healthcheck.NetInterfaces = mockInterfaces
+		healthcheck.HTTPClients = mockHTTPClient
+		ocm.DefaultOCMInterface = mockOcmInterface
+		backplaneapi.DefaultClientUtils = mockClientUtil
+
+		originalGetConfigFunc = healthcheck.GetConfigFunc
+		originalGetVPNCheckEndpointFunc = healthcheck.GetVPNCheckEndpointFunc
+		originalGetProxyTestEndpointFunc = healthcheck.GetProxyTestEndpointFunc
+		originalLookupCNAMEFunc = healthcheck.LookupCNAMEFunc
+		originalNewKubeClientFunc = healthcheck.NewKubeClientFunc
+		originalDefaultTransport = http.DefaultTransport
+
+		// the proxy answers every request it forwards
+		proxyServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		proxyURL := proxyServer.URL
+		healthcheck.GetConfigFunc = func() (config.BackplaneConfiguration, error) {
+			return config.BackplaneConfiguration{URL: "http://api.backplane.example.com", ProxyURL: &proxyURL}, nil
+		}
+		healthcheck.GetVPNCheckEndpointFunc = func() (string, error) { return "http://vpn.example.com", nil }
+		healthcheck.GetProxyTestEndpointFunc = func() (string, error) { return "http://proxy-check.example.com", nil }
+		healthcheck.LookupCNAMEFunc = func(ctx context.Context, host string) (string, error) { return "elb.example.com.", nil }
+
+		mockInterfaces.EXPECT().Interfaces().Return([]net.Interface{{Name: "tun0"}}, nil).AnyTimes()
+		mockHTTPClient.EXPECT().Get(gomock.Any()).Return(&http.Response{StatusCode: http.StatusOK}, nil).AnyTimes()
+		mockOcmInterface.EXPECT().GetOCMAccessToken().Return(&accessToken, nil).AnyTimes()
+		mockOcmInterface.EXPECT().GetTargetCluster("my-cluster").Return(clusterID, "my-cluster", nil).AnyTimes()
+
+		restConfig = nil
+		healthcheck.NewKubeClientFunc = func(config *rest.Config) (kubernetes.Interface, error) {
+			restConfig = config
+			client := fake.NewSimpleClientset()
+			client.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: "v1.29.5"}
+			client.PrependReactor("create", "selfsubjectreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, &authenticationv1.SelfSubjectReview{Status: authenticationv1.SelfSubjectReviewStatus{
+					UserInfo: authenticationv1.UserInfo{Username: "system:serviceaccount:backplane:jdoe"},
+				}}, nil
+			})
+			return client, nil
+		}
+	})
+
+	AfterEach(func() {
+		healthcheck.GetConfigFunc = originalGetConfigFunc
+		healthcheck.GetVPNCheckEndpointFunc = originalGetVPNCheckEndpointFunc
+		healthcheck.GetProxyTestEndpointFunc = originalGetProxyTestEndpointFunc
+		healthcheck.LookupCNAMEFunc = originalLookupCNAMEFunc
+		healthcheck.NewKubeClientFunc = originalNewKubeClientFunc
+		http.DefaultTransport = originalDefaultTransport
+		proxyServer.Close()
+		mockCtrl.Finish()
+	})
+
+	It("should check every hop of the path to the cluster", func() {
+		mockOcmInterface.EXPECT().IsClusterHibernating(clusterID).Return(false, nil)
+		mockOcmInterface.EXPECT().SetupOCMConnection().Return(nil, nil)
+		mockOcmInterface.EXPECT().IsClusterAccessProtectionEnabled(nil, clusterID).Return(false, nil)
+		mockClientUtil.EXPECT().MakeRawBackplaneAPIClientWithAccessToken("http://api.backplane.example.com", accessToken).Return(mockClient, nil)
+		mockClient.EXPECT().LoginCluster(gomock.Any(), clusterID).Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"proxy_uri":"/backplane/cluster/` + clusterID + `"}`)),
+		}, nil)
+
+		results, err := healthcheck.RunChecks(context.Background(), nil, "my-cluster")
+		Expect(err).To(BeNil())
+		Expect(healthcheck.Passed(results)).To(BeTrue(), "%+v", results)
+
+		byName := resultsByName(results)
+		Expect(byName).To(HaveLen(11))
+		Expect(byName[healthcheck.CheckClusterResolve].Detail).To(Equal(clusterID + " (my-cluster)"))
+		Expect(byName[healthcheck.CheckClusterLogin].Detail).To(Equal("http://api.backplane.example.com/backplane/cluster/" + clusterID))
+		Expect(byName[healthcheck.CheckClusterProxy].Detail).To(Equal("Kubernetes v1.29.5"))
+		Expect(byName[healthcheck.CheckClusterSelfSubjectReview].Detail).To(Equal("authenticated as system:serviceaccount:backplane:jdoe"))
+		Expect(byName).NotTo(HaveKey(healthcheck.CheckGitHub))
+
+		Expect(restConfig.Host).To(Equal("http://api.backplane.example.com/backplane/cluster/" + clusterID))
+		Expect(restConfig.BearerToken).To(Equal(accessToken))
+		Expect(restConfig.Proxy).NotTo(BeNil())
+	})
+
+	It("should report the hibernating cluster and skip the following hops", func() {
+		mockOcmInterface.EXPECT().IsClusterHibernating(clusterID).Return(true, nil)
+		mockOcmInterface.EXPECT().SetupOCMConnection().Return(nil, nil)
+		mockOcmInterface.EXPECT().IsClusterAccessProtectionEnabled(nil, clusterID).Return(false, nil)
+
+		results, err := healthcheck.RunChecks(context.Background(), nil, "my-cluster")
+		Expect(err).To(BeNil())
+
+		byName := resultsByName(results)
+		Expect(byName[healthcheck.CheckClusterHibernation].Status).To(Equal(healthcheck.StatusFailed))
+		Expect(byName[healthcheck.CheckClusterHibernation].Error).To(Equal("cluster " + clusterID + " is hibernating"))
+		Expect(byName[healthcheck.CheckClusterAccessProtection].Status).To(Equal(healthcheck.StatusPassed))
+		Expect(byName[healthcheck.CheckClusterLogin].Status).To(Equal(healthcheck.StatusSkipped))
+		Expect(byName[healthcheck.CheckClusterSelfSubjectReview].Status).To(Equal(healthcheck.StatusSkipped))
+	})
+
+	It("should report the access request pending approval", func() {
+		accessRequest, err := acctrspv1.NewAccessRequest().ID("req-1").
+			Status(acctrspv1.NewAccessRequestStatus().State(acctrspv1.AccessRequestStatePending).ExpiresAt(time.Now().Add(time.Hour))).Build()
+		Expect(err).To(BeNil())
+		mockOcmInterface.EXPECT().IsClusterHibernating(clusterID).Return(false, nil)
+		mockOcmInterface.EXPECT().SetupOCMConnection().Return(nil, nil)
+		mockOcmInterface.EXPECT().IsClusterAccessProtectionEnabled(nil, clusterID).Return(true, nil)
+		mockOcmInterface.EXPECT().GetClusterActiveAccessRequest(nil, clusterID).Return(accessRequest, nil)
+
+		results, err := healthcheck.RunChecks(context.Background(), []string{healthcheck.CheckClusterLogin}, "my-cluster")
+		Expect(err).To(BeNil())
+
+		byName := resultsByName(results)
+		Expect(byName[healthcheck.CheckClusterAccessProtection].Error).To(Equal("access protection is enabled and access request 'req-1' is pending"))
+		Expect(byName[healthcheck.CheckClusterAccessProtection].Hint).To(ContainSubstring("accessrequest create --cluster-id my-cluster --wait"))
+		Expect(byName[healthcheck.CheckClusterLogin].Status).To(Equal(healthcheck.StatusSkipped))
+	})
+
+	It("should report a cluster OCM does not know", func() {
+		mockOcmInterface.EXPECT().GetTargetCluster("unknown").Return("", "", errors.New("no cluster found"))
+
+		results, err := healthcheck.RunChecks(context.Background(), []string{healthcheck.CheckClusterHibernation}, "unknown")
+		Expect(err).To(BeNil())
+
+		byName := resultsByName(results)
+		Expect(byName[healthcheck.CheckClusterResolve].Error).To(Equal("no cluster found"))
+		Expect(byName[healthcheck.CheckClusterHibernation].Error).To(Equal("skipped as cluster-resolve did not pass"))
+	})
+})
@@ -4,11 +4,9 @@ import (
 	"fmt"
 	"net"
 	"net/http"
-	"os"
 
 	"github.com/openshift/backplane-cli/pkg/cli/config"
 	logger "github.com/sirupsen/logrus"
-	"github.com/spf13/cobra"
 )
 
 //go:generate go tool mockgen -destination=mocks/networkMock.go -package=mocks github.com/openshift/backplane-cli/pkg/healthcheck NetworkInterface
@@ -46,83 +44,6 @@ var (
 	GetConfigFunc                             = config.GetBackplaneConfiguration
 )
 
-// RunHealthCheck returns a cobra command handler that performs connectivity health checks.
-// It can check VPN connectivity, proxy connectivity, or both based on the provided flags.
-// When neither flag is set, it performs comprehensive connectivity checks.
-func RunHealthCheck(checkVPN, checkProxy bool) func(cmd *cobra.Command, args []string) {
-	return func(cmd *cobra.Command, args []string) {
-		if NetInterfaces == nil || HTTPClients == nil {
-			logger.Error("Network interfaces or HTTP client is not configured")
-			os.Exit(1)
-		}
-
-		if checkVPN {
-			fmt.Println("Checking VPN connectivity...")
-			err := CheckVPNConnectivity(NetInterfaces, HTTPClients)
-			if err != nil {
-				fmt.Println("VPN connectivity check failed:", err)
-				os.Exit(1)
-			} else {
-				fmt.Println("VPN connectivity check passed!")
-			}
-			return
-		}
-
-		if checkProxy {
-			err := CheckVPNConnectivity(NetInterfaces, HTTPClients)
-			if err != nil {
-				fmt.Println("VPN connectivity check failed:", err)
-				fmt.Println("Note: Proxy connectivity check requires VPN to be connected. Please ensure VPN is connected and try again.")
-				os.Exit(1)
-			}
-
-			fmt.Println("Checking proxy connectivity...")
-			_, err = CheckProxyConnectivity(HTTPClients)
-			if err != nil {
-				fmt.Println("Proxy connectivity check failed:", err)
-				os.Exit(1)
-			} else {
-				fmt.Println("Proxy connectivity check passed!")
-			}
-			return
-		}
-
-		// If neither flag is set, check both VPN and Proxy connectivity, then check Backplane API connectivity.
-		checkAllConnections()
-	}
-}
-
-// checkAllConnections performs comprehensive connectivity checks including VPN, proxy, and Backplane API.
-// It sequentially checks each connection type and exits on the first failure.
-func checkAllConnections() {
-	fmt.Println("Checking VPN connectivity...")
-	err := CheckVPNConnectivity(NetInterfaces, HTTPClients)
-	if err != nil {
-		fmt.Println("VPN connectivity check failed:", err)
-		os.Exit(1)
-	} else {
-		fmt.Println("VPN connectivity check passed!")
-	}
-
-	fmt.Println("Checking proxy connectivity...")
-	proxyURL, err := CheckProxyConnectivity(HTTPClients)
-	if err != nil {
-		fmt.Println("Proxy connectivity check failed:", err)
-		os.Exit(1)
-	} else {
-		fmt.Println("Proxy connectivity check passed!")
-	}
-
-	fmt.Println("Checking backplane API connectivity...")
-	err = CheckBackplaneAPIConnectivity(HTTPClients, proxyURL)
-	if err != nil {
-		fmt.Println("Backplane API connectivity check failed:", err)
-		os.Exit(1)
-	} else {
-		fmt.Println("Backplane API connectivity check passed!")
-	}
-}
-
 // testEndPointConnectivity tests if a given endpoint is reachable via HTTP GET request.
 // Returns an error if the request fails or returns a non-2xx status code.
 func testEndPointConnectivity(testURL string, client HTTPClient) error {
@@ -164,6 +85,6 @@ func CheckBackplaneAPIConnectivity(client HTTPClient, proxyURL string) error {
 		return fmt.Errorf("failed to access backplane API: %v", err)
 	}
 
-	fmt.Println("Successfully connected to the backplane API!")
+	logger.Debug("Successfully connected to the backplane API")
 	return nil
 }
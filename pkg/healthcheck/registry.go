@@ -0,0 +1,211 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Status is the outcome of a health check
+type Status string
+
+const (
+	StatusPassed  Status = "passed"
+	StatusFailed  Status = "failed"
+	StatusSkipped Status = "skipped"
+
+	// DefaultCheckTimeout applies to the checks registered without timeout
+	DefaultCheckTimeout = 10 * time.Second
+)
+
+// Check is a named health check
+type Check struct {
+	Name        string
+	Description string
+	// DependsOn are the checks which must pass before running this one
+	DependsOn []string
+	Timeout   time.Duration
+	// Hint tells how to fix the failure of the check
+	Hint string
+	// Run returns a short detail about what was checked, or the reason the check failed
+	Run func(ctx context.Context) (string, error)
+}
+
+// Result is the outcome of a health check along with how long it took
+type Result struct {
+	Name      string `json:"name"`
+	Status    Status `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Error     string `json:"error,omitempty"`
+	Hint      string `json:"hint,omitempty"`
+	LatencyMs int64  `json:"latencyMs"`
+}
+
+// Registry holds the health checks, in their registration order
+type Registry struct {
+	checks []Check
+	byName map[string]Check
+}
+
+func NewRegistry() *Registry {
+	return &Registry{byName: map[string]Check{}}
+}
+
+// Register adds a check to the registry. Its dependencies must be registered first, which rules out cycles.
+func (r *Registry) Register(check Check) error {
+	if check.Name == "" || check.Run == nil {
+		return fmt.Errorf("a health check needs a name and a function to run")
+	}
+	if _, exists := r.byName[check.Name]; exists {
+		return fmt.Errorf("health check %s is already registered", check.Name)
+	}
+	for _, dependency := range check.DependsOn {
+		if _, exists := r.byName[dependency]; !exists {
+			return fmt.Errorf("health check %s depends on %s which is not registered", check.Name, dependency)
+		}
+	}
+	if check.Timeout <= 0 {
+		check.Timeout = DefaultCheckTimeout
+	}
+	r.checks = append(r.checks, check)
+	r.byName[check.Name] = check
+	return nil
+}
+
+// Checks returns the registered checks
+func (r *Registry) Checks() []Check {
+	return append([]Check{}, r.checks...)
+}
+
+// Run runs the named checks along with their dependencies, all of them when no name is given.
+// The checks run in parallel as soon as their dependencies pass, and are skipped when one of them does not.
+// The results are in the registration order of the checks.
+func (r *Registry) Run(ctx context.Context, names []string) ([]Result, error) {
+	selected, err := r.selectChecks(names)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]*Result, len(selected))
+	done := make(map[string]chan struct{}, len(selected))
+	for _, check := range r.checks {
+		if selected[check.Name] {
+			results[check.Name] = &Result{Name: check.Name}
+			done[check.Name] = make(chan struct{})
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, check := range r.checks {
+		if !selected[check.Name] {
+			continue
+		}
+		wg.Add(1)
+		go func(check Check) {
+			defer wg.Done()
+			defer close(done[check.Name])
+
+			result := results[check.Name]
+			failedDependencies := []string{}
+			for _, dependency := range check.DependsOn {
+				<-done[dependency]
+				if results[dependency].Status != StatusPassed {
+					failedDependencies = append(failedDependencies, dependency)
+				}
+			}
+			if len(failedDependencies) > 0 {
+				result.Status = StatusSkipped
+				result.Error = fmt.Sprintf("skipped as %s did not pass", strings.Join(failedDependencies, ", "))
+				result.Hint = results[failedDependencies[0]].Hint
+				return
+			}
+
+			*result = runCheck(ctx, check)
+		}(check)
+	}
+	wg.Wait()
+
+	ordered := []Result{}
+	for _, check := range r.checks {
+		if selected[check.Name] {
+			ordered = append(ordered, *results[check.Name])
+		}
+	}
+	return ordered, nil
+}
+
+// selectChecks returns the names of the given checks and of their dependencies
+func (r *Registry) selectChecks(names []string) (map[string]bool, error) {
+	selected := map[string]bool{}
+	if len(names) == 0 {
+		for _, check := range r.checks {
+			selected[check.Name] = true
+		}
+		return selected, nil
+	}
+
+	var add func(name string) error
+	add = func(name string) error {
+		check, exists := r.byName[name]
+		if !exists {
+			known := []string{}
+			for _, check := range r.checks {
+				known = append(known, check.Name)
+			}
+			return fmt.Errorf("unknown health check %s, expected one of %s", name, strings.Join(known, ", "))
+		}
+		selected[name] = true
+		for _, dependency := range check.DependsOn {
+			if err := add(dependency); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, name := range names {
+		if err := add(strings.TrimSpace(name)); err != nil {
+			return nil, err
+		}
+	}
+	return selected, nil
+}
+
+// runCheck runs the check within its timeout
+func runCheck(ctx context.Context, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, check.Timeout)
+	defer cancel()
+
+	type outcome struct {
+		detail string
+		err    error
+	}
+	// buffered so that a check which ignores the context does not leak once timed out
+	outcomes := make(chan outcome, 1)
+	start := time.Now()
+	go func() {
+		detail, err := check.Run(ctx)
+		outcomes <- outcome{detail, err}
+	}()
+
+	var o outcome
+	select {
+	case o = <-outcomes:
+	case <-ctx.Done():
+		o.err = fmt.Errorf("timed out after %s", check.Timeout)
+	}
+
+	result := Result{
+		Name:      check.Name,
+		Status:    StatusPassed,
+		Detail:    o.detail,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if o.err != nil {
+		result.Status = StatusFailed
+		result.Error = o.err.Error()
+		result.Hint = check.Hint
+	}
+	return result
+}
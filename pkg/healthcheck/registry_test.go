@@ -0,0 +1,170 @@
+package healthcheck_test
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/backplane-cli/pkg/healthcheck"
+)
+
+var _ = Describe("Health check registry", func() {
+	var registry *healthcheck.Registry
+
+	passing := func(detail string) func(ctx context.Context) (string, error) {
+		return func(ctx context.Context) (string, error) { return detail, nil }
+	}
+
+	BeforeEach(func() {
+		registry = healthcheck.NewRegistry()
+	})
+
+	Context("When registering checks", func() {
+		It("should reject duplicated checks and unknown dependencies", func() {
+			Expect(registry.Register(healthcheck.Check{Name: "a", Run: passing("")})).To(Succeed())
+			Expect(registry.Register(healthcheck.Check{Name: "a", Run: passing("")})).To(MatchError("health check a is already registered"))
+			Expect(registry.Register(healthcheck.Check{Name: "b", DependsOn: []string{"c"}, Run: passing("")})).
+				To(MatchError("health check b depends on c which is not registered"))
+		})
+
+		It("should register the default checks", func() {
+			Expect(healthcheck.NewDefaultRegistry().Checks()).To(HaveLen(9))
+		})
+	})
+
+	Context("When running checks", func() {
+		It("should keep running past failures and skip the checks depending on them", func() {
+			Expect(registry.Register(healthcheck.Check{Name: "network", Hint: "connect", Run: func(ctx context.Context) (string, error) {
+				return "", errors.New("no network")
+			}})).To(Succeed())
+			Expect(registry.Register(healthcheck.Check{Name: "api", DependsOn: []string{"network"}, Run: passing("")})).To(Succeed())
+			Expect(registry.Register(healthcheck.Check{Name: "tool", Run: passing("v1.0.0")})).To(Succeed())
+
+			results, err := registry.Run(context.Background(), nil)
+			Expect(err).To(BeNil())
+			Expect(results).To(HaveLen(3))
+			Expect(results[0]).To(matchResult(healthcheck.StatusFailed, "no network", "connect"))
+			Expect(results[1].Status).To(Equal(healthcheck.StatusSkipped))
+			Expect(results[1].Error).To(Equal("skipped as network did not pass"))
+			Expect(results[2].Status).To(Equal(healthcheck.StatusPassed))
+			Expect(results[2].Detail).To(Equal("v1.0.0"))
+			Expect(healthcheck.Passed(results)).To(BeFalse())
+		})
+
+		It("should run the independent checks in parallel and the dependent ones after their dependencies", func() {
+			var running, maxRunning int32
+			slow := func(ctx context.Context) (string, error) {
+				current := atomic.AddInt32(&running, 1)
+				defer atomic.AddInt32(&running, -1)
+				for {
+					previous := atomic.LoadInt32(&maxRunning)
+					if current <= previous || atomic.CompareAndSwapInt32(&maxRunning, previous, current) {
+						break
+					}
+				}
+				time.Sleep(50 * time.Millisecond)
+				return "", nil
+			}
+			apiRan := false
+			Expect(registry.Register(healthcheck.Check{Name: "a", Run: slow})).To(Succeed())
+			Expect(registry.Register(healthcheck.Check{Name: "b", Run: slow})).To(Succeed())
+			Expect(registry.Register(healthcheck.Check{Name: "api", DependsOn: []string{"a", "b"}, Run: func(ctx context.Context) (string, error) {
+				apiRan = atomic.LoadInt32(&running) == 0
+				return "", nil
+			}})).To(Succeed())
+
+			results, err := registry.Run(context.Background(), nil)
+			Expect(err).To(BeNil())
+			Expect(healthcheck.Passed(results)).To(BeTrue())
+			Expect(maxRunning).To(Equal(int32(2)))
+			Expect(apiRan).To(BeTrue())
+		})
+
+		It("should fail the checks running over their timeout", func() {
+			Expect(registry.Register(healthcheck.Check{Name: "hanging", Timeout: 10 * time.Millisecond, Run: func(ctx context.Context) (string, error) {
+				time.Sleep(time.Second)
+				return "", nil
+			}})).To(Succeed())
+
+			results, err := registry.Run(context.Background(), nil)
+			Expect(err).To(BeNil())
+			Expect(results[0].Status).To(Equal(healthcheck.StatusFailed))
+			Expect(results[0].Error).To(Equal("timed out after 10ms"))
+			Expect(results[0].LatencyMs).To(BeNumerically("<", 1000))
+		})
+
+		It("should run the selected checks along with their dependencies only", func() {
+			Expect(registry.Register(healthcheck.Check{Name: "network", Run: passing("")})).To(Succeed())
+			Expect(registry.Register(healthcheck.Check{Name: "api", DependsOn: []string{"network"}, Run: passing("")})).To(Succeed())
+			Expect(registry.Register(healthcheck.Check{Name: "tool", Run: passing("")})).To(Succeed())
+
+			results, err := registry.Run(context.Background(), []string{"api"})
+			Expect(err).To(BeNil())
+			Expect(results).To(HaveLen(2))
+			Expect(results[0].Name).To(Equal("network"))
+			Expect(results[1].Name).To(Equal("api"))
+
+			_, err = registry.Run(context.Background(), []string{"unknown"})
+			Expect(err).To(MatchError("unknown health check unknown, expected one of network, api, tool"))
+		})
+	})
+
+	Context("When checking the tools", func() {
+		var (
+			originalLookPath    func(string) (string, error)
+			originalExecCommand func(context.Context, string, ...string) *exec.Cmd
+		)
+
+		BeforeEach(func() {
+			originalLookPath = healthcheck.LookPathFunc
+			originalExecCommand = healthcheck.ExecCommandFunc
+		})
+
+		AfterEach(func() {
+			healthcheck.LookPathFunc = originalLookPath
+			healthcheck.ExecCommandFunc = originalExecCommand
+		})
+
+		It("should report the version of the container engine found in the PATH", func() {
+			GinkgoT().Setenv(healthcheck.EnvContainerEngine, "")
+			healthcheck.LookPathFunc = func(name string) (string, error) {
+				if name == "docker" {
+					return "/usr/bin/docker", nil
+				}
+				return "", exec.ErrNotFound
+			}
+			healthcheck.ExecCommandFunc = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+				return exec.CommandContext(ctx, "echo", "27.3.1")
+			}
+
+			results, err := healthcheck.NewDefaultRegistry().Run(context.Background(), []string{healthcheck.CheckContainerEngine})
+			Expect(err).To(BeNil())
+			Expect(results[0].Status).To(Equal(healthcheck.StatusPassed))
+			Expect(results[0].Detail).To(Equal("docker 27.3.1"))
+		})
+
+		It("should fail with a hint when oc is not installed", func() {
+			healthcheck.LookPathFunc = func(name string) (string, error) { return "", exec.ErrNotFound }
+
+			results, err := healthcheck.NewDefaultRegistry().Run(context.Background(), []string{healthcheck.CheckOCVersion})
+			Expect(err).To(BeNil())
+			Expect(results[0].Status).To(Equal(healthcheck.StatusFailed))
+			Expect(results[0].Error).To(Equal("cannot find oc in PATH"))
+			Expect(results[0].Hint).To(ContainSubstring("Install oc"))
+		})
+	})
+})
+
+// matchResult matches the status, the error and the hint of a result
+func matchResult(status healthcheck.Status, errorMessage, hint string) OmegaMatcher {
+	return And(
+		WithTransform(func(r healthcheck.Result) healthcheck.Status { return r.Status }, Equal(status)),
+		WithTransform(func(r healthcheck.Result) string { return r.Error }, Equal(errorMessage)),
+		WithTransform(func(r healthcheck.Result) string { return r.Hint }, Equal(hint)),
+	)
+}
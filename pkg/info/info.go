@@ -9,13 +9,17 @@ import (
 
 const (
 	// Environment Variables
-	BackplaneURLEnvName        = "BACKPLANE_URL"
-	BackplaneProxyEnvName      = "HTTPS_PROXY"
-	BackplaneAWSProxyEnvName   = "BACKPLANE_AWS_PROXY"
-	BackplaneConfigPathEnvName = "BACKPLANE_CONFIG"
-	BackplaneKubeconfigEnvName = "KUBECONFIG"
-	BackplaneJiraAPITokenEnvName = "JIRA_API_TOKEN" //nolint:gosec
-	BackplaneJiraEmailEnvName   = "JIRA_EMAIL"
+	BackplaneURLEnvName               = "BACKPLANE_URL"
+	BackplaneProxyEnvName             = "HTTPS_PROXY"
+	BackplaneAWSProxyEnvName          = "BACKPLANE_AWS_PROXY"
+	BackplaneConfigPathEnvName        = "BACKPLANE_CONFIG"
+	BackplaneProfileEnvName           = "BACKPLANE_PROFILE"
+	BackplaneSecretStoreEnvName       = "BACKPLANE_SECRET_STORE"
+	BackplaneSecretsPassphraseEnvName = "BACKPLANE_SECRETS_PASSPHRASE"
+	BackplaneStrictDeprecationEnvName = "BACKPLANE_STRICT_DEPRECATION"
+	BackplaneKubeconfigEnvName        = "KUBECONFIG"
+	BackplaneJiraAPITokenEnvName      = "JIRA_API_TOKEN" //nolint:gosec
+	BackplaneJiraEmailEnvName         = "JIRA_EMAIL"
 
 	// Configuration
 	BackplaneConfigDefaultFilePath = ".config/backplane"
@@ -59,6 +63,10 @@ var (
 	// This will be set via Goreleaser during the build process
 	Version string
 
+	// ReleasePublicKey verifies the signature of the release checksums on upgrade, PEM or base64 encoded.
+	// It can be set at build time with -ldflags "-X github.com/openshift/backplane-cli/pkg/info.ReleasePublicKey=..."
+	ReleasePublicKey string
+
 	UpstreamREADMETagged = fmt.Sprintf(UpstreamREADMETemplate, Version)
 )
 
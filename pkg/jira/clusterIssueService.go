@@ -0,0 +1,139 @@
+package jira
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/openshift/backplane-cli/pkg/cli/config"
+)
+
+// DefaultClusterPattern matches an OCM cluster ID or an external cluster ID in the summary or the description
+// of the issues of the projects without cluster pattern
+const DefaultClusterPattern = `\b([0-9a-v]{32}|[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})\b`
+
+// ClusterIssue is a JIRA issue along with the cluster it is about
+type ClusterIssue struct {
+	ID         string
+	Key        string
+	Title      string
+	ProjectKey string
+	WebURL     string
+	// ClusterKey is the cluster ID, external ID or name found in the issue
+	ClusterKey string
+}
+
+// ClusterIssueService finds the cluster of the issues of the configured JIRA projects
+type ClusterIssueService struct {
+	issueService IssueServiceInterface
+	projects     config.JiraLoginProjectsConfiguration
+}
+
+func NewClusterIssueService(client IssueServiceInterface, projects config.JiraLoginProjectsConfiguration) *ClusterIssueService {
+	return &ClusterIssueService{
+		issueService: client,
+		projects:     projects,
+	}
+}
+
+// GetIssue returns the matching issue of a configured project, with the cluster found in its cluster field,
+// or in its summary or description otherwise
+func (s *ClusterIssueService) GetIssue(issueID string) (clusterIssue ClusterIssue, err error) {
+	if issueID == "" {
+		return clusterIssue, fmt.Errorf("empty issue Id")
+	}
+	issue, _, err := s.issueService.Get(issueID, nil)
+	if err != nil {
+		return clusterIssue, err
+	}
+	if issue == nil || issue.Fields == nil {
+		return clusterIssue, fmt.Errorf("no matching issue for issueID:%s", issueID)
+	}
+
+	project, ok := s.projects[issue.Fields.Project.Key]
+	if !ok {
+		return clusterIssue, fmt.Errorf("issue %s belongs to project %s which is not one of the JIRA projects to log in from (%s), add it to '%s' in the config file",
+			issueID, issue.Fields.Project.Key, strings.Join(s.projectKeys(), ", "), config.JiraLoginProjectsKey)
+	}
+
+	clusterIssue = ClusterIssue{
+		ID:         issue.ID,
+		Key:        issue.Key,
+		Title:      issue.Fields.Summary,
+		ProjectKey: issue.Fields.Project.Key,
+	}
+	if issue.Self != "" {
+		if u, err := url.Parse(issue.Self); err == nil {
+			clusterIssue.WebURL = fmt.Sprintf("%s://%s/browse/%s", u.Scheme, u.Host, issue.Key)
+		}
+	}
+
+	if project.ClusterField != "" {
+		clusterIssue.ClusterKey = fieldValue(issue.Fields.Unknowns[project.ClusterField])
+	}
+	if clusterIssue.ClusterKey == "" {
+		clusterIssue.ClusterKey, err = findClusterKey(project.ClusterPattern, issue.Fields.Summary, issue.Fields.Description)
+		if err != nil {
+			return clusterIssue, fmt.Errorf("invalid cluster pattern of JIRA project %s: %v", clusterIssue.ProjectKey, err)
+		}
+	}
+
+	return clusterIssue, nil
+}
+
+// projectKeys returns the sorted keys of the configured projects
+func (s *ClusterIssueService) projectKeys() []string {
+	keys := make([]string, 0, len(s.projects))
+	for key := range s.projects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// fieldValue returns the text of a custom field, which is either a text field, a select list or a multi-value field
+func fieldValue(field interface{}) string {
+	switch value := field.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(value)
+	case map[string]interface{}:
+		for _, key := range []string{"value", "name"} {
+			if text, ok := value[key].(string); ok {
+				return strings.TrimSpace(text)
+			}
+		}
+		return ""
+	case []interface{}:
+		if len(value) == 0 {
+			return ""
+		}
+		return fieldValue(value[0])
+	default:
+		return strings.TrimSpace(fmt.Sprintf("%v", value))
+	}
+}
+
+// findClusterKey returns the first capture group of the pattern in the first text it matches
+func findClusterKey(pattern string, texts ...string) (string, error) {
+	if pattern == "" {
+		pattern = DefaultClusterPattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", err
+	}
+	for _, text := range texts {
+		match := re.FindStringSubmatch(text)
+		if len(match) > 1 {
+			return match[1], nil
+		}
+		if len(match) == 1 {
+			return match[0], nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,116 @@
+package jira
+
+import (
+	"github.com/andygrunwald/go-jira"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/trivago/tgo/tcontainer"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift/backplane-cli/pkg/cli/config"
+	jiraMock "github.com/openshift/backplane-cli/pkg/jira/mocks"
+)
+
+var _ = Describe("ClusterIssueService", func() {
+	var (
+		mockCtrl            *gomock.Controller
+		mockIssueService    *jiraMock.MockIssueServiceInterface
+		clusterIssueService *ClusterIssueService
+		testIssue           jira.Issue
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockIssueService = jiraMock.NewMockIssueServiceInterface(mockCtrl)
+		clusterIssueService = NewClusterIssueService(mockIssueService, config.JiraLoginProjectsConfiguration{
+			"OHSS":    {ClusterField: "customfield_10852"},
+			"SREP":    {ClusterField: "customfield_12345", ClusterPattern: `cluster:\s*(\S+)`},
+			"OCPBUGS": {},
+		})
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When getting the cluster of an issue", func() {
+		It("Should return the cluster of the cluster field", func() {
+			testIssue = jira.Issue{ID: "1", Key: "OHSS-1000", Self: "https://redhat.atlassian.net/rest/api/2/issue/1", Fields: &jira.IssueFields{
+				Project:  jira.Project{Key: "OHSS"},
+				Summary:  "Cluster is down",
+				Unknowns: tcontainer.MarshalMap{"customfield_10852": " 1a2b3c4d5e6f7g8h9i0j1k2l3m4n5o6p "},
+			}}
+			mockIssueService.EXPECT().Get("OHSS-1000", nil).Return(&testIssue, nil, nil).Times(1)
+
+			issue, err := clusterIssueService.GetIssue("OHSS-1000")
+			Expect(err).To(BeNil())
+			Expect(issue.ClusterKey).To(Equal("1a2b3c4d5e6f7g8h9i0j1k2l3m4n5o6p"))
+			Expect(issue.Title).To(Equal("Cluster is down"))
+			Expect(issue.WebURL).To(Equal("https://redhat.atlassian.net/browse/OHSS-1000"))
+		})
+
+		It("Should return the value of a select list cluster field", func() {
+			testIssue = jira.Issue{Key: "SREP-1", Fields: &jira.IssueFields{
+				Project:  jira.Project{Key: "SREP"},
+				Unknowns: tcontainer.MarshalMap{"customfield_12345": []interface{}{map[string]interface{}{"value": "my-cluster"}}},
+			}}
+			mockIssueService.EXPECT().Get("SREP-1", nil).Return(&testIssue, nil, nil).Times(1)
+
+			issue, err := clusterIssueService.GetIssue("SREP-1")
+			Expect(err).To(BeNil())
+			Expect(issue.ClusterKey).To(Equal("my-cluster"))
+		})
+
+		It("Should fall back to the cluster pattern of the project over the summary and the description", func() {
+			testIssue = jira.Issue{Key: "SREP-2", Fields: &jira.IssueFields{
+				Project:     jira.Project{Key: "SREP"},
+				Summary:     "Upgrade is stuck",
+				Description: "Affected cluster: my-cluster\nSince yesterday",
+			}}
+			mockIssueService.EXPECT().Get("SREP-2", nil).Return(&testIssue, nil, nil).Times(1)
+
+			issue, err := clusterIssueService.GetIssue("SREP-2")
+			Expect(err).To(BeNil())
+			Expect(issue.ClusterKey).To(Equal("my-cluster"))
+		})
+
+		It("Should find an external cluster ID with the default cluster pattern", func() {
+			testIssue = jira.Issue{Key: "OCPBUGS-3", Fields: &jira.IssueFields{
+				Project: jira.Project{Key: "OCPBUGS"},
+				Summary: "Degraded operators on 0f1e2d3c-4b5a-6978-8796-a5b4c3d2e1f0",
+			}}
+			mockIssueService.EXPECT().Get("OCPBUGS-3", nil).Return(&testIssue, nil, nil).Times(1)
+
+			issue, err := clusterIssueService.GetIssue("OCPBUGS-3")
+			Expect(err).To(BeNil())
+			Expect(issue.ClusterKey).To(Equal("0f1e2d3c-4b5a-6978-8796-a5b4c3d2e1f0"))
+		})
+
+		It("Should return an empty cluster when none is found", func() {
+			testIssue = jira.Issue{Key: "OCPBUGS-4", Fields: &jira.IssueFields{
+				Project: jira.Project{Key: "OCPBUGS"},
+				Summary: "No cluster here",
+			}}
+			mockIssueService.EXPECT().Get("OCPBUGS-4", nil).Return(&testIssue, nil, nil).Times(1)
+
+			issue, err := clusterIssueService.GetIssue("OCPBUGS-4")
+			Expect(err).To(BeNil())
+			Expect(issue.ClusterKey).To(BeEmpty())
+		})
+
+		It("Should return error for issue of a project which is not configured", func() {
+			testIssue = jira.Issue{Key: "OTHER-1", Fields: &jira.IssueFields{Project: jira.Project{Key: "OTHER"}}}
+			mockIssueService.EXPECT().Get("OTHER-1", nil).Return(&testIssue, nil, nil).Times(1)
+
+			_, err := clusterIssueService.GetIssue("OTHER-1")
+			Expect(err).To(MatchError("issue OTHER-1 belongs to project OTHER which is not one of the JIRA projects to log in from (OCPBUGS, OHSS, SREP), add it to 'jira-login-projects' in the config file"))
+		})
+
+		It("Should return error for empty issue", func() {
+			mockIssueService.EXPECT().Get("OHSS-1000", nil).Return(nil, nil, nil).Times(1)
+
+			_, err := clusterIssueService.GetIssue("OHSS-1000")
+			Expect(err).To(MatchError("no matching issue for issueID:OHSS-1000"))
+		})
+	})
+})
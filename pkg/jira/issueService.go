@@ -16,6 +16,7 @@ type IssueServiceInterface interface {
 	Update(issue *jira.Issue) (*jira.Issue, *jira.Response, error)
 	GetTransitions(id string) ([]jira.Transition, *jira.Response, error)
 	DoTransition(ticketID, transitionID string) (*jira.Response, error)
+	AddComment(issueID string, comment *jira.Comment) (*jira.Comment, *jira.Response, error)
 }
 
 type IssueServiceGetter interface {
@@ -76,6 +77,16 @@ func (decorator *IssueServiceDecorator) DoTransition(ticketID, transitionID stri
 	return issueService.DoTransition(ticketID, transitionID)
 }
 
+func (decorator *IssueServiceDecorator) AddComment(issueID string, comment *jira.Comment) (*jira.Comment, *jira.Response, error) {
+	issueService, err := decorator.Getter.GetIssueService()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return issueService.AddComment(issueID, comment)
+}
+
 type DefaultIssueServiceGetterImpl struct {
 	issueService *jira.IssueService
 }
@@ -94,9 +105,14 @@ func createIssueService() (*jira.IssueService, error) {
 		return nil, fmt.Errorf("JIRA email is not defined, consider defining it running 'ocm-backplane config set %s <email>' or setting the JIRA_EMAIL environment variable", config.JiraEmailViperKey)
 	}
 
+	jiraToken, err := bpConfig.GetJiraToken()
+	if err != nil {
+		return nil, err
+	}
+
 	transport := jira.BasicAuthTransport{
 		Username: bpConfig.JiraEmail,
-		Password: bpConfig.JiraToken,
+		Password: jiraToken,
 	}
 
 	jiraClient, err := jira.NewClient(transport.Client(), bpConfig.JiraBaseURL)
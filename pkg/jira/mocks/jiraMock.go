@@ -40,6 +40,22 @@ func (m *MockIssueServiceInterface) EXPECT() *MockIssueServiceInterfaceMockRecor
 	return m.recorder
 }
 
+// AddComment mocks base method.
+func (m *MockIssueServiceInterface) AddComment(issueID string, comment *jira.Comment) (*jira.Comment, *jira.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddComment", issueID, comment)
+	ret0, _ := ret[0].(*jira.Comment)
+	ret1, _ := ret[1].(*jira.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddComment indicates an expected call of AddComment.
+func (mr *MockIssueServiceInterfaceMockRecorder) AddComment(issueID, comment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddComment", reflect.TypeOf((*MockIssueServiceInterface)(nil).AddComment), issueID, comment)
+}
+
 // Create mocks base method.
 func (m *MockIssueServiceInterface) Create(issue *jira.Issue) (*jira.Issue, *jira.Response, error) {
 	m.ctrl.T.Helper()
@@ -25,6 +25,9 @@ type OHSSService struct {
 	issueService IssueServiceInterface
 }
 
+// NewOHSSService returns the service of the OHSS issues.
+//
+// Deprecated: use NewClusterIssueService, which works with any configured JIRA project.
 func NewOHSSService(client IssueServiceInterface) *OHSSService {
 	return &OHSSService{
 		issueService: client,
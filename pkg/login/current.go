@@ -0,0 +1,109 @@
+package login
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/openshift/backplane-cli/pkg/utils"
+)
+
+// CurrentLogin describes the backplane login of the current kubeconfig context.
+// It is read from local files only, so it is cheap enough to run at each shell prompt.
+type CurrentLogin struct {
+	KubeConfigPath string
+	ClusterID      string
+	ClusterName    string
+	Production     bool
+	// ElevationReasons are the reasons stored in the context, the login is elevated while there are any
+	ElevationReasons []string
+	// TokenExpiry is the expiry of the OCM token of the context, zero when unknown
+	TokenExpiry time.Time
+}
+
+// GetCurrentLogin returns the backplane login of the current kubeconfig context
+func GetCurrentLogin() (CurrentLogin, error) {
+	pathOptions := clientcmd.NewDefaultPathOptions()
+	current := CurrentLogin{KubeConfigPath: pathOptions.GetDefaultFilename()}
+	if !filepath.IsAbs(current.KubeConfigPath) {
+		if abs, err := filepath.Abs(current.KubeConfigPath); err == nil {
+			current.KubeConfigPath = abs
+		}
+	}
+
+	cfg, err := pathOptions.GetStartingConfig()
+	if err != nil {
+		return current, err
+	}
+	currentContext := cfg.Contexts[cfg.CurrentContext]
+	if currentContext == nil {
+		return current, fmt.Errorf("no current kubeconfig context")
+	}
+	cluster := cfg.Clusters[currentContext.Cluster]
+	if cluster == nil {
+		return current, fmt.Errorf("no cluster for the current kubeconfig context")
+	}
+	clusterID, _, err := utils.DefaultClusterUtils.GetClusterIDAndHostFromClusterURL(cluster.Server)
+	if err != nil {
+		return current, fmt.Errorf("the current kubeconfig context is not a backplane login: %v", err)
+	}
+	current.ClusterID = clusterID
+	// login names the kubeconfig cluster after the cluster name
+	current.ClusterName = currentContext.Cluster
+	if recent, ok := GetRecentLogin(clusterID); ok {
+		current.ClusterName = recent.ClusterName
+		current.Production = recent.Production
+	}
+
+	current.ElevationReasons = GetElevateContextReasons(*cfg)
+	if user := cfg.AuthInfos[currentContext.AuthInfo]; user != nil && user.Token != "" {
+		if expiry, err := utils.GetExpiryFromJWT(user.Token); err == nil {
+			current.TokenExpiry = expiry
+		}
+	}
+	return current, nil
+}
+
+// Prompt returns the prompt segment of the login: the cluster name, a production marker,
+// the elevation status and the time left on the token
+func (c CurrentLogin) Prompt(now time.Time) string {
+	prompt := c.ClusterName
+	if c.Production {
+		prompt += " [prod]"
+	}
+	if len(c.ElevationReasons) > 0 {
+		prompt += " [elevated]"
+	}
+	if !c.TokenExpiry.IsZero() {
+		left := c.TokenExpiry.Sub(now)
+		if left <= 0 {
+			prompt += " (token expired)"
+		} else {
+			prompt += fmt.Sprintf(" (%s)", formatTimeLeft(left))
+		}
+	}
+	return prompt
+}
+
+// Env returns the variables of the login, as set in the environment of a session
+func (c CurrentLogin) Env() [][2]string {
+	return [][2]string{
+		{"KUBECONFIG", c.KubeConfigPath},
+		{"CLUSTERID", c.ClusterID},
+		{"CLUSTERNAME", c.ClusterName},
+	}
+}
+
+// formatTimeLeft returns the duration rounded to the minute, e.g. 1h05m or 42m
+func formatTimeLeft(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d < time.Minute {
+		return "<1m"
+	}
+	if d >= time.Hour {
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
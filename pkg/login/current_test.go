@@ -0,0 +1,104 @@
+package login
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/openshift/backplane-cli/pkg/info"
+)
+
+var _ = Describe("Current login", func() {
+	var (
+		kubeConfigPath string
+		expiry         time.Time
+	)
+
+	BeforeEach(func() {
+		home := GinkgoT().TempDir()
+		GinkgoT().Setenv(info.BackplaneConfigPathEnvName, filepath.Join(home, "config.json"))
+		kubeConfigPath = filepath.Join(home, "kubeconfig")
+		GinkgoT().Setenv("KUBECONFIG", kubeConfigPath)
+
+		expiry = time.Now().Add(90 * time.Minute).Truncate(time.Second)
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"username": "test-user",
+			"exp":      expiry.Unix(),
+		}).SignedString([]byte("secret"))
+		Expect(err).To(BeNil())
+
+		cfg := api.NewConfig()
+		cfg.Clusters["my-cluster"] = &api.Cluster{Server: "https://api-backplane.apps.example.com/backplane/cluster/test123/"}
+		cfg.AuthInfos["test-user"] = &api.AuthInfo{Token: token}
+		cfg.Contexts["default/my-cluster/test-user"] = &api.Context{Cluster: "my-cluster", AuthInfo: "test-user", Namespace: "default"}
+		cfg.CurrentContext = "default/my-cluster/test-user"
+		Expect(clientcmd.WriteToFile(*cfg, kubeConfigPath)).To(Succeed())
+	})
+
+	It("should read the login of the current context", func() {
+		current, err := GetCurrentLogin()
+		Expect(err).To(BeNil())
+		Expect(current.KubeConfigPath).To(Equal(kubeConfigPath))
+		Expect(current.ClusterID).To(Equal("test123"))
+		Expect(current.ClusterName).To(Equal("my-cluster"))
+		Expect(current.Production).To(BeFalse())
+		Expect(current.ElevationReasons).To(BeEmpty())
+		Expect(current.TokenExpiry.Equal(expiry)).To(BeTrue())
+
+		Expect(current.Env()).To(ContainElement([2]string{"CLUSTERID", "test123"}))
+		Expect(current.Prompt(expiry.Add(-65 * time.Minute))).To(Equal("my-cluster (1h05m)"))
+		Expect(current.Prompt(expiry.Add(time.Minute))).To(Equal("my-cluster (token expired)"))
+	})
+
+	It("should mark production clusters from the recent logins", func() {
+		Expect(RecordRecentLogin(RecentLogin{ClusterID: "test123", ClusterName: "my-prod-cluster", Production: true})).To(Succeed())
+
+		current, err := GetCurrentLogin()
+		Expect(err).To(BeNil())
+		Expect(current.ClusterName).To(Equal("my-prod-cluster"))
+		current.ElevationReasons = []string{"https://pagerduty.example.com/incidents/123"}
+		Expect(current.Prompt(expiry.Add(-10 * time.Minute))).To(Equal("my-prod-cluster [prod] [elevated] (10m)"))
+	})
+
+	It("should fail outside of a backplane login", func() {
+		cfg := api.NewConfig()
+		cfg.Clusters["kind"] = &api.Cluster{Server: "https://127.0.0.1:6443"}
+		cfg.Contexts["kind"] = &api.Context{Cluster: "kind"}
+		cfg.CurrentContext = "kind"
+		Expect(clientcmd.WriteToFile(*cfg, kubeConfigPath)).To(Succeed())
+
+		_, err := GetCurrentLogin()
+		Expect(err).To(MatchError(ContainSubstring("not a backplane login")))
+	})
+})
+
+var _ = Describe("Recent logins", func() {
+	BeforeEach(func() {
+		GinkgoT().Setenv(info.BackplaneConfigPathEnvName, filepath.Join(GinkgoT().TempDir(), "config.json"))
+	})
+
+	It("should keep the most recent login of each cluster first", func() {
+		logins, err := GetRecentLogins()
+		Expect(err).To(BeNil())
+		Expect(logins).To(BeEmpty())
+
+		now := time.Now()
+		Expect(RecordRecentLogin(RecentLogin{ClusterID: "a", ClusterName: "cluster-a", LastLogin: now.Add(-2 * time.Hour)})).To(Succeed())
+		Expect(RecordRecentLogin(RecentLogin{ClusterID: "b", ClusterName: "cluster-b", LastLogin: now.Add(-time.Hour)})).To(Succeed())
+		Expect(RecordRecentLogin(RecentLogin{ClusterID: "a", ClusterName: "cluster-a-renamed"})).To(Succeed())
+
+		logins, err = GetRecentLogins()
+		Expect(err).To(BeNil())
+		Expect(logins).To(HaveLen(2))
+		Expect(logins[0].ClusterName).To(Equal("cluster-a-renamed"))
+		Expect(logins[1].ClusterID).To(Equal("b"))
+
+		_, ok := GetRecentLogin("unknown")
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -96,6 +96,17 @@ func CreateClusterKubeConfig(clusterID string, kubeConfig api.Config) (string, e
 
 }
 
+// GetClusterKubeConfigPath returns the path of the cluster specific kube config
+// written by a multi-cluster login
+func GetClusterKubeConfigPath(clusterID string) (string, error) {
+	basePath, err := getKubeConfigBasePath()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(basePath, clusterID, "config"), nil
+}
+
 // RemoveClusterKubeConfig delete cluster specific kube config file
 func RemoveClusterKubeConfig(clusterID string) error {
 
@@ -0,0 +1,106 @@
+package login
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/openshift/backplane-cli/pkg/cli/config"
+)
+
+const (
+	// The file under the backplane config directory holding the recent logins
+	recentLoginsFileName = "recent-logins.json"
+
+	// The number of recent logins kept
+	maxRecentLogins = 50
+)
+
+// RecentLogin describes a cluster the user logged into
+type RecentLogin struct {
+	ClusterID   string    `json:"cluster_id"`
+	ClusterName string    `json:"cluster_name"`
+	Production  bool      `json:"production,omitempty"`
+	LastLogin   time.Time `json:"last_login"`
+}
+
+// getRecentLoginsPath returns the file holding the recent logins
+func getRecentLoginsPath() (string, error) {
+	configDir, err := config.GetConfigDirectory()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, recentLoginsFileName), nil
+}
+
+// GetRecentLogins returns the clusters the user logged into, the most recent first
+func GetRecentLogins() ([]RecentLogin, error) {
+	path, err := getRecentLoginsPath()
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []RecentLogin{}, nil
+		}
+		return nil, err
+	}
+	logins := []RecentLogin{}
+	if err := json.Unmarshal(content, &logins); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(logins, func(i, j int) bool {
+		return logins[i].LastLogin.After(logins[j].LastLogin)
+	})
+	return logins, nil
+}
+
+// GetRecentLogin returns the recent login of the cluster
+func GetRecentLogin(clusterID string) (RecentLogin, bool) {
+	logins, err := GetRecentLogins()
+	if err != nil {
+		return RecentLogin{}, false
+	}
+	for _, login := range logins {
+		if login.ClusterID == clusterID {
+			return login, true
+		}
+	}
+	return RecentLogin{}, false
+}
+
+// RecordRecentLogin adds the login to the recent logins, replacing the previous login of the cluster
+func RecordRecentLogin(login RecentLogin) error {
+	logins, err := GetRecentLogins()
+	if err != nil {
+		// start over rather than failing the login on an unreadable history
+		logins = []RecentLogin{}
+	}
+	if login.LastLogin.IsZero() {
+		login.LastLogin = time.Now()
+	}
+
+	recent := []RecentLogin{login}
+	for _, l := range logins {
+		if l.ClusterID != login.ClusterID && len(recent) < maxRecentLogins {
+			recent = append(recent, l)
+		}
+	}
+
+	content, err := json.MarshalIndent(recent, "", "  ")
+	if err != nil {
+		return err
+	}
+	path, err := getRecentLoginsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0600)
+}
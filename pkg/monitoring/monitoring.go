@@ -73,6 +73,19 @@ func (c Client) RunMonitoring(monitoringType string) error {
 		return fmt.Errorf("the api server is not a backplane url, please make sure you login to the cluster using backplane")
 	}
 
+	// reuse the proxy of 'monitoring all' when one is running for the cluster
+	if c.url == "" && len(MonitoringOpts.OriginURL) == 0 && len(MonitoringOpts.ListenAddr) == 0 {
+		if clusterID, _, err := utils.DefaultClusterUtils.GetClusterIDAndHostFromClusterURL(cfg.Host); err == nil {
+			running, err := GetRunningProxy(clusterID)
+			if err != nil {
+				logger.Debugf("cannot discover a running monitoring proxy: %v", err)
+			} else if running != nil && running.Serves(monitoringType) && running.Namespace == MonitoringOpts.Namespace {
+				fmt.Printf("%s is already served at %s\n", monitoringType, running.BackendURL(monitoringType))
+				return openMonitoringBrowser(running.BackendURL(monitoringType))
+			}
+		}
+	}
+
 	// set up monitoring Url if it's empty
 	if c.url == "" {
 		c.url, err = getBackplaneMonitoringURL(monitoringType)
@@ -111,16 +124,10 @@ func (c Client) RunMonitoring(monitoringType string) error {
 
 	var name string
 	if isGrafana {
-		userInterface, err := userv1typedclient.NewForConfig(cfg)
-		if err != nil {
-			return err
-		}
-
-		user, err := userInterface.Users().Get(context.TODO(), "~", metav1.GetOptions{})
+		name, err = getGrafanaUserName(cfg)
 		if err != nil {
 			return err
 		}
-		name = strings.Replace(user.Name, "system:serviceaccount:", "", 1)
 	}
 
 	// Test if the monitoring stack works, by sending a request to backend/backplane-api
@@ -206,6 +213,20 @@ func (c Client) RunMonitoring(monitoringType string) error {
 
 }
 
+// getGrafanaUserName returns the user name forwarded to grafana
+func getGrafanaUserName(cfg *restclient.Config) (string, error) {
+	userInterface, err := userv1typedclient.NewForConfig(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	user, err := userInterface.Users().Get(context.TODO(), "~", metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	return strings.Replace(user.Name, "system:serviceaccount:", "", 1), nil
+}
+
 // getBackplaneMonitoringURL returns the backplane API monitoring URL based on monitoring type
 func getBackplaneMonitoringURL(monitoringType string) (string, error) {
 	monitoringURL := ""
@@ -0,0 +1,487 @@
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/browser"
+	logger "github.com/sirupsen/logrus"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/openshift/backplane-cli/pkg/cli/config"
+	"github.com/openshift/backplane-cli/pkg/ocm"
+	"github.com/openshift/backplane-cli/pkg/utils"
+)
+
+const (
+	// The directory under the backplane config directory holding the running monitoring proxies
+	monitoringProxyDirectory = "monitoring"
+
+	// The endpoint reporting the health of every backend of the multiplexed proxy
+	MonitoringHealthPath = "/healthz"
+
+	// How long a single backend health check or discovery request may take
+	monitoringHealthTimeout = 10 * time.Second
+)
+
+// ProxyState describes a running multiplexed monitoring proxy, so other commands can discover it
+type ProxyState struct {
+	ClusterID string    `json:"cluster_id"`
+	URL       string    `json:"url"`
+	PID       int       `json:"pid"`
+	Namespace string    `json:"namespace"`
+	Backends  []string  `json:"backends"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// BackendURL returns the local URL of the monitoring backend served by the proxy
+func (s ProxyState) BackendURL(monitoringType string) string {
+	return fmt.Sprintf("%s/%s/", strings.TrimSuffix(s.URL, "/"), monitoringType)
+}
+
+// Serves returns whether the proxy serves the monitoring backend
+func (s ProxyState) Serves(monitoringType string) bool {
+	for _, b := range s.Backends {
+		if b == monitoringType {
+			return true
+		}
+	}
+	return false
+}
+
+// BackendHealth is the health of a single backend of the multiplexed proxy
+type BackendHealth struct {
+	Healthy bool   `json:"healthy"`
+	Status  int    `json:"status,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ProxyHealth is the response of the health endpoint of the multiplexed proxy
+type ProxyHealth struct {
+	ClusterID string                   `json:"cluster_id"`
+	Healthy   bool                     `json:"healthy"`
+	Backends  map[string]BackendHealth `json:"backends"`
+}
+
+// multiplexProxy serves every monitoring backend of a cluster on path prefixes of a single listener
+type multiplexProxy struct {
+	clusterID string
+	backends  map[string]*url.URL
+	userName  string
+	http      http.Client
+}
+
+var multiplexLandingTemplate = template.Must(template.New("landing").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Backplane monitoring {{ .ClusterID }}</title>
+</head>
+<body>
+<h1>Backplane monitoring of cluster {{ .ClusterID }}</h1>
+<ul>
+{{- range .Backends }}
+<li><a href="/{{ . }}/">{{ . }}</a></li>
+{{- end }}
+</ul>
+<p><a href="{{ .HealthPath }}">Health</a></p>
+</body>
+</html>
+`))
+
+// RunAllMonitoring serves every monitoring backend of the current cluster from a single listener,
+// each on the path prefix of its name, until interrupted.
+func (c Client) RunAllMonitoring() error {
+	cfg, err := clientcmd.BuildConfigFromFlags("", clientcmd.NewDefaultPathOptions().GetDefaultFilename())
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(cfg.Host, "backplane/cluster") {
+		return fmt.Errorf("the api server is not a backplane url, please make sure you login to the cluster using backplane")
+	}
+	clusterID, _, err := utils.DefaultClusterUtils.GetClusterIDAndHostFromClusterURL(cfg.Host)
+	if err != nil {
+		return err
+	}
+
+	running, err := GetRunningProxy(clusterID)
+	if err != nil {
+		return err
+	}
+	if running != nil {
+		fmt.Printf("Monitoring of cluster %s is already served at %s\n", clusterID, running.URL)
+		return openMonitoringBrowser(running.URL)
+	}
+
+	proxy := &multiplexProxy{
+		clusterID: clusterID,
+		backends:  map[string]*url.URL{},
+		http:      c.http,
+	}
+	for _, monitoringType := range ValidMonitoringNames {
+		if err := validateClusterVersion(monitoringType); err != nil {
+			logger.Warnf("Skipping %s: %v", monitoringType, err)
+			continue
+		}
+		monitoringURL, err := getBackplaneMonitoringURL(monitoringType)
+		if err != nil {
+			return err
+		}
+		proxy.backends[monitoringType], err = url.Parse(monitoringURL)
+		if err != nil {
+			return err
+		}
+	}
+	if len(proxy.backends) == 0 {
+		return fmt.Errorf("none of the monitoring backends can be served for cluster %s", clusterID)
+	}
+
+	if _, ok := proxy.backends[GRAFANA]; ok {
+		proxy.userName, err = getGrafanaUserName(cfg)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Add http proxy transport unless the client already has one
+	if proxy.http.Transport == nil {
+		proxyURL, err := getProxyURL()
+		if err != nil {
+			return err
+		}
+		if proxyURL != nil && *proxyURL != "" {
+			parsedProxyURL, err := url.Parse(*proxyURL)
+			if err != nil {
+				return err
+			}
+			proxy.http.Transport = &http.Transport{Proxy: http.ProxyURL(parsedProxyURL)}
+			logger.Debugf("Using backplane Proxy URL: %s\n", parsedProxyURL)
+		}
+	}
+
+	addr := MonitoringOpts.ListenAddr
+	if addr == "" {
+		port, err := utils.GetFreePort()
+		if err != nil {
+			return err
+		}
+		addr = fmt.Sprintf("127.0.0.1:%d", port)
+	}
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	state := ProxyState{
+		ClusterID: clusterID,
+		URL:       fmt.Sprintf("http://%s", l.Addr().String()),
+		PID:       os.Getpid(),
+		Namespace: MonitoringOpts.Namespace,
+		Backends:  proxy.backendNames(),
+		StartedAt: time.Now(),
+	}
+	if err := saveProxyState(state); err != nil {
+		return err
+	}
+	defer removeProxyState(clusterID)
+
+	fmt.Printf("Serving monitoring of cluster %s at %s\n", clusterID, state.URL)
+	for _, b := range state.Backends {
+		fmt.Printf("  %-12s %s\n", b, state.BackendURL(b))
+	}
+	if err := openMonitoringBrowser(state.URL); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := &http.Server{Handler: proxy.handler(), ReadHeaderTimeout: 30 * time.Second}
+	errs := make(chan error, 1)
+	go func() { errs <- srv.Serve(l) }()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+		logger.Debugln("Stopping the monitoring proxy")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
+
+// openMonitoringBrowser opens the URL when the browser option is set
+func openMonitoringBrowser(u string) error {
+	if !MonitoringOpts.Browser {
+		return nil
+	}
+	if err := browser.OpenURL(u); err != nil {
+		logger.Warnf("failed opening a browser: %s", err)
+	}
+	return nil
+}
+
+// backendNames returns the sorted names of the served backends
+func (p *multiplexProxy) backendNames() []string {
+	names := make([]string, 0, len(p.backends))
+	for name := range p.backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// handler returns the handler serving the landing page, the health endpoint and every backend
+func (p *multiplexProxy) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(MonitoringHealthPath, p.serveHealth)
+	for name := range p.backends {
+		mux.Handle("/"+name+"/", p.backendHandler(name))
+	}
+	mux.HandleFunc("/", p.serveLanding)
+	return mux
+}
+
+// serveLanding serves the landing page, and redirects the absolute paths requested by a backend UI
+// to the prefix of that backend
+func (p *multiplexProxy) serveLanding(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		err := multiplexLandingTemplate.Execute(w, struct {
+			ClusterID  string
+			Backends   []string
+			HealthPath string
+		}{p.clusterID, p.backendNames(), MonitoringHealthPath})
+		if err != nil {
+			logger.Warnf("failed to render the landing page: %v", err)
+		}
+		return
+	}
+
+	// UIs like the prometheus one request their assets from absolute paths
+	if referer, err := url.Parse(r.Referer()); err == nil {
+		name := strings.SplitN(strings.TrimPrefix(referer.Path, "/"), "/", 2)[0]
+		if _, ok := p.backends[name]; ok {
+			target := *r.URL
+			target.Path = "/" + name + r.URL.Path
+			target.RawPath = ""
+			http.Redirect(w, r, target.String(), http.StatusTemporaryRedirect)
+			return
+		}
+	}
+	http.NotFound(w, r)
+}
+
+// backendHandler returns the reverse proxy of the backend, stripping its path prefix
+func (p *multiplexProxy) backendHandler(name string) http.Handler {
+	mURL := p.backends[name]
+	prefix := "/" + name
+	hasNs := len(MonitoringOpts.Namespace) != 0
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the token is refreshed by the OCM connection, so a long running proxy keeps working
+		accessToken, err := ocm.DefaultOCMInterface.GetOCMAccessToken()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to get the OCM access token: %v", err), http.StatusBadGateway)
+			return
+		}
+
+		proxy := &httputil.ReverseProxy{
+			Director: func(req *http.Request) {
+				req.URL.Path = strings.TrimPrefix(req.URL.Path, prefix)
+				req.URL.RawPath = ""
+				setProxyRequest(req, mURL, p.userName, accessToken, name == GRAFANA, hasNs, false, false)
+			},
+			ModifyResponse: func(resp *http.Response) error {
+				// keep redirects of the backend within its prefix
+				if location := resp.Header.Get("Location"); strings.HasPrefix(location, "/") && !strings.HasPrefix(location, "//") {
+					location = strings.TrimPrefix(location, strings.TrimSuffix(mURL.Path, "/"))
+					resp.Header.Set("Location", singleJoiningSlash(prefix, location))
+				}
+				return nil
+			},
+			Transport: p.http.Transport,
+		}
+		proxy.ServeHTTP(w, r)
+	})
+}
+
+// serveHealth checks every backend concurrently and reports their health
+func (p *multiplexProxy) serveHealth(w http.ResponseWriter, r *http.Request) {
+	health := p.checkHealth(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+	if !health.Healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(health)
+}
+
+// checkHealth sends a request to the root of every backend
+func (p *multiplexProxy) checkHealth(ctx context.Context) ProxyHealth {
+	health := ProxyHealth{ClusterID: p.clusterID, Healthy: true, Backends: map[string]BackendHealth{}}
+
+	client := p.http
+	client.Timeout = monitoringHealthTimeout
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	hasNs := len(MonitoringOpts.Namespace) != 0
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for name, mURL := range p.backends {
+		wg.Add(1)
+		go func(name string, mURL *url.URL) {
+			defer wg.Done()
+			result := BackendHealth{}
+			if err := func() error {
+				accessToken, err := ocm.DefaultOCMInterface.GetOCMAccessToken()
+				if err != nil {
+					return err
+				}
+				req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/", nil)
+				if err != nil {
+					return err
+				}
+				req = setProxyRequest(req, mURL, p.userName, accessToken, name == GRAFANA, hasNs, false, false)
+				resp, err := client.Do(req)
+				if err != nil {
+					return err
+				}
+				_ = resp.Body.Close()
+				result.Status = resp.StatusCode
+				if resp.StatusCode >= 400 {
+					return fmt.Errorf("%s", resp.Status)
+				}
+				return nil
+			}(); err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Healthy = true
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			health.Backends[name] = result
+			health.Healthy = health.Healthy && result.Healthy
+		}(name, mURL)
+	}
+	wg.Wait()
+	return health
+}
+
+// getMonitoringProxyDirectory returns the directory holding the running monitoring proxies
+func getMonitoringProxyDirectory() (string, error) {
+	configDirectory, err := config.GetConfigDirectory()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDirectory, monitoringProxyDirectory), nil
+}
+
+// GetProxyLogPath returns the path of the log of the monitoring proxy of the cluster when running in the background
+func GetProxyLogPath(clusterID string) (string, error) {
+	dir, err := getMonitoringProxyDirectory()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, clusterID+".log"), nil
+}
+
+// saveProxyState records the running proxy of the cluster
+func saveProxyState(state ProxyState) error {
+	dir, err := getMonitoringProxyDirectory()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, state.ClusterID+".json"), content, 0600)
+}
+
+// removeProxyState removes the record of the running proxy of the cluster
+func removeProxyState(clusterID string) {
+	dir, err := getMonitoringProxyDirectory()
+	if err != nil {
+		logger.Warnf("failed to remove the monitoring proxy record: %v", err)
+		return
+	}
+	if err := os.Remove(filepath.Join(dir, clusterID+".json")); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.Warnf("failed to remove the monitoring proxy record: %v", err)
+	}
+}
+
+// GetRunningProxy returns the multiplexed monitoring proxy running for the cluster, nil if there is none.
+// Records of proxies which do not answer anymore are removed.
+func GetRunningProxy(clusterID string) (*ProxyState, error) {
+	dir, err := getMonitoringProxyDirectory()
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(filepath.Join(dir, clusterID+".json"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var state ProxyState
+	if err := json.Unmarshal(content, &state); err != nil {
+		logger.Debugf("removing the unreadable monitoring proxy record of %s: %v", clusterID, err)
+		removeProxyState(clusterID)
+		return nil, nil
+	}
+
+	// any answer of the health endpoint, even an unhealthy one, means the proxy is running
+	client := http.Client{Timeout: monitoringHealthTimeout}
+	resp, err := client.Get(strings.TrimSuffix(state.URL, "/") + MonitoringHealthPath)
+	if err != nil {
+		logger.Debugf("removing the stale monitoring proxy record of %s: %v", clusterID, err)
+		removeProxyState(clusterID)
+		return nil, nil
+	}
+	_ = resp.Body.Close()
+	return &state, nil
+}
+
+// StopRunningProxy stops the multiplexed monitoring proxy running for the cluster
+func StopRunningProxy(clusterID string) error {
+	state, err := GetRunningProxy(clusterID)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		return fmt.Errorf("no monitoring proxy is running for cluster %s", clusterID)
+	}
+	process, err := os.FindProcess(state.PID)
+	if err != nil {
+		return err
+	}
+	return process.Signal(syscall.SIGTERM)
+}
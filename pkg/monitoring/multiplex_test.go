@@ -0,0 +1,177 @@
+package monitoring
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift/backplane-cli/pkg/info"
+	"github.com/openshift/backplane-cli/pkg/ocm"
+	ocmMock "github.com/openshift/backplane-cli/pkg/ocm/mocks"
+)
+
+var _ = Describe("Backplane Monitoring multiplexed proxy", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockOcmInterface *ocmMock.MockOCMInterface
+		testToken        string
+		lastRequest      *http.Request
+		backend          *httptest.Server
+		proxy            *multiplexProxy
+		local            *httptest.Server
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockOcmInterface = ocmMock.NewMockOCMInterface(mockCtrl)
+		ocm.DefaultOCMInterface = mockOcmInterface
+
+		testToken = "hello123"
+		mockOcmInterface.EXPECT().GetOCMAccessToken().Return(&testToken, nil).AnyTimes()
+		MonitoringOpts.Namespace = OpenShiftMonitoringNS
+
+		backend = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lastRequest = r
+			switch r.URL.Path {
+			case "/backplane/prometheus/test123/":
+				http.Redirect(w, r, "/backplane/prometheus/test123/graph", http.StatusFound)
+			case "/backplane/alertmanager/test123/":
+				w.WriteHeader(http.StatusForbidden)
+			default:
+				_, _ = w.Write([]byte("ok"))
+			}
+		}))
+		prometheusURL, _ := url.Parse(backend.URL + "/backplane/prometheus/test123")
+		alertmanagerURL, _ := url.Parse(backend.URL + "/backplane/alertmanager/test123")
+
+		proxy = &multiplexProxy{
+			clusterID: "test123",
+			backends:  map[string]*url.URL{PROMETHEUS: prometheusURL, ALERTMANAGER: alertmanagerURL},
+			http:      *backend.Client(),
+		}
+		local = httptest.NewServer(proxy.handler())
+	})
+
+	AfterEach(func() {
+		local.Close()
+		backend.Close()
+		mockCtrl.Finish()
+	})
+
+	noRedirectClient := func() *http.Client {
+		return &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	}
+
+	Context("serving backends", func() {
+		It("should list every backend on the landing page", func() {
+			resp, err := http.Get(local.URL + "/")
+			Expect(err).To(BeNil())
+			body, _ := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			Expect(string(body)).To(ContainSubstring(`href="/alertmanager/"`))
+			Expect(string(body)).To(ContainSubstring(`href="/prometheus/"`))
+		})
+
+		It("should proxy the path prefix to the backend", func() {
+			resp, err := http.Get(local.URL + "/prometheus/api/v1/query?query=up")
+			Expect(err).To(BeNil())
+			_ = resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(lastRequest.URL.Path).To(Equal("/backplane/prometheus/test123/api/v1/query"))
+			Expect(lastRequest.URL.Query().Get("query")).To(Equal("up"))
+			Expect(lastRequest.Header.Get("Authorization")).To(Equal("Bearer hello123"))
+			Expect(lastRequest.Header.Get("x-namespace")).To(Equal(OpenShiftMonitoringNS))
+		})
+
+		It("should keep the redirects of the backend within its prefix", func() {
+			resp, err := noRedirectClient().Get(local.URL + "/prometheus/")
+			Expect(err).To(BeNil())
+			_ = resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusFound))
+			Expect(resp.Header.Get("Location")).To(Equal("/prometheus/graph"))
+		})
+
+		It("should redirect absolute paths requested by a backend UI", func() {
+			req, _ := http.NewRequest(http.MethodGet, local.URL+"/static/app.js", nil)
+			req.Header.Set("Referer", local.URL+"/prometheus/graph")
+			resp, err := noRedirectClient().Do(req)
+			Expect(err).To(BeNil())
+			_ = resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusTemporaryRedirect))
+			Expect(resp.Header.Get("Location")).To(Equal("/prometheus/static/app.js"))
+		})
+
+		It("should not find unknown paths", func() {
+			resp, err := http.Get(local.URL + "/unknown")
+			Expect(err).To(BeNil())
+			_ = resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+		})
+
+		It("should report the health of every backend", func() {
+			resp, err := http.Get(local.URL + MonitoringHealthPath)
+			Expect(err).To(BeNil())
+			defer func() { _ = resp.Body.Close() }()
+			Expect(resp.StatusCode).To(Equal(http.StatusServiceUnavailable))
+
+			var health ProxyHealth
+			Expect(json.NewDecoder(resp.Body).Decode(&health)).To(Succeed())
+			Expect(health.Healthy).To(BeFalse())
+			Expect(health.Backends[PROMETHEUS].Healthy).To(BeTrue())
+			Expect(health.Backends[ALERTMANAGER].Healthy).To(BeFalse())
+			Expect(health.Backends[ALERTMANAGER].Status).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Context("discovering a running proxy", func() {
+		var tempDir string
+
+		BeforeEach(func() {
+			tempDir = GinkgoT().TempDir()
+			_ = os.Setenv(info.BackplaneConfigPathEnvName, filepath.Join(tempDir, "config.json"))
+		})
+
+		AfterEach(func() {
+			_ = os.Unsetenv(info.BackplaneConfigPathEnvName)
+		})
+
+		It("should discover the proxy while it answers", func() {
+			state := ProxyState{ClusterID: "test123", URL: local.URL, PID: os.Getpid(), Namespace: OpenShiftMonitoringNS, Backends: proxy.backendNames(), StartedAt: time.Now()}
+			Expect(saveProxyState(state)).To(Succeed())
+
+			running, err := GetRunningProxy("test123")
+			Expect(err).To(BeNil())
+			Expect(running).NotTo(BeNil())
+			Expect(running.Serves(PROMETHEUS)).To(BeTrue())
+			Expect(running.Serves(GRAFANA)).To(BeFalse())
+			Expect(running.BackendURL(PROMETHEUS)).To(Equal(local.URL + "/prometheus/"))
+		})
+
+		It("should remove the record of a proxy which stopped", func() {
+			local.Close()
+			state := ProxyState{ClusterID: "test123", URL: local.URL, PID: os.Getpid()}
+			Expect(saveProxyState(state)).To(Succeed())
+
+			running, err := GetRunningProxy("test123")
+			Expect(err).To(BeNil())
+			Expect(running).To(BeNil())
+			_, err = os.Stat(filepath.Join(tempDir, monitoringProxyDirectory, "test123.json"))
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+
+		It("should not find a proxy which never ran", func() {
+			running, err := GetRunningProxy("unknown123")
+			Expect(err).To(BeNil())
+			Expect(running).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,318 @@
+package monitoring
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/openshift/backplane-cli/pkg/ocm"
+)
+
+const (
+	// Prometheus HTTP API paths, served by both Prometheus and Thanos querier
+	prometheusQueryPath      = "/api/v1/query"
+	prometheusQueryRangePath = "/api/v1/query_range"
+	prometheusAlertsPath     = "/api/v1/alerts"
+
+	// Alert states reported by the Prometheus alerts API
+	AlertStateFiring  = "firing"
+	AlertStatePending = "pending"
+)
+
+// Sample is a single value of a series at a point in time
+type Sample struct {
+	Timestamp time.Time `json:"timestamp"`
+	Value     float64   `json:"value"`
+}
+
+// Series is a set of samples sharing the same labels
+type Series struct {
+	Metric  map[string]string `json:"metric"`
+	Samples []Sample          `json:"samples"`
+}
+
+// QueryResult is the result of an instant or range PromQL query
+type QueryResult struct {
+	ResultType string   `json:"resultType"`
+	Series     []Series `json:"series"`
+}
+
+// Alert is an alert as reported by the Prometheus alerts API
+type Alert struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	State       string            `json:"state"`
+	ActiveAt    time.Time         `json:"activeAt"`
+	Value       string            `json:"value"`
+}
+
+// apiResponse is the envelope of every Prometheus HTTP API response
+type apiResponse struct {
+	Status    string          `json:"status"`
+	Data      json.RawMessage `json:"data"`
+	ErrorType string          `json:"errorType"`
+	Error     string          `json:"error"`
+}
+
+type apiQueryData struct {
+	ResultType string          `json:"resultType"`
+	Result     json.RawMessage `json:"result"`
+}
+
+type apiSeries struct {
+	Metric map[string]string `json:"metric"`
+	Value  []interface{}     `json:"value"`
+	Values [][]interface{}   `json:"values"`
+}
+
+type apiAlertsData struct {
+	Alerts []Alert `json:"alerts"`
+}
+
+// Name returns the metric name and labels of the series in PromQL notation
+func (s Series) Name() string {
+	labels := make([]string, 0, len(s.Metric))
+	for k, v := range s.Metric {
+		if k == "__name__" {
+			continue
+		}
+		labels = append(labels, fmt.Sprintf("%s=%q", k, v))
+	}
+	sort.Strings(labels)
+	return fmt.Sprintf("%s{%s}", s.Metric["__name__"], strings.Join(labels, ", "))
+}
+
+// Values returns the sample values of the series
+func (s Series) Values() []float64 {
+	values := make([]float64, 0, len(s.Samples))
+	for _, sample := range s.Samples {
+		values = append(values, sample.Value)
+	}
+	return values
+}
+
+// Query runs an instant PromQL query through the backplane monitoring endpoint
+func (c Client) Query(monitoringType, query string, at time.Time) (*QueryResult, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	if !at.IsZero() {
+		params.Set("time", formatPrometheusTime(at))
+	}
+	return c.query(monitoringType, prometheusQueryPath, params)
+}
+
+// QueryRange runs a PromQL range query through the backplane monitoring endpoint
+func (c Client) QueryRange(monitoringType, query string, start, end time.Time, step time.Duration) (*QueryResult, error) {
+	if step <= 0 {
+		return nil, fmt.Errorf("step should be a positive duration")
+	}
+	if !end.After(start) {
+		return nil, fmt.Errorf("the end of the range should be after its start")
+	}
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("start", formatPrometheusTime(start))
+	params.Set("end", formatPrometheusTime(end))
+	params.Set("step", strconv.FormatFloat(step.Seconds(), 'f', -1, 64))
+	return c.query(monitoringType, prometheusQueryRangePath, params)
+}
+
+// Alerts returns the alerts through the backplane monitoring endpoint.
+// When firingOnly is set, pending alerts are left out.
+func (c Client) Alerts(monitoringType string, firingOnly bool) ([]Alert, error) {
+	var data apiAlertsData
+	if err := c.getAPI(monitoringType, prometheusAlertsPath, url.Values{}, &data); err != nil {
+		return nil, err
+	}
+
+	alerts := []Alert{}
+	for _, alert := range data.Alerts {
+		if firingOnly && alert.State != AlertStateFiring {
+			continue
+		}
+		alerts = append(alerts, alert)
+	}
+	sort.SliceStable(alerts, func(i, j int) bool {
+		return alerts[i].ActiveAt.Before(alerts[j].ActiveAt)
+	})
+	return alerts, nil
+}
+
+// query runs the query against the given API path and decodes its result
+func (c Client) query(monitoringType, path string, params url.Values) (*QueryResult, error) {
+	var data apiQueryData
+	if err := c.getAPI(monitoringType, path, params, &data); err != nil {
+		return nil, err
+	}
+	return parseQueryData(data)
+}
+
+// getAPI sends a GET request to the Prometheus HTTP API of the monitoring type and
+// decodes the data of the response into out
+func (c Client) getAPI(monitoringType, path string, params url.Values, out interface{}) error {
+	status, body, err := c.doAPI(monitoringType, http.MethodGet, path, params, nil)
+	if err != nil {
+		return err
+	}
+
+	var apiResp apiResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		if status >= 400 {
+			return fmt.Errorf("%d %s: %s", status, http.StatusText(status), strings.TrimSpace(string(body)))
+		}
+		return fmt.Errorf("failed to parse the response from %s: %v", monitoringType, err)
+	}
+	if apiResp.Status != "success" {
+		return fmt.Errorf("%s query failed: %s: %s", monitoringType, apiResp.ErrorType, apiResp.Error)
+	}
+
+	return json.Unmarshal(apiResp.Data, out)
+}
+
+// doAPI sends a request to the API of the monitoring type through the backplane monitoring endpoint,
+// and returns the status code and body of the response
+func (c Client) doAPI(monitoringType, method, path string, params url.Values, body []byte) (int, []byte, error) {
+	if monitoringType == "" {
+		return 0, nil, fmt.Errorf("monitoring type is empty")
+	}
+
+	// set up monitoring Url if it's empty
+	var err error
+	if c.url == "" {
+		c.url, err = getBackplaneMonitoringURL(monitoringType)
+		if err != nil {
+			return 0, nil, err
+		}
+	}
+	mURL, err := url.Parse(c.url)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	accessToken, err := ocm.DefaultOCMInterface.GetOCMAccessToken()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// Add http proxy transport unless the client already has one
+	if c.http.Transport == nil {
+		proxyURL, err := getProxyURL()
+		if err != nil {
+			return 0, nil, err
+		}
+		if proxyURL != nil && *proxyURL != "" {
+			parsedProxyURL, err := url.Parse(*proxyURL)
+			if err != nil {
+				return 0, nil, err
+			}
+			c.http.Transport = &http.Transport{Proxy: http.ProxyURL(parsedProxyURL)}
+			logger.Debugf("Using backplane Proxy URL: %s\n", parsedProxyURL)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), method, path, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	req.URL.RawQuery = params.Encode()
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	hasNs := len(MonitoringOpts.Namespace) != 0
+	req = setProxyRequest(req, mURL, "", accessToken, false, hasNs, false, false)
+
+	logger.Debugf("Sending %s %s\n", method, req.URL.String())
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, respBody, nil
+}
+
+// parseQueryData converts the Prometheus result of any result type into series
+func parseQueryData(data apiQueryData) (*QueryResult, error) {
+	result := &QueryResult{ResultType: data.ResultType, Series: []Series{}}
+
+	switch data.ResultType {
+	case "vector", "matrix":
+		var apiResult []apiSeries
+		if err := json.Unmarshal(data.Result, &apiResult); err != nil {
+			return nil, err
+		}
+		for _, s := range apiResult {
+			series := Series{Metric: s.Metric}
+			if s.Value != nil {
+				sample, err := parseSample(s.Value)
+				if err != nil {
+					return nil, err
+				}
+				series.Samples = append(series.Samples, sample)
+			}
+			for _, v := range s.Values {
+				sample, err := parseSample(v)
+				if err != nil {
+					return nil, err
+				}
+				series.Samples = append(series.Samples, sample)
+			}
+			result.Series = append(result.Series, series)
+		}
+	case "scalar":
+		var value []interface{}
+		if err := json.Unmarshal(data.Result, &value); err != nil {
+			return nil, err
+		}
+		sample, err := parseSample(value)
+		if err != nil {
+			return nil, err
+		}
+		result.Series = append(result.Series, Series{Metric: map[string]string{}, Samples: []Sample{sample}})
+	default:
+		return nil, fmt.Errorf("unsupported result type %q", data.ResultType)
+	}
+
+	return result, nil
+}
+
+// parseSample converts a Prometheus [<unix time>, "<value>"] pair into a Sample
+func parseSample(pair []interface{}) (Sample, error) {
+	if len(pair) != 2 {
+		return Sample{}, fmt.Errorf("malformed sample %v", pair)
+	}
+	ts, ok := pair[0].(float64)
+	if !ok {
+		return Sample{}, fmt.Errorf("malformed sample timestamp %v", pair[0])
+	}
+	valueStr, ok := pair[1].(string)
+	if !ok {
+		return Sample{}, fmt.Errorf("malformed sample value %v", pair[1])
+	}
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return Sample{}, fmt.Errorf("malformed sample value %v: %v", pair[1], err)
+	}
+	sec := int64(ts)
+	nsec := int64((ts - float64(sec)) * float64(time.Second))
+	return Sample{Timestamp: time.Unix(sec, nsec).UTC(), Value: value}, nil
+}
+
+// formatPrometheusTime formats the time as a unix timestamp accepted by the Prometheus API
+func formatPrometheusTime(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixNano())/float64(time.Second), 'f', 3, 64)
+}
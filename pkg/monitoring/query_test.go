@@ -0,0 +1,144 @@
+package monitoring
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift/backplane-cli/pkg/ocm"
+	ocmMock "github.com/openshift/backplane-cli/pkg/ocm/mocks"
+)
+
+var _ = Describe("Backplane Monitoring query", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockOcmInterface *ocmMock.MockOCMInterface
+		testToken        string
+		lastRequest      *http.Request
+		responseBody     string
+		responseStatus   int
+		svr              *httptest.Server
+		client           Client
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockOcmInterface = ocmMock.NewMockOCMInterface(mockCtrl)
+		ocm.DefaultOCMInterface = mockOcmInterface
+
+		testToken = "hello123"
+		mockOcmInterface.EXPECT().GetOCMAccessToken().Return(&testToken, nil).AnyTimes()
+
+		responseStatus = http.StatusOK
+		svr = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lastRequest = r
+			w.WriteHeader(responseStatus)
+			_, _ = w.Write([]byte(responseBody))
+		}))
+		client = NewClient(svr.URL+"/backplane/thanos/test123", *svr.Client())
+		MonitoringOpts.Namespace = OpenShiftMonitoringNS
+	})
+
+	AfterEach(func() {
+		svr.Close()
+		mockCtrl.Finish()
+	})
+
+	Context("instant queries", func() {
+		It("should send the query with the OCM token and parse the vector", func() {
+			responseBody = `{"status":"success","data":{"resultType":"vector","result":[
+				{"metric":{"__name__":"up","job":"kubelet"},"value":[1700000000.5,"1"]}]}}`
+
+			result, err := client.Query(THANOS, "up", time.Time{})
+			Expect(err).To(BeNil())
+
+			Expect(lastRequest.URL.Path).To(Equal("/backplane/thanos/test123/api/v1/query"))
+			Expect(lastRequest.URL.Query().Get("query")).To(Equal("up"))
+			Expect(lastRequest.Header.Get("Authorization")).To(Equal("Bearer hello123"))
+			Expect(lastRequest.Header.Get("x-namespace")).To(Equal(OpenShiftMonitoringNS))
+
+			Expect(result.ResultType).To(Equal("vector"))
+			Expect(result.Series).To(HaveLen(1))
+			Expect(result.Series[0].Name()).To(Equal(`up{job="kubelet"}`))
+			Expect(result.Series[0].Samples[0].Value).To(Equal(1.0))
+			Expect(result.Series[0].Samples[0].Timestamp.Unix()).To(Equal(int64(1700000000)))
+		})
+
+		It("should parse a scalar", func() {
+			responseBody = `{"status":"success","data":{"resultType":"scalar","result":[1700000000,"42"]}}`
+
+			result, err := client.Query(THANOS, "42", time.Time{})
+			Expect(err).To(BeNil())
+			Expect(result.Series).To(HaveLen(1))
+			Expect(result.Series[0].Values()).To(Equal([]float64{42}))
+		})
+
+		It("should return the error reported by the API", func() {
+			responseStatus = http.StatusBadRequest
+			responseBody = `{"status":"error","errorType":"bad_data","error":"parse error"}`
+
+			_, err := client.Query(THANOS, "up{", time.Time{})
+			Expect(err).To(MatchError(ContainSubstring("bad_data: parse error")))
+		})
+
+		It("should return the body of a non API error", func() {
+			responseStatus = http.StatusForbidden
+			responseBody = `forbidden`
+
+			_, err := client.Query(THANOS, "up", time.Time{})
+			Expect(err).To(MatchError(ContainSubstring("forbidden")))
+		})
+	})
+
+	Context("range queries", func() {
+		It("should send the range and parse the matrix", func() {
+			responseBody = `{"status":"success","data":{"resultType":"matrix","result":[
+				{"metric":{"__name__":"up"},"values":[[1700000000,"1"],[1700000030,"0"]]}]}}`
+
+			end := time.Unix(1700000030, 0)
+			result, err := client.QueryRange(THANOS, "up", end.Add(-30*time.Second), end, 30*time.Second)
+			Expect(err).To(BeNil())
+
+			Expect(lastRequest.URL.Path).To(Equal("/backplane/thanos/test123/api/v1/query_range"))
+			Expect(lastRequest.URL.Query().Get("start")).To(Equal("1700000000.000"))
+			Expect(lastRequest.URL.Query().Get("end")).To(Equal("1700000030.000"))
+			Expect(lastRequest.URL.Query().Get("step")).To(Equal("30"))
+
+			Expect(result.ResultType).To(Equal("matrix"))
+			Expect(result.Series[0].Values()).To(Equal([]float64{1, 0}))
+		})
+
+		It("should reject an empty range", func() {
+			now := time.Now()
+			_, err := client.QueryRange(THANOS, "up", now, now, time.Second)
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Context("alerts", func() {
+		BeforeEach(func() {
+			responseBody = `{"status":"success","data":{"alerts":[
+				{"labels":{"alertname":"KubePodCrashLooping"},"state":"pending","activeAt":"2024-01-01T10:00:00Z","value":"1"},
+				{"labels":{"alertname":"Watchdog"},"state":"firing","activeAt":"2024-01-01T09:00:00Z","value":"1"}]}}`
+		})
+
+		It("should list all alerts sorted by activation", func() {
+			alerts, err := client.Alerts(THANOS, false)
+			Expect(err).To(BeNil())
+			Expect(lastRequest.URL.Path).To(Equal("/backplane/thanos/test123/api/v1/alerts"))
+			Expect(alerts).To(HaveLen(2))
+			Expect(alerts[0].Labels["alertname"]).To(Equal("Watchdog"))
+		})
+
+		It("should only list firing alerts", func() {
+			alerts, err := client.Alerts(THANOS, true)
+			Expect(err).To(BeNil())
+			Expect(alerts).To(HaveLen(1))
+			Expect(alerts[0].State).To(Equal(AlertStateFiring))
+		})
+	})
+})
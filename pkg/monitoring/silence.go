@@ -0,0 +1,205 @@
+package monitoring
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// Alertmanager v2 API paths
+	alertmanagerSilencesPath = "/api/v2/silences"
+	alertmanagerSilencePath  = "/api/v2/silence/"
+
+	// Silence states reported by the Alertmanager API
+	SilenceStateActive  = "active"
+	SilenceStatePending = "pending"
+	SilenceStateExpired = "expired"
+)
+
+var (
+	// matcherRegexp splits a matcher like alertname=~"Kube.*" into its name, operator and value
+	matcherRegexp = regexp.MustCompile(`^\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*(=~|!~|!=|=)\s*(.*?)\s*$`)
+)
+
+// Matcher is a label matcher of a silence
+type Matcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual bool   `json:"isEqual"`
+}
+
+// SilenceStatus is the state of a silence
+type SilenceStatus struct {
+	State string `json:"state"`
+}
+
+// Silence is a silence as reported by the Alertmanager v2 API
+type Silence struct {
+	ID        string         `json:"id,omitempty"`
+	Matchers  []Matcher      `json:"matchers"`
+	StartsAt  time.Time      `json:"startsAt"`
+	EndsAt    time.Time      `json:"endsAt"`
+	CreatedBy string         `json:"createdBy"`
+	Comment   string         `json:"comment"`
+	Status    *SilenceStatus `json:"status,omitempty"`
+}
+
+type apiSilenceCreated struct {
+	SilenceID string `json:"silenceID"`
+}
+
+// String returns the matcher in PromQL notation
+func (m Matcher) String() string {
+	op := "="
+	switch {
+	case m.IsRegex && m.IsEqual:
+		op = "=~"
+	case m.IsRegex && !m.IsEqual:
+		op = "!~"
+	case !m.IsEqual:
+		op = "!="
+	}
+	return fmt.Sprintf("%s%s%q", m.Name, op, m.Value)
+}
+
+// State returns the state of the silence, empty when unknown
+func (s Silence) State() string {
+	if s.Status == nil {
+		return ""
+	}
+	return s.Status.State
+}
+
+// ParseMatchers parses matchers in PromQL notation, e.g. alertname="Watchdog" or namespace=~"openshift-.*"
+func ParseMatchers(matchers []string) ([]Matcher, error) {
+	parsed := []Matcher{}
+	for _, m := range matchers {
+		parts := matcherRegexp.FindStringSubmatch(m)
+		if parts == nil {
+			return nil, fmt.Errorf("invalid matcher %q, expected <label><op><value> with op one of =|!=|=~|!~", m)
+		}
+		value := parts[3]
+		if unquoted, err := unquoteMatcherValue(value); err == nil {
+			value = unquoted
+		}
+		if value == "" {
+			return nil, fmt.Errorf("invalid matcher %q, the value should not be empty", m)
+		}
+		matcher := Matcher{
+			Name:    parts[1],
+			Value:   value,
+			IsRegex: strings.HasSuffix(parts[2], "~"),
+			IsEqual: !strings.HasPrefix(parts[2], "!"),
+		}
+		if matcher.IsRegex {
+			if _, err := regexp.Compile(matcher.Value); err != nil {
+				return nil, fmt.Errorf("invalid regular expression in matcher %q: %v", m, err)
+			}
+		}
+		parsed = append(parsed, matcher)
+	}
+	return parsed, nil
+}
+
+// unquoteMatcherValue removes the double quotes around a matcher value
+func unquoteMatcherValue(value string) (string, error) {
+	if len(value) < 2 || !strings.HasPrefix(value, `"`) || !strings.HasSuffix(value, `"`) {
+		return value, fmt.Errorf("value is not quoted")
+	}
+	var unquoted string
+	err := json.Unmarshal([]byte(value), &unquoted)
+	return unquoted, err
+}
+
+// ListSilences returns the silences through the backplane alertmanager endpoint, the most recent first.
+// When all is not set, expired silences are left out.
+func (c Client) ListSilences(all bool) ([]Silence, error) {
+	status, body, err := c.doAPI(ALERTMANAGER, http.MethodGet, alertmanagerSilencesPath, url.Values{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := alertmanagerError(status, body); err != nil {
+		return nil, err
+	}
+
+	var apiSilences []Silence
+	if err := json.Unmarshal(body, &apiSilences); err != nil {
+		return nil, fmt.Errorf("failed to parse the silences: %v", err)
+	}
+
+	silences := []Silence{}
+	for _, s := range apiSilences {
+		if !all && s.State() == SilenceStateExpired {
+			continue
+		}
+		silences = append(silences, s)
+	}
+	sort.SliceStable(silences, func(i, j int) bool {
+		return silences[i].StartsAt.After(silences[j].StartsAt)
+	})
+	return silences, nil
+}
+
+// CreateSilence creates the silence through the backplane alertmanager endpoint and returns its ID
+func (c Client) CreateSilence(silence Silence) (string, error) {
+	if len(silence.Matchers) == 0 {
+		return "", fmt.Errorf("a silence needs at least one matcher")
+	}
+	if !silence.EndsAt.After(silence.StartsAt) {
+		return "", fmt.Errorf("the end of the silence should be after its start")
+	}
+	if silence.CreatedBy == "" || silence.Comment == "" {
+		return "", fmt.Errorf("a silence needs a creator and a comment")
+	}
+
+	payload, err := json.Marshal(silence)
+	if err != nil {
+		return "", err
+	}
+	status, body, err := c.doAPI(ALERTMANAGER, http.MethodPost, alertmanagerSilencesPath, url.Values{}, payload)
+	if err != nil {
+		return "", err
+	}
+	if err := alertmanagerError(status, body); err != nil {
+		return "", err
+	}
+
+	var created apiSilenceCreated
+	if err := json.Unmarshal(body, &created); err != nil {
+		return "", fmt.Errorf("failed to parse the created silence: %v", err)
+	}
+	return created.SilenceID, nil
+}
+
+// ExpireSilence expires the silence of the given ID through the backplane alertmanager endpoint
+func (c Client) ExpireSilence(id string) error {
+	if id == "" {
+		return fmt.Errorf("silence ID is empty")
+	}
+	status, body, err := c.doAPI(ALERTMANAGER, http.MethodDelete, alertmanagerSilencePath+url.PathEscape(id), url.Values{}, nil)
+	if err != nil {
+		return err
+	}
+	return alertmanagerError(status, body)
+}
+
+// alertmanagerError returns the error reported by the Alertmanager API, if any
+func alertmanagerError(status int, body []byte) error {
+	if status < 400 {
+		return nil
+	}
+	// errors are reported as a JSON string, or plain text by the backplane proxy
+	message := strings.TrimSpace(string(body))
+	var apiMessage string
+	if err := json.Unmarshal(body, &apiMessage); err == nil {
+		message = apiMessage
+	}
+	return fmt.Errorf("alertmanager request failed: %d %s: %s", status, http.StatusText(status), message)
+}
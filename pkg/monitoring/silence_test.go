@@ -0,0 +1,171 @@
+package monitoring
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift/backplane-cli/pkg/ocm"
+	ocmMock "github.com/openshift/backplane-cli/pkg/ocm/mocks"
+)
+
+var _ = Describe("Backplane Monitoring silences", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockOcmInterface *ocmMock.MockOCMInterface
+		testToken        string
+		lastRequest      *http.Request
+		lastBody         []byte
+		responseBody     string
+		responseStatus   int
+		svr              *httptest.Server
+		client           Client
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockOcmInterface = ocmMock.NewMockOCMInterface(mockCtrl)
+		ocm.DefaultOCMInterface = mockOcmInterface
+
+		testToken = "hello123"
+		mockOcmInterface.EXPECT().GetOCMAccessToken().Return(&testToken, nil).AnyTimes()
+
+		lastRequest = nil
+		responseStatus = http.StatusOK
+		svr = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lastRequest = r
+			lastBody, _ = io.ReadAll(r.Body)
+			w.WriteHeader(responseStatus)
+			_, _ = w.Write([]byte(responseBody))
+		}))
+		client = NewClient(svr.URL+"/backplane/alertmanager/test123", *svr.Client())
+		MonitoringOpts.Namespace = OpenShiftMonitoringNS
+	})
+
+	AfterEach(func() {
+		svr.Close()
+		mockCtrl.Finish()
+	})
+
+	Context("parsing matchers", func() {
+		It("should parse every operator", func() {
+			matchers, err := ParseMatchers([]string{`alertname="Watchdog"`, `severity!=info`, `namespace=~"openshift-.*"`, `job!~kube.*`})
+			Expect(err).To(BeNil())
+			Expect(matchers).To(Equal([]Matcher{
+				{Name: "alertname", Value: "Watchdog", IsEqual: true},
+				{Name: "severity", Value: "info"},
+				{Name: "namespace", Value: "openshift-.*", IsRegex: true, IsEqual: true},
+				{Name: "job", Value: "kube.*", IsRegex: true},
+			}))
+			Expect(matchers[2].String()).To(Equal(`namespace=~"openshift-.*"`))
+		})
+
+		It("should reject invalid matchers", func() {
+			_, err := ParseMatchers([]string{"alertname"})
+			Expect(err).NotTo(BeNil())
+			_, err = ParseMatchers([]string{`alertname=""`})
+			Expect(err).NotTo(BeNil())
+			_, err = ParseMatchers([]string{`alertname=~"("`})
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Context("listing silences", func() {
+		BeforeEach(func() {
+			responseBody = `[
+				{"id":"old","matchers":[{"name":"alertname","value":"A","isRegex":false,"isEqual":true}],"startsAt":"2024-01-01T09:00:00Z","endsAt":"2024-01-01T10:00:00Z","status":{"state":"expired"}},
+				{"id":"new","matchers":[{"name":"alertname","value":"B","isRegex":false,"isEqual":true}],"startsAt":"2024-01-02T09:00:00Z","endsAt":"2024-01-02T10:00:00Z","status":{"state":"active"}}]`
+		})
+
+		It("should leave out expired silences", func() {
+			silences, err := client.ListSilences(false)
+			Expect(err).To(BeNil())
+			Expect(lastRequest.Method).To(Equal(http.MethodGet))
+			Expect(lastRequest.URL.Path).To(Equal("/backplane/alertmanager/test123/api/v2/silences"))
+			Expect(lastRequest.Header.Get("Authorization")).To(Equal("Bearer hello123"))
+			Expect(silences).To(HaveLen(1))
+			Expect(silences[0].ID).To(Equal("new"))
+		})
+
+		It("should list all silences, the most recent first", func() {
+			silences, err := client.ListSilences(true)
+			Expect(err).To(BeNil())
+			Expect(silences).To(HaveLen(2))
+			Expect(silences[0].ID).To(Equal("new"))
+			Expect(silences[1].State()).To(Equal(SilenceStateExpired))
+		})
+	})
+
+	Context("creating silences", func() {
+		It("should post the silence and return its ID", func() {
+			responseBody = `{"silenceID":"abc123"}`
+			start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+			id, err := client.CreateSilence(Silence{
+				Matchers:  []Matcher{{Name: "alertname", Value: "Watchdog", IsEqual: true}},
+				StartsAt:  start,
+				EndsAt:    start.Add(time.Hour),
+				CreatedBy: "user",
+				Comment:   "maintenance",
+			})
+			Expect(err).To(BeNil())
+			Expect(id).To(Equal("abc123"))
+
+			Expect(lastRequest.Method).To(Equal(http.MethodPost))
+			Expect(lastRequest.URL.Path).To(Equal("/backplane/alertmanager/test123/api/v2/silences"))
+			Expect(lastRequest.Header.Get("Content-Type")).To(Equal("application/json"))
+			var posted Silence
+			Expect(json.Unmarshal(lastBody, &posted)).To(Succeed())
+			Expect(posted.ID).To(BeEmpty())
+			Expect(posted.Comment).To(Equal("maintenance"))
+			Expect(posted.EndsAt).To(Equal(start.Add(time.Hour)))
+		})
+
+		It("should reject a silence without comment", func() {
+			start := time.Now()
+			_, err := client.CreateSilence(Silence{
+				Matchers:  []Matcher{{Name: "alertname", Value: "Watchdog", IsEqual: true}},
+				StartsAt:  start,
+				EndsAt:    start.Add(time.Hour),
+				CreatedBy: "user",
+			})
+			Expect(err).NotTo(BeNil())
+			Expect(lastRequest).To(BeNil())
+		})
+
+		It("should return the error reported by the API", func() {
+			responseStatus = http.StatusBadRequest
+			responseBody = `"silence invalid: start time must be before end time"`
+			start := time.Now()
+
+			_, err := client.CreateSilence(Silence{
+				Matchers:  []Matcher{{Name: "alertname", Value: "Watchdog", IsEqual: true}},
+				StartsAt:  start,
+				EndsAt:    start.Add(time.Hour),
+				CreatedBy: "user",
+				Comment:   "maintenance",
+			})
+			Expect(err).To(MatchError(ContainSubstring("silence invalid: start time must be before end time")))
+		})
+	})
+
+	Context("expiring silences", func() {
+		It("should delete the silence", func() {
+			Expect(client.ExpireSilence("abc123")).To(Succeed())
+			Expect(lastRequest.Method).To(Equal(http.MethodDelete))
+			Expect(lastRequest.URL.Path).To(Equal("/backplane/alertmanager/test123/api/v2/silence/abc123"))
+		})
+
+		It("should return the error of an unknown silence", func() {
+			responseStatus = http.StatusNotFound
+			responseBody = `silence not found`
+			Expect(client.ExpireSilence("unknown")).To(MatchError(ContainSubstring("silence not found")))
+		})
+	})
+})
@@ -0,0 +1,196 @@
+package monitoring
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/openshift/backplane-cli/pkg/ocm"
+)
+
+const (
+	// the number of points of each series captured by a snapshot
+	snapshotRangePoints = 120
+
+	// the query listing the scrape targets which are down
+	snapshotDownTargetsQuery = "up == 0"
+)
+
+// SnapshotQuery is a cluster health PromQL series captured by a snapshot
+type SnapshotQuery struct {
+	Name  string
+	Query string
+}
+
+// SnapshotQueries are the cluster health series captured by every snapshot
+var SnapshotQueries = []SnapshotQuery{
+	{"cluster-operators-degraded", `cluster_operator_conditions{condition="Degraded"} == 1`},
+	{"cluster-operators-unavailable", `cluster_operator_conditions{condition="Available"} == 0`},
+	{"nodes-not-ready", `kube_node_status_condition{condition="Ready",status="true"} == 0`},
+	{"node-cpu-utilisation", `1 - avg by (instance) (rate(node_cpu_seconds_total{mode="idle"}[5m]))`},
+	{"node-memory-available-ratio", `node_memory_MemAvailable_bytes / node_memory_MemTotal_bytes`},
+	{"apiserver-error-ratio", `sum(rate(apiserver_request_total{code=~"5.."}[5m])) / sum(rate(apiserver_request_total[5m]))`},
+	{"etcd-leader-changes", `increase(etcd_server_leader_changes_seen_total[15m])`},
+	{"pods-crashlooping", `sum by (namespace) (kube_pod_container_status_waiting_reason{reason="CrashLoopBackOff"})`},
+}
+
+// Snapshot is the point-in-time state of the monitoring stack of a cluster
+type Snapshot struct {
+	ClusterID   string
+	TakenAt     time.Time
+	Since       time.Duration
+	Step        time.Duration
+	Cluster     *cmv1.Cluster
+	Alerts      []Alert
+	DownTargets *QueryResult
+	Series      map[string]*QueryResult
+	// Errors holds the data which could not be captured, by name
+	Errors map[string]string
+}
+
+// snapshotMetadata is the description of the snapshot written in the archive
+type snapshotMetadata struct {
+	ClusterID string            `json:"cluster_id"`
+	TakenAt   time.Time         `json:"taken_at"`
+	Since     string            `json:"since"`
+	Step      string            `json:"step"`
+	Queries   map[string]string `json:"queries"`
+	Errors    map[string]string `json:"errors,omitempty"`
+}
+
+// TakeSnapshot captures the firing alerts, the down targets and the cluster health series over the given
+// duration until now, together with the OCM description of the cluster.
+// Data which cannot be captured is recorded in the errors of the snapshot rather than failing it.
+func (c Client) TakeSnapshot(clusterID string, since time.Duration) (*Snapshot, error) {
+	if since <= 0 {
+		return nil, fmt.Errorf("since should be a positive duration")
+	}
+
+	end := time.Now()
+	snapshot := &Snapshot{
+		ClusterID: clusterID,
+		TakenAt:   end,
+		Since:     since,
+		Step:      max(since/snapshotRangePoints, time.Second).Round(time.Second),
+		Series:    map[string]*QueryResult{},
+		Errors:    map[string]string{},
+	}
+
+	cluster, err := ocm.DefaultOCMInterface.GetClusterInfoByID(clusterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the cluster %s from OCM: %v", clusterID, err)
+	}
+	snapshot.Cluster = cluster
+
+	snapshot.Alerts, err = c.Alerts(THANOS, true)
+	if err != nil {
+		logger.Warnf("Failed to capture the firing alerts: %v", err)
+		snapshot.Errors["alerts"] = err.Error()
+	}
+
+	snapshot.DownTargets, err = c.Query(THANOS, snapshotDownTargetsQuery, end)
+	if err != nil {
+		logger.Warnf("Failed to capture the down targets: %v", err)
+		snapshot.Errors["down-targets"] = err.Error()
+	}
+
+	for _, q := range SnapshotQueries {
+		result, err := c.QueryRange(THANOS, q.Query, end.Add(-since), end, snapshot.Step)
+		if err != nil {
+			logger.Warnf("Failed to capture %s: %v", q.Name, err)
+			snapshot.Errors[q.Name] = err.Error()
+			continue
+		}
+		snapshot.Series[q.Name] = result
+	}
+
+	return snapshot, nil
+}
+
+// Filename returns the default name of the archive of the snapshot
+func (s *Snapshot) Filename() string {
+	return fmt.Sprintf("monitoring-snapshot-%s-%s.tar.gz", s.ClusterID, s.TakenAt.UTC().Format("20060102T150405Z"))
+}
+
+// WriteArchive writes the snapshot as a gzipped tarball
+func (s *Snapshot) WriteArchive(w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	metadata := snapshotMetadata{
+		ClusterID: s.ClusterID,
+		TakenAt:   s.TakenAt.UTC(),
+		Since:     s.Since.String(),
+		Step:      s.Step.String(),
+		Queries:   map[string]string{"down-targets": snapshotDownTargetsQuery},
+		Errors:    s.Errors,
+	}
+	for _, q := range SnapshotQueries {
+		metadata.Queries[q.Name] = q.Query
+	}
+
+	files := map[string]interface{}{"metadata.json": metadata}
+	names := []string{"metadata.json"}
+	if _, failed := s.Errors["alerts"]; !failed {
+		files["alerts.json"] = s.Alerts
+		names = append(names, "alerts.json")
+	}
+	if s.DownTargets != nil {
+		files["down-targets.json"] = s.DownTargets
+		names = append(names, "down-targets.json")
+	}
+	for _, q := range SnapshotQueries {
+		if result, ok := s.Series[q.Name]; ok {
+			name := fmt.Sprintf("series/%s.json", q.Name)
+			files[name] = result
+			names = append(names, name)
+		}
+	}
+
+	for _, name := range names {
+		content, err := json.MarshalIndent(files[name], "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := writeTarFile(tw, name, content, s.TakenAt); err != nil {
+			return err
+		}
+	}
+
+	if s.Cluster != nil {
+		var cluster bytes.Buffer
+		if err := cmv1.MarshalCluster(s.Cluster, &cluster); err != nil {
+			return err
+		}
+		if err := writeTarFile(tw, "cluster.json", cluster.Bytes(), s.TakenAt); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// writeTarFile adds a regular file to the tarball
+func writeTarFile(tw *tar.Writer, name string, content []byte, modTime time.Time) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(content)),
+		ModTime: modTime,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := tw.Write(content)
+	return err
+}
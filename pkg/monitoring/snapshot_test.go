@@ -0,0 +1,115 @@
+package monitoring
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift/backplane-cli/pkg/ocm"
+	ocmMock "github.com/openshift/backplane-cli/pkg/ocm/mocks"
+)
+
+var _ = Describe("Backplane Monitoring snapshot", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockOcmInterface *ocmMock.MockOCMInterface
+		testToken        string
+		svr              *httptest.Server
+		client           Client
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockOcmInterface = ocmMock.NewMockOCMInterface(mockCtrl)
+		ocm.DefaultOCMInterface = mockOcmInterface
+
+		testToken = "hello123"
+		mockOcmInterface.EXPECT().GetOCMAccessToken().Return(&testToken, nil).AnyTimes()
+		cluster, _ := cmv1.NewCluster().ID("test123").Name("test-cluster").Build()
+		mockOcmInterface.EXPECT().GetClusterInfoByID("test123").Return(cluster, nil).AnyTimes()
+
+		svr = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case strings.HasSuffix(r.URL.Path, prometheusAlertsPath):
+				_, _ = w.Write([]byte(`{"status":"success","data":{"alerts":[
+					{"labels":{"alertname":"Watchdog"},"state":"firing","activeAt":"2024-01-01T09:00:00Z","value":"1"}]}}`))
+			case strings.HasSuffix(r.URL.Path, prometheusQueryPath):
+				_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[
+					{"metric":{"__name__":"up","job":"kubelet"},"value":[1700000000,"0"]}]}}`))
+			case strings.Contains(r.URL.Query().Get("query"), "etcd"):
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"status":"error","errorType":"bad_data","error":"unknown"}`))
+			default:
+				_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[]}}`))
+			}
+		}))
+		client = NewClient(svr.URL+"/backplane/thanos/test123", *svr.Client())
+		MonitoringOpts.Namespace = OpenShiftMonitoringNS
+	})
+
+	AfterEach(func() {
+		svr.Close()
+		mockCtrl.Finish()
+	})
+
+	readArchive := func(archive []byte) map[string][]byte {
+		files := map[string][]byte{}
+		gz, err := gzip.NewReader(bytes.NewReader(archive))
+		Expect(err).To(BeNil())
+		tr := tar.NewReader(gz)
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			Expect(err).To(BeNil())
+			content, err := io.ReadAll(tr)
+			Expect(err).To(BeNil())
+			files[header.Name] = content
+		}
+		return files
+	}
+
+	It("should capture the monitoring data and the cluster description", func() {
+		snapshot, err := client.TakeSnapshot("test123", 2*time.Hour)
+		Expect(err).To(BeNil())
+		Expect(snapshot.Alerts).To(HaveLen(1))
+		Expect(snapshot.DownTargets.Series).To(HaveLen(1))
+		Expect(snapshot.Series).To(HaveLen(len(SnapshotQueries) - 1))
+		Expect(snapshot.Errors).To(HaveKey("etcd-leader-changes"))
+		Expect(snapshot.Step).To(Equal(time.Minute))
+		Expect(snapshot.Filename()).To(HavePrefix("monitoring-snapshot-test123-"))
+
+		var archive bytes.Buffer
+		Expect(snapshot.WriteArchive(&archive)).To(Succeed())
+		files := readArchive(archive.Bytes())
+
+		Expect(files).To(HaveKey("alerts.json"))
+		Expect(files).To(HaveKey("down-targets.json"))
+		Expect(files).To(HaveKey("series/apiserver-error-ratio.json"))
+		Expect(files).NotTo(HaveKey("series/etcd-leader-changes.json"))
+		Expect(string(files["cluster.json"])).To(ContainSubstring(`"test-cluster"`))
+
+		var metadata snapshotMetadata
+		Expect(json.Unmarshal(files["metadata.json"], &metadata)).To(Succeed())
+		Expect(metadata.ClusterID).To(Equal("test123"))
+		Expect(metadata.Since).To(Equal("2h0m0s"))
+		Expect(metadata.Errors).To(HaveKey("etcd-leader-changes"))
+	})
+
+	It("should reject a non positive duration", func() {
+		_, err := client.TakeSnapshot("test123", 0)
+		Expect(err).NotTo(BeNil())
+	})
+})
@@ -316,6 +316,21 @@ func (mr *MockOCMInterfaceMockRecorder) IsProduction() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsProduction", reflect.TypeOf((*MockOCMInterface)(nil).IsProduction))
 }
 
+// ListClusterAccessRequests mocks base method.
+func (m *MockOCMInterface) ListClusterAccessRequests(ocmConnection *sdk.Connection, clusterID string, states []v1.AccessRequestState) ([]*v1.AccessRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListClusterAccessRequests", ocmConnection, clusterID, states)
+	ret0, _ := ret[0].([]*v1.AccessRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListClusterAccessRequests indicates an expected call of ListClusterAccessRequests.
+func (mr *MockOCMInterfaceMockRecorder) ListClusterAccessRequests(ocmConnection, clusterID, states any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClusterAccessRequests", reflect.TypeOf((*MockOCMInterface)(nil).ListClusterAccessRequests), ocmConnection, clusterID, states)
+}
+
 // SetupOCMConnection mocks base method.
 func (m *MockOCMInterface) SetupOCMConnection() (*sdk.Connection, error) {
 	m.ctrl.T.Helper()
@@ -40,6 +40,7 @@ type OCMInterface interface {
 	GetOCMEnvironmentWithConn(connection *ocmsdk.Connection) (*cmv1.Environment, error)
 	IsClusterAccessProtectionEnabled(ocmConnection *ocmsdk.Connection, clusterID string) (bool, error)
 	GetClusterActiveAccessRequest(ocmConnection *ocmsdk.Connection, clusterID string) (*acctrspv1.AccessRequest, error)
+	ListClusterAccessRequests(ocmConnection *ocmsdk.Connection, clusterID string, states []acctrspv1.AccessRequestState) ([]*acctrspv1.AccessRequest, error)
 	CreateClusterAccessRequest(ocmConnection *ocmsdk.Connection, clusterID, reason, jiraIssueID, approvalDuration string) (*acctrspv1.AccessRequest, error)
 	CreateAccessRequestDecision(ocmConnection *ocmsdk.Connection, accessRequest *acctrspv1.AccessRequest, decision acctrspv1.DecisionDecision, justification string) (*acctrspv1.Decision, error)
 	GetTrustedIPList(*ocmsdk.Connection) (*cmv1.TrustedIpList, error)
@@ -477,6 +478,40 @@ func (o *DefaultOCMInterfaceImpl) GetClusterActiveAccessRequest(ocmConnection *o
 	return accessRequest, nil
 }
 
+// ListClusterAccessRequests returns the access requests of the cluster in the given states, or in any state
+// when none is given, the most recent first
+func (o *DefaultOCMInterfaceImpl) ListClusterAccessRequests(ocmConnection *ocmsdk.Connection, clusterID string, states []acctrspv1.AccessRequestState) ([]*acctrspv1.AccessRequest, error) {
+	search := fmt.Sprintf("cluster_id = '%s'", clusterID)
+	if len(states) > 0 {
+		stateSearches := []string{}
+		for _, state := range states {
+			stateSearches = append(stateSearches, fmt.Sprintf("status.state = '%s'", state))
+		}
+		search += " and (" + strings.Join(stateSearches, " or ") + ")"
+	}
+
+	accessRequests := []*acctrspv1.AccessRequest{}
+	page := 1
+	for {
+		listResponse, err := ocmConnection.AccessTransparency().V1().AccessRequests().List().
+			Search(search).
+			Order("created_at desc").
+			Page(page).
+			Size(100).
+			Send()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list access requests: %v", err)
+		}
+		accessRequests = append(accessRequests, listResponse.Items().Slice()...)
+		if listResponse.Size() < 100 || len(accessRequests) >= listResponse.Total() {
+			break
+		}
+		page++
+	}
+
+	return accessRequests, nil
+}
+
 func (o *DefaultOCMInterfaceImpl) CreateClusterAccessRequest(ocmConnection *ocmsdk.Connection, clusterID, justification, jiraIssueID, approvalDuration string) (*acctrspv1.AccessRequest, error) {
 	requestBuilder := acctrspv1.NewAccessRequestPostRequest().
 		ClusterId(clusterID).
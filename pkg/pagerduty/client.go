@@ -15,6 +15,9 @@ type PagerDutyClient interface {
 	ListIncidents(pdApi.ListIncidentsOptions) (*pdApi.ListIncidentsResponse, error)
 	ListIncidentAlerts(incidentID string) (*pdApi.ListAlertsResponse, error)
 	GetServiceWithContext(ctx context.Context, serviceID string, opts *pdApi.GetServiceOptions) (*pdApi.Service, error)
+	GetCurrentUser(opts pdApi.GetCurrentUserOptions) (*pdApi.User, error)
+	ManageIncidents(from string, incidents []pdApi.ManageIncidentsOptions) (*pdApi.ListIncidentsResponse, error)
+	CreateIncidentNote(incidentID string, note pdApi.IncidentNote) (*pdApi.IncidentNote, error)
 }
 
 type DefaultPagerDutyClientImpl struct {
@@ -51,3 +54,15 @@ func (c *DefaultPagerDutyClientImpl) ListIncidentAlerts(incidentID string) (*pdA
 func (c *DefaultPagerDutyClientImpl) GetServiceWithContext(ctx context.Context, serviceID string, opts *pdApi.GetServiceOptions) (*pdApi.Service, error) {
 	return c.client.GetServiceWithContext(ctx, serviceID, opts)
 }
+
+func (c *DefaultPagerDutyClientImpl) GetCurrentUser(opts pdApi.GetCurrentUserOptions) (*pdApi.User, error) {
+	return c.client.GetCurrentUserWithContext(context.TODO(), opts)
+}
+
+func (c *DefaultPagerDutyClientImpl) ManageIncidents(from string, incidents []pdApi.ManageIncidentsOptions) (*pdApi.ListIncidentsResponse, error) {
+	return c.client.ManageIncidentsWithContext(context.TODO(), from, incidents)
+}
+
+func (c *DefaultPagerDutyClientImpl) CreateIncidentNote(incidentID string, note pdApi.IncidentNote) (*pdApi.IncidentNote, error) {
+	return c.client.CreateIncidentNoteWithContext(context.TODO(), incidentID, note)
+}
@@ -0,0 +1,149 @@
+package pagerduty
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	pdApi "github.com/PagerDuty/go-pagerduty"
+	logger "github.com/sirupsen/logrus"
+)
+
+const (
+	StatusResolved = "resolved"
+
+	// incidentsPageSize is the maximal page size of the PD API
+	incidentsPageSize = 100
+)
+
+// Incident is a PD incident, along with the name of the cluster it fires for
+type Incident struct {
+	ID          string
+	Number      uint
+	Title       string
+	Status      string
+	Urgency     string
+	CreatedAt   time.Time
+	WebURL      string
+	ServiceID   string
+	ClusterName string
+}
+
+// ParseIncidentID returns the incident ID of an incident ID or an incident URL
+func ParseIncidentID(incident string) string {
+	if strings.Contains(incident, "/incidents/") {
+		return incident[strings.LastIndex(incident, "/")+1:]
+	}
+	return incident
+}
+
+// getCurrentUser returns the PD user owning the API token
+func (pd *PagerDuty) getCurrentUser() (*pdApi.User, error) {
+	user, err := pd.client.GetCurrentUser(pdApi.GetCurrentUserOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the current PagerDuty user: %v", err)
+	}
+	return user, nil
+}
+
+// ListMyIncidents returns the incidents in the given statuses which are assigned to the current user,
+// including the ones escalated to the user, the most recent first
+func (pd *PagerDuty) ListMyIncidents(statuses []string) ([]Incident, error) {
+	user, err := pd.getCurrentUser()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := pdApi.ListIncidentsOptions{
+		Limit:    incidentsPageSize,
+		Statuses: statuses,
+		UserIDs:  []string{user.ID},
+		SortBy:   "created_at:desc",
+	}
+
+	var incidents []Incident
+	clusterNames := map[string]string{}
+	for {
+		response, err := pd.client.ListIncidents(opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the PagerDuty incidents: %v", err)
+		}
+
+		for _, incident := range response.Incidents {
+			// the incidents of a service all fire for the same cluster
+			clusterName, ok := clusterNames[incident.Service.ID]
+			if !ok {
+				clusterName, err = pd.GetClusterName(incident.Service.ID)
+				if err != nil {
+					logger.Warnf("Failed to get cluster name for service %s: %v", incident.Service.ID, err)
+					clusterName = "N/A"
+				}
+				clusterNames[incident.Service.ID] = clusterName
+			}
+
+			createdAt, _ := time.Parse(time.RFC3339, incident.CreatedAt)
+			incidents = append(incidents, Incident{
+				ID:          incident.ID,
+				Number:      incident.IncidentNumber,
+				Title:       incident.Title,
+				Status:      incident.Status,
+				Urgency:     incident.Urgency,
+				CreatedAt:   createdAt,
+				WebURL:      incident.HTMLURL,
+				ServiceID:   incident.Service.ID,
+				ClusterName: clusterName,
+			})
+		}
+
+		if !response.More {
+			return incidents, nil
+		}
+		opts.Offset += incidentsPageSize
+	}
+}
+
+// AcknowledgeIncident acknowledges the incident on behalf of the current user
+func (pd *PagerDuty) AcknowledgeIncident(incidentID string) error {
+	return pd.updateIncidentStatus(incidentID, StatusAcknowledged)
+}
+
+// ResolveIncident resolves the incident on behalf of the current user
+func (pd *PagerDuty) ResolveIncident(incidentID string) error {
+	return pd.updateIncidentStatus(incidentID, StatusResolved)
+}
+
+func (pd *PagerDuty) updateIncidentStatus(incidentID, status string) error {
+	user, err := pd.getCurrentUser()
+	if err != nil {
+		return err
+	}
+
+	_, err = pd.client.ManageIncidents(user.Email, []pdApi.ManageIncidentsOptions{{
+		ID:     incidentID,
+		Status: status,
+	}})
+	if err != nil {
+		return fmt.Errorf("failed to update the status of incident %s to %s: %v", incidentID, status, err)
+	}
+
+	return nil
+}
+
+// AddIncidentNote adds a note to the incident on behalf of the current user
+func (pd *PagerDuty) AddIncidentNote(incidentID, content string) error {
+	user, err := pd.getCurrentUser()
+	if err != nil {
+		return err
+	}
+
+	// the PD client sends the user summary as the mandatory From header, which expects the user email
+	_, err = pd.client.CreateIncidentNote(incidentID, pdApi.IncidentNote{
+		User:    pdApi.APIObject{ID: user.ID, Type: "user_reference", Summary: user.Email},
+		Content: content,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add a note to incident %s: %v", incidentID, err)
+	}
+
+	return nil
+}
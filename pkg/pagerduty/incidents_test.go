@@ -0,0 +1,109 @@
+package pagerduty
+
+import (
+	"context"
+	"errors"
+
+	pdApi "github.com/PagerDuty/go-pagerduty"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	pdMock "github.com/openshift/backplane-cli/pkg/pagerduty/mocks"
+)
+
+var _ = Describe("Pagerduty incidents", func() {
+	var (
+		mockCtrl     *gomock.Controller
+		mockPdClient *pdMock.MockPagerDutyClient
+		pagerDuty    *PagerDuty
+		currentUser  *pdApi.User
+	)
+
+	incident := func(id, serviceID string) pdApi.Incident {
+		return pdApi.Incident{
+			APIObject: pdApi.APIObject{ID: id, HTMLURL: "https://example.pagerduty.com/incidents/" + id},
+			Title:     "alert on " + serviceID,
+			Status:    StatusTriggered,
+			CreatedAt: "2026-01-02T03:04:05Z",
+			Service:   pdApi.APIObject{ID: serviceID},
+		}
+	}
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockPdClient = pdMock.NewMockPagerDutyClient(mockCtrl)
+		pagerDuty = NewPagerDuty(mockPdClient)
+		currentUser = &pdApi.User{APIObject: pdApi.APIObject{ID: "user-1"}, Email: "sre@example.com"}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("parse incident ID", func() {
+		It("should return the ID of an incident URL", func() {
+			Expect(ParseIncidentID("https://example.pagerduty.com/incidents/Q0ZNH7TDQBOO54")).To(Equal("Q0ZNH7TDQBOO54"))
+			Expect(ParseIncidentID("Q0ZNH7TDQBOO54")).To(Equal("Q0ZNH7TDQBOO54"))
+		})
+	})
+
+	Context("list my incidents", func() {
+		It("should list the incidents of every page, resolving the cluster name once per service", func() {
+			mockPdClient.EXPECT().GetCurrentUser(gomock.Any()).Return(currentUser, nil)
+			gomock.InOrder(
+				mockPdClient.EXPECT().ListIncidents(gomock.Any()).DoAndReturn(func(opts pdApi.ListIncidentsOptions) (*pdApi.ListIncidentsResponse, error) {
+					Expect(opts.UserIDs).To(Equal([]string{"user-1"}))
+					Expect(opts.Statuses).To(Equal([]string{StatusTriggered}))
+					Expect(opts.Offset).To(BeZero())
+					return &pdApi.ListIncidentsResponse{
+						APIListObject: pdApi.APIListObject{More: true},
+						Incidents:     []pdApi.Incident{incident("inc-1", "svc-1"), incident("inc-2", "svc-1")},
+					}, nil
+				}),
+				mockPdClient.EXPECT().ListIncidents(gomock.Any()).DoAndReturn(func(opts pdApi.ListIncidentsOptions) (*pdApi.ListIncidentsResponse, error) {
+					Expect(opts.Offset).To(BeEquivalentTo(incidentsPageSize))
+					return &pdApi.ListIncidentsResponse{Incidents: []pdApi.Incident{incident("inc-3", "svc-2")}}, nil
+				}),
+			)
+			mockPdClient.EXPECT().GetServiceWithContext(context.TODO(), "svc-1", gomock.Any()).Return(&pdApi.Service{Description: "cluster-one description"}, nil)
+			mockPdClient.EXPECT().GetServiceWithContext(context.TODO(), "svc-2", gomock.Any()).Return(nil, errors.New("not found"))
+
+			incidents, err := pagerDuty.ListMyIncidents([]string{StatusTriggered})
+			Expect(err).To(BeNil())
+			Expect(incidents).To(HaveLen(3))
+			Expect(incidents[0].ClusterName).To(Equal("cluster-one"))
+			Expect(incidents[1].ClusterName).To(Equal("cluster-one"))
+			Expect(incidents[2].ClusterName).To(Equal("N/A"))
+			Expect(incidents[0].CreatedAt.Year()).To(Equal(2026))
+		})
+	})
+
+	Context("update incidents", func() {
+		It("should acknowledge the incident on behalf of the current user", func() {
+			mockPdClient.EXPECT().GetCurrentUser(gomock.Any()).Return(currentUser, nil)
+			mockPdClient.EXPECT().ManageIncidents("sre@example.com", []pdApi.ManageIncidentsOptions{{ID: "inc-1", Status: StatusAcknowledged}}).
+				Return(&pdApi.ListIncidentsResponse{}, nil)
+
+			Expect(pagerDuty.AcknowledgeIncident("inc-1")).To(Succeed())
+		})
+
+		It("should report the failure to resolve the incident", func() {
+			mockPdClient.EXPECT().GetCurrentUser(gomock.Any()).Return(currentUser, nil)
+			mockPdClient.EXPECT().ManageIncidents("sre@example.com", gomock.Any()).Return(nil, errors.New("forbidden"))
+
+			Expect(pagerDuty.ResolveIncident("inc-1")).To(MatchError(ContainSubstring("failed to update the status of incident inc-1 to resolved: forbidden")))
+		})
+
+		It("should add a note from the current user", func() {
+			mockPdClient.EXPECT().GetCurrentUser(gomock.Any()).Return(currentUser, nil)
+			mockPdClient.EXPECT().CreateIncidentNote("inc-1", gomock.Any()).DoAndReturn(func(_ string, note pdApi.IncidentNote) (*pdApi.IncidentNote, error) {
+				Expect(note.User.Summary).To(Equal("sre@example.com"))
+				Expect(note.Content).To(Equal("looking into it"))
+				return &note, nil
+			})
+
+			Expect(pagerDuty.AddIncidentNote("inc-1", "looking into it")).To(Succeed())
+		})
+	})
+})
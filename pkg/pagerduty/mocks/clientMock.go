@@ -60,6 +60,36 @@ func (mr *MockPagerDutyClientMockRecorder) Connect(authToken any, options ...any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Connect", reflect.TypeOf((*MockPagerDutyClient)(nil).Connect), varargs...)
 }
 
+// CreateIncidentNote mocks base method.
+func (m *MockPagerDutyClient) CreateIncidentNote(incidentID string, note pagerduty.IncidentNote) (*pagerduty.IncidentNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIncidentNote", incidentID, note)
+	ret0, _ := ret[0].(*pagerduty.IncidentNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIncidentNote indicates an expected call of CreateIncidentNote.
+func (mr *MockPagerDutyClientMockRecorder) CreateIncidentNote(incidentID, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIncidentNote", reflect.TypeOf((*MockPagerDutyClient)(nil).CreateIncidentNote), incidentID, note)
+}
+
+// GetCurrentUser mocks base method.
+func (m *MockPagerDutyClient) GetCurrentUser(opts pagerduty.GetCurrentUserOptions) (*pagerduty.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentUser", opts)
+	ret0, _ := ret[0].(*pagerduty.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentUser indicates an expected call of GetCurrentUser.
+func (mr *MockPagerDutyClientMockRecorder) GetCurrentUser(opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentUser", reflect.TypeOf((*MockPagerDutyClient)(nil).GetCurrentUser), opts)
+}
+
 // GetServiceWithContext mocks base method.
 func (m *MockPagerDutyClient) GetServiceWithContext(ctx context.Context, serviceID string, opts *pagerduty.GetServiceOptions) (*pagerduty.Service, error) {
 	m.ctrl.T.Helper()
@@ -104,3 +134,18 @@ func (mr *MockPagerDutyClientMockRecorder) ListIncidents(arg0 any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIncidents", reflect.TypeOf((*MockPagerDutyClient)(nil).ListIncidents), arg0)
 }
+
+// ManageIncidents mocks base method.
+func (m *MockPagerDutyClient) ManageIncidents(from string, incidents []pagerduty.ManageIncidentsOptions) (*pagerduty.ListIncidentsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ManageIncidents", from, incidents)
+	ret0, _ := ret[0].(*pagerduty.ListIncidentsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ManageIncidents indicates an expected call of ManageIncidents.
+func (mr *MockPagerDutyClientMockRecorder) ManageIncidents(from, incidents any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ManageIncidents", reflect.TypeOf((*MockPagerDutyClient)(nil).ManageIncidents), from, incidents)
+}
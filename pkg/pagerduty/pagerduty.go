@@ -9,6 +9,8 @@ import (
 
 	pdApi "github.com/PagerDuty/go-pagerduty"
 	logger "github.com/sirupsen/logrus"
+
+	"github.com/openshift/backplane-cli/pkg/cli/config"
 )
 
 // Alert struct represents the data contained in an alert.
@@ -61,6 +63,26 @@ func NewWithToken(authToken string, options ...pdApi.ClientOptions) (*PagerDuty,
 
 }
 
+// NewWithConfiguredKey returns a PagerDuty client authenticated with the PD API key of the backplane configuration
+func NewWithConfiguredKey() (*PagerDuty, error) {
+	bpConfig, err := config.GetBackplaneConfiguration()
+	if err != nil {
+		return nil, err
+	}
+	if bpConfig.PagerDutyAPIKey == "" {
+		return nil, fmt.Errorf("please make sure the PD API Key is configured correctly in the config file, running 'ocm-backplane config set pd-key <api-key>'")
+	}
+	apiKey, err := bpConfig.GetPagerDutyAPIKey()
+	if err != nil {
+		return nil, err
+	}
+	pd, err := NewWithToken(apiKey)
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize the client: %v", err)
+	}
+	return pd, nil
+}
+
 // GetIncidentAlerts returns all the alerts belonging to a particular incident.
 func (pd *PagerDuty) GetIncidentAlerts(incidentID string) ([]Alert, error) {
 	var alerts []Alert
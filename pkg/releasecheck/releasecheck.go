@@ -0,0 +1,331 @@
+package releasecheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Masterminds/semver"
+	logger "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/backplane-cli/internal/github"
+	"github.com/openshift/backplane-cli/internal/upgrade"
+	"github.com/openshift/backplane-cli/pkg/cli/config"
+	"github.com/openshift/backplane-cli/pkg/info"
+)
+
+const (
+	// The file under the backplane config directory holding the last release check
+	stateFileName = "release-check.json"
+
+	// CheckTTL is how long the last release check is trusted before checking the releases again
+	CheckTTL = 12 * time.Hour
+	// NoticeInterval is the minimal interval between two notices of a new release
+	NoticeInterval = 24 * time.Hour
+
+	// The maximal duration of the background refresh
+	refreshTimeout = 10 * time.Second
+	// WaitTimeout is how long a command waits for the background refresh before exiting
+	WaitTimeout = 2 * time.Second
+	// The maximal number of changes listed by a notice
+	maxNoticeChanges = 10
+
+	releasesURL = "https://github.com/openshift/backplane-cli/releases"
+)
+
+// GitServer lists the releases, it is implemented by the internal/github client
+type GitServer interface {
+	GetLatestVersion(ctx context.Context) (upgrade.Release, error)
+	ListReleases(ctx context.Context) ([]upgrade.Release, error)
+}
+
+// ReleaseNotes are the changes of a release
+type ReleaseNotes struct {
+	Version string   `json:"version"`
+	Changes []string `json:"changes,omitempty"`
+}
+
+// State is the last release check, along with the last notice
+type State struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Latest    string    `json:"latest,omitempty"`
+	// Releases are the notes of the releases newer than the version running at check time, the latest first
+	Releases   []ReleaseNotes `json:"releases,omitempty"`
+	NotifiedAt time.Time      `json:"notified_at,omitempty"`
+}
+
+var (
+	newGitServer = func() GitServer { return github.NewClient() }
+	getVersion   = func() string { return info.DefaultInfoService.GetVersion() }
+	now          = time.Now
+
+	noticeOutput io.Writer = os.Stderr
+
+	// stateMutex serializes the updates of the state file by the notice and the background refresh
+	stateMutex sync.Mutex
+	// refreshes are the background refreshes started by Notify, waited for by Wait
+	refreshes      []<-chan struct{}
+	refreshesMutex sync.Mutex
+
+	// changePattern matches a change of the changelog of a release, optionally prefixed by its commit
+	changePattern = regexp.MustCompile(`^[*-]\s+(?:[0-9a-f]{7,40}\s+)?(.+)$`)
+)
+
+// Notify prints a notice of the new release on stderr, at most once per NoticeInterval.
+// The notice relies on the last release check, which is refreshed in the background once older than CheckTTL
+// so that neither the latency of GitHub nor its unavailability affect the command.
+// The returned channel is closed once the refresh is over, callers do not need to wait for it
+// as the root command waits for the refreshes in progress with Wait before exiting.
+func Notify(cmd *cobra.Command, bpConfig config.BackplaneConfiguration) <-chan struct{} {
+	done := make(chan struct{})
+	ctx := context.Background()
+	if cmd != nil && cmd.Context() != nil {
+		ctx = cmd.Context()
+	}
+	if bpConfig.DisableUpdateCheck {
+		close(done)
+		return done
+	}
+
+	stateMutex.Lock()
+	state, err := readState()
+	if err != nil {
+		logger.Debugf("Could not read the last release check: %v", err)
+	}
+	if printNotice(state) {
+		state.NotifiedAt = now()
+		if err := writeState(state); err != nil {
+			logger.Debugf("Could not record the release notice: %v", err)
+		}
+	}
+	stateMutex.Unlock()
+
+	if now().Sub(state.CheckedAt) < CheckTTL {
+		close(done)
+		return done
+	}
+
+	refreshesMutex.Lock()
+	refreshes = append(refreshes, done)
+	refreshesMutex.Unlock()
+
+	go func() {
+		defer close(done)
+		// the refresh outlives the command context
+		if err := Refresh(context.WithoutCancel(ctx)); err != nil {
+			logger.Debugf("Could not check the latest release: %v", err)
+		}
+	}()
+
+	return done
+}
+
+// Wait waits for the background refreshes started by Notify, at most timeout.
+// A refresh still in progress is abandoned, the next command refreshes the check again.
+// It returns whether the refreshes are over.
+func Wait(timeout time.Duration) bool {
+	refreshesMutex.Lock()
+	pending := refreshes
+	refreshesMutex.Unlock()
+
+	expired := time.After(timeout)
+	for _, done := range pending {
+		select {
+		case <-done:
+		case <-expired:
+			logger.Debugf("Not waiting any longer for the release check")
+			return false
+		}
+	}
+	return true
+}
+
+// Refresh checks the latest release and the changelog of the releases newer than the running version.
+// A failed check is recorded too, so that it is not retried until CheckTTL elapses.
+func Refresh(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, refreshTimeout)
+	defer cancel()
+
+	var (
+		notes   []ReleaseNotes
+		git     = newGitServer()
+		current = getVersion()
+	)
+
+	latest, err := git.GetLatestVersion(ctx)
+	if err == nil {
+		var releases []upgrade.Release
+		releases, err = git.ListReleases(ctx)
+		notes = releaseNotes(releases, current, latest.TagName)
+	}
+
+	stateMutex.Lock()
+	defer stateMutex.Unlock()
+
+	state, readErr := readState()
+	if readErr != nil {
+		logger.Debugf("Could not read the last release check: %v", readErr)
+	}
+	state.CheckedAt = now()
+	if err == nil {
+		state.Latest = latest.TagName
+		state.Releases = notes
+	}
+
+	if writeErr := writeState(state); writeErr != nil {
+		return errors.Join(err, writeErr)
+	}
+
+	return err
+}
+
+// printNotice prints the notice of the latest release when newer than the running version,
+// unless a notice was printed during the last NoticeInterval. It returns whether the notice is printed.
+func printNotice(state State) bool {
+	if state.Latest == "" || now().Sub(state.NotifiedAt) < NoticeInterval {
+		return false
+	}
+
+	current := getVersion()
+	currentVer, err := semver.NewVersion(current)
+	if err != nil {
+		logger.Debugf("Not checking the releases of version %s: %v", current, err)
+		return false
+	}
+	latestVer, err := semver.NewVersion(state.Latest)
+	if err != nil || !currentVer.LessThan(latestVer) {
+		return false
+	}
+
+	var notice strings.Builder
+	fmt.Fprintf(&notice, "A new version %s of backplane-cli is available, you are running %s. Run 'ocm backplane upgrade' to upgrade.\n", state.Latest, current)
+
+	listed, total := 0, 0
+	for _, release := range state.Releases {
+		releaseVer, err := semver.NewVersion(release.Version)
+		if err != nil || !currentVer.LessThan(releaseVer) || latestVer.LessThan(releaseVer) {
+			continue
+		}
+		for i, change := range release.Changes {
+			total++
+			if listed == maxNoticeChanges {
+				continue
+			}
+			if listed == 0 {
+				fmt.Fprintf(&notice, "Changes since %s:\n", current)
+			}
+			if i == 0 {
+				fmt.Fprintf(&notice, "  %s\n", release.Version)
+			}
+			fmt.Fprintf(&notice, "    - %s\n", change)
+			listed++
+		}
+	}
+	if total > listed {
+		fmt.Fprintf(&notice, "  ... and %d more changes, see %s\n", total-listed, releasesURL)
+	}
+	notice.WriteString("To disable these notices, run 'ocm backplane config set disable-update-check true'.\n")
+
+	_, _ = io.WriteString(noticeOutput, notice.String())
+
+	return true
+}
+
+// releaseNotes returns the notes of the releases newer than the current version, up to the latest one, the latest first
+func releaseNotes(releases []upgrade.Release, current string, latest string) []ReleaseNotes {
+	currentVer, err := semver.NewVersion(current)
+	if err != nil {
+		return nil
+	}
+	latestVer, err := semver.NewVersion(latest)
+	if err != nil {
+		return nil
+	}
+
+	type versionNotes struct {
+		version *semver.Version
+		notes   ReleaseNotes
+	}
+	newer := []versionNotes{}
+	for _, release := range releases {
+		ver, err := semver.NewVersion(release.TagName)
+		if err != nil || !currentVer.LessThan(ver) || latestVer.LessThan(ver) {
+			continue
+		}
+		newer = append(newer, versionNotes{ver, ReleaseNotes{Version: release.TagName, Changes: parseChanges(release.Body)}})
+	}
+	sort.Slice(newer, func(i, j int) bool {
+		return newer[j].version.LessThan(newer[i].version)
+	})
+
+	notes := []ReleaseNotes{}
+	for _, n := range newer {
+		notes = append(notes, n.notes)
+	}
+	return notes
+}
+
+// parseChanges returns the changes listed by the changelog of a release, without their commit
+func parseChanges(body string) []string {
+	changes := []string{}
+	for _, line := range strings.Split(body, "\n") {
+		if match := changePattern.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+			changes = append(changes, strings.TrimSpace(match[1]))
+		}
+	}
+	return changes
+}
+
+// getStatePath returns the file holding the last release check
+func getStatePath() (string, error) {
+	configDir, err := config.GetConfigDirectory()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, stateFileName), nil
+}
+
+// readState returns the last release check, an absent state file holds no check
+func readState() (State, error) {
+	state := State{}
+	path, err := getStatePath()
+	if err != nil {
+		return state, err
+	}
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return state, nil
+		}
+		return state, err
+	}
+	if err := json.Unmarshal(content, &state); err != nil {
+		return State{}, err
+	}
+	return state, nil
+}
+
+func writeState(state State) error {
+	path, err := getStatePath()
+	if err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0600)
+}
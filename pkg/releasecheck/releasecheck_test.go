@@ -0,0 +1,227 @@
+package releasecheck
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/backplane-cli/internal/upgrade"
+	"github.com/openshift/backplane-cli/pkg/cli/config"
+	"github.com/openshift/backplane-cli/pkg/info"
+)
+
+// fakeGitServer stands in for the internal/github client
+type fakeGitServer struct {
+	releases []upgrade.Release
+	err      error
+	calls    int
+	// blocked holds the check until closed, when set
+	blocked chan struct{}
+}
+
+func (f *fakeGitServer) GetLatestVersion(_ context.Context) (upgrade.Release, error) {
+	f.calls++
+	if f.blocked != nil {
+		<-f.blocked
+	}
+	if f.err != nil {
+		return upgrade.Release{}, f.err
+	}
+	return f.releases[0], nil
+}
+
+func (f *fakeGitServer) ListReleases(_ context.Context) ([]upgrade.Release, error) {
+	return f.releases, f.err
+}
+
+var _ = Describe("releasecheck", func() {
+	var (
+		cmd         *cobra.Command
+		git         *fakeGitServer
+		output      *bytes.Buffer
+		currentTime time.Time
+		statePath   string
+	)
+
+	BeforeEach(func() {
+		configDir := GinkgoT().TempDir()
+		GinkgoT().Setenv(info.BackplaneConfigPathEnvName, filepath.Join(configDir, "config.json"))
+		statePath = filepath.Join(configDir, stateFileName)
+
+		git = &fakeGitServer{releases: []upgrade.Release{
+			{TagName: "v0.3.0", Body: "## Changelog\n* 0123abcd Add the release notices (#30)\n* 4567efab Fix the upgrade (#31)\n"},
+			{TagName: "v0.2.0", Body: "## Changelog\n* 89abcdef Add the profiles (#20)\n"},
+			{TagName: "v0.1.0", Body: "## Changelog\n* 01234567 Initial release (#10)\n"},
+		}}
+		cmd = &cobra.Command{}
+		cmd.SetContext(context.Background())
+		output = &bytes.Buffer{}
+		currentTime = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+		originalGitServer, originalVersion, originalNow, originalOutput := newGitServer, getVersion, now, noticeOutput
+		newGitServer = func() GitServer { return git }
+		getVersion = func() string { return "0.1.0" }
+		now = func() time.Time { return currentTime }
+		noticeOutput = output
+		DeferCleanup(func() {
+			newGitServer, getVersion, now, noticeOutput = originalGitServer, originalVersion, originalNow, originalOutput
+		})
+	})
+
+	Context("Refresh", func() {
+		It("caches the latest release with the changelog of the newer releases", func() {
+			Expect(Refresh(context.Background())).To(Succeed())
+
+			state, err := readState()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(state.CheckedAt).To(Equal(currentTime))
+			Expect(state.Latest).To(Equal("v0.3.0"))
+			Expect(state.Releases).To(Equal([]ReleaseNotes{
+				{Version: "v0.3.0", Changes: []string{"Add the release notices (#30)", "Fix the upgrade (#31)"}},
+				{Version: "v0.2.0", Changes: []string{"Add the profiles (#20)"}},
+			}))
+		})
+
+		It("records a failed check without losing the last release", func() {
+			Expect(writeState(State{Latest: "v0.2.0"})).To(Succeed())
+			git.err = errors.New("offline")
+
+			Expect(Refresh(context.Background())).To(MatchError("offline"))
+
+			state, err := readState()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(state.CheckedAt).To(Equal(currentTime))
+			Expect(state.Latest).To(Equal("v0.2.0"))
+		})
+	})
+
+	Context("Wait", func() {
+		It("waits for the background refresh to be over", func() {
+			git.blocked = make(chan struct{})
+			done := Notify(cmd, config.BackplaneConfiguration{})
+			time.AfterFunc(10*time.Millisecond, func() { close(git.blocked) })
+
+			Expect(Wait(time.Minute)).To(BeTrue())
+			Expect(done).To(BeClosed())
+			Expect(statePath).To(BeAnExistingFile())
+		})
+
+		It("gives up on a background refresh longer than the timeout", func() {
+			git.blocked = make(chan struct{})
+			done := Notify(cmd, config.BackplaneConfiguration{})
+
+			Expect(Wait(10 * time.Millisecond)).To(BeFalse())
+
+			close(git.blocked)
+			<-done
+		})
+
+		It("returns at once without a background refresh", func() {
+			Expect(Wait(time.Minute)).To(BeTrue())
+		})
+	})
+
+	Context("Notify", func() {
+		It("does nothing when disabled", func() {
+			<-Notify(cmd, config.BackplaneConfiguration{DisableUpdateCheck: true})
+
+			Expect(output.String()).To(BeEmpty())
+			Expect(git.calls).To(Equal(0))
+			Expect(statePath).ToNot(BeAnExistingFile())
+		})
+
+		It("refreshes a stale check in the background without printing a notice", func() {
+			<-Notify(cmd, config.BackplaneConfiguration{})
+
+			Expect(output.String()).To(BeEmpty())
+			Expect(git.calls).To(Equal(1))
+			Expect(statePath).To(BeAnExistingFile())
+		})
+
+		It("does not refresh a fresh check", func() {
+			Expect(writeState(State{CheckedAt: currentTime.Add(-time.Hour), Latest: "v0.1.0"})).To(Succeed())
+
+			<-Notify(cmd, config.BackplaneConfiguration{})
+
+			Expect(git.calls).To(Equal(0))
+		})
+
+		It("prints the changelog of the newer releases once per day", func() {
+			Expect(Refresh(context.Background())).To(Succeed())
+
+			<-Notify(cmd, config.BackplaneConfiguration{})
+			Expect(output.String()).To(Equal(
+				"A new version v0.3.0 of backplane-cli is available, you are running 0.1.0. Run 'ocm backplane upgrade' to upgrade.\n" +
+					"Changes since 0.1.0:\n" +
+					"  v0.3.0\n" +
+					"    - Add the release notices (#30)\n" +
+					"    - Fix the upgrade (#31)\n" +
+					"  v0.2.0\n" +
+					"    - Add the profiles (#20)\n" +
+					"To disable these notices, run 'ocm backplane config set disable-update-check true'.\n",
+			))
+
+			output.Reset()
+			currentTime = currentTime.Add(time.Hour)
+			<-Notify(cmd, config.BackplaneConfiguration{})
+			Expect(output.String()).To(BeEmpty())
+
+			currentTime = currentTime.Add(NoticeInterval)
+			<-Notify(cmd, config.BackplaneConfiguration{})
+			Expect(output.String()).To(ContainSubstring("A new version v0.3.0"))
+		})
+
+		It("lists the changes of the releases newer than the running version only", func() {
+			Expect(Refresh(context.Background())).To(Succeed())
+			getVersion = func() string { return "0.2.0" }
+
+			<-Notify(cmd, config.BackplaneConfiguration{})
+
+			Expect(output.String()).To(ContainSubstring("Add the release notices (#30)"))
+			Expect(output.String()).ToNot(ContainSubstring("Add the profiles (#20)"))
+		})
+
+		It("does not print a notice when up to date", func() {
+			Expect(Refresh(context.Background())).To(Succeed())
+			getVersion = func() string { return "0.3.0" }
+
+			<-Notify(cmd, config.BackplaneConfiguration{})
+
+			Expect(output.String()).To(BeEmpty())
+		})
+
+		It("caps the number of changes listed", func() {
+			body := "## Changelog\n"
+			for i := 0; i < maxNoticeChanges+2; i++ {
+				body += "* a change\n"
+			}
+			Expect(writeState(State{CheckedAt: currentTime, Latest: "v0.3.0", Releases: []ReleaseNotes{{Version: "v0.3.0", Changes: parseChanges(body)}}})).To(Succeed())
+
+			<-Notify(cmd, config.BackplaneConfiguration{})
+
+			Expect(output.String()).To(ContainSubstring("... and 2 more changes"))
+		})
+
+		It("does not need the context of a command", func() {
+			<-Notify(nil, config.BackplaneConfiguration{})
+
+			Expect(git.calls).To(Equal(1))
+		})
+
+		It("does not fail on an unreadable state file", func() {
+			Expect(os.WriteFile(statePath, []byte("not json"), 0600)).To(Succeed())
+
+			<-Notify(cmd, config.BackplaneConfiguration{})
+
+			Expect(output.String()).To(BeEmpty())
+			Expect(git.calls).To(Equal(1))
+		})
+	})
+})
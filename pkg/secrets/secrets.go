@@ -0,0 +1,241 @@
+// Package secrets keeps the secrets of the config file, e.g. the PagerDuty and JIRA tokens,
+// in the OS keyring or in a file encrypted with age and a passphrase on the hosts without keyring.
+package secrets
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"filippo.io/age"
+	"github.com/zalando/go-keyring"
+)
+
+const (
+	// ServiceName is the service of the secrets in the keyring
+	ServiceName = "backplane-cli"
+
+	StoreKeyring = "keyring"
+	StoreFile    = "file"
+
+	// FileName is the age encrypted file holding the secrets, next to the config file
+	FileName = "secrets.age"
+)
+
+var (
+	// ErrNotFound is returned when the secret is not in the store
+	ErrNotFound = errors.New("secret not found")
+
+	// ScryptWorkFactor is the work factor of the passphrase encrypting the secrets file, overridden in tests
+	ScryptWorkFactor = 18
+)
+
+// Store keeps the secrets by their account name
+type Store interface {
+	// Name is the name of the store, keyring or file
+	Name() string
+	Get(account string) (string, error)
+	Set(account string, value string) error
+	Delete(account string) error
+}
+
+// keyringStore keeps the secrets in the OS keyring, the Secret Service on Linux
+type keyringStore struct{}
+
+// NewKeyringStore returns the store of the OS keyring
+func NewKeyringStore() Store {
+	return keyringStore{}
+}
+
+func (keyringStore) Name() string {
+	return StoreKeyring
+}
+
+func (keyringStore) Get(account string) (string, error) {
+	value, err := keyring.Get(ServiceName, account)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", ErrNotFound
+	}
+	return value, err
+}
+
+func (keyringStore) Set(account string, value string) error {
+	return keyring.Set(ServiceName, account, value)
+}
+
+func (keyringStore) Delete(account string) error {
+	err := keyring.Delete(ServiceName, account)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// KeyringAvailable tells if the OS keyring can be used, e.g. not on a headless host without Secret Service
+func KeyringAvailable() bool {
+	_, err := keyring.Get(ServiceName, "availability-check")
+	return err == nil || errors.Is(err, keyring.ErrNotFound)
+}
+
+// PassphraseFunc returns the passphrase of the secrets file
+type PassphraseFunc func() (string, error)
+
+// fileStore keeps the secrets in a file encrypted with age with a passphrase, for the hosts without keyring.
+// The passphrase is not kept on disk, so that reading the config directory is not enough to read the secrets.
+type fileStore struct {
+	mu         sync.Mutex
+	path       string
+	passphrase PassphraseFunc
+}
+
+// NewFileStore returns the store of the secrets file of a directory, encrypted with the given passphrase
+func NewFileStore(dir string, passphrase PassphraseFunc) Store {
+	return &fileStore{
+		path:       filepath.Join(dir, FileName),
+		passphrase: passphrase,
+	}
+}
+
+func (s *fileStore) Name() string {
+	return StoreFile
+}
+
+func (s *fileStore) Get(account string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	secrets, err := s.read()
+	if err != nil {
+		return "", err
+	}
+	value, ok := secrets[account]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+func (s *fileStore) Set(account string, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	secrets, err := s.read()
+	if err != nil {
+		return err
+	}
+	secrets[account] = value
+	return s.write(secrets)
+}
+
+func (s *fileStore) Delete(account string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	secrets, err := s.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := secrets[account]; !ok {
+		return ErrNotFound
+	}
+	delete(secrets, account)
+	return s.write(secrets)
+}
+
+// getPassphrase returns the passphrase of the secrets file
+func (s *fileStore) getPassphrase() (string, error) {
+	passphrase, err := s.passphrase()
+	if err != nil {
+		return "", fmt.Errorf("failed to get the passphrase of the secrets file: %v", err)
+	}
+	if passphrase == "" {
+		return "", fmt.Errorf("empty passphrase for the secrets file")
+	}
+	return passphrase, nil
+}
+
+// read decrypts the secrets of the file, an absent file has no secrets
+func (s *fileStore) read() (map[string]string, error) {
+	secrets := map[string]string{}
+	encrypted, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return secrets, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	passphrase, err := s.getPassphrase()
+	if err != nil {
+		return nil, err
+	}
+	identity, err := age.NewScryptIdentity(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := age.Decrypt(bytes.NewReader(encrypted), identity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the secrets file %s: %v", s.path, err)
+	}
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, &secrets); err != nil {
+		return nil, fmt.Errorf("failed to parse the secrets file %s: %v", s.path, err)
+	}
+	return secrets, nil
+}
+
+// write encrypts the secrets to the file with the passphrase. The file is replaced by renaming
+// a temporary file, so that an interrupted write does not lose the secrets already stored.
+func (s *fileStore) write(secrets map[string]string) error {
+	passphrase, err := s.getPassphrase()
+	if err != nil {
+		return err
+	}
+	recipient, err := age.NewScryptRecipient(passphrase)
+	if err != nil {
+		return err
+	}
+	recipient.SetWorkFactor(ScryptWorkFactor)
+	content, err := json.Marshal(secrets)
+	if err != nil {
+		return err
+	}
+
+	var encrypted bytes.Buffer
+	writer, err := age.Encrypt(&encrypted, recipient)
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(content); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	// CreateTemp creates the file readable by the user only
+	file, err := os.CreateTemp(filepath.Dir(s.path), "."+filepath.Base(s.path)+"-*")
+	if err != nil {
+		return fmt.Errorf("failed to create the secrets file: %v", err)
+	}
+	_, err = file.Write(encrypted.Bytes())
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), s.path)
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return fmt.Errorf("failed to write the secrets file %s: %v", s.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package secrets_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/zalando/go-keyring"
+
+	"github.com/openshift/backplane-cli/pkg/secrets"
+)
+
+var _ = Describe("Secret stores", func() {
+	Context("file store", func() {
+		var (
+			dir   string
+			store secrets.Store
+		)
+
+		passphrase := func(value string) secrets.PassphraseFunc {
+			return func() (string, error) { return value, nil }
+		}
+
+		BeforeEach(func() {
+			dir = GinkgoT().TempDir()
+			store = secrets.NewFileStore(dir, passphrase("my-passphrase"))
+
+			workFactor := secrets.ScryptWorkFactor
+			secrets.ScryptWorkFactor = 10
+			DeferCleanup(func() { secrets.ScryptWorkFactor = workFactor })
+		})
+
+		It("should keep the secrets encrypted with the passphrase in a file readable by the user only", func() {
+			Expect(store.Set("pd-key", "my-pd-key")).To(Succeed())
+			Expect(store.Set("stage/pd-key", "my-stage-pd-key")).To(Succeed())
+
+			value, err := store.Get("stage/pd-key")
+			Expect(err).To(BeNil())
+			Expect(value).To(Equal("my-stage-pd-key"))
+
+			info, err := os.Stat(filepath.Join(dir, secrets.FileName))
+			Expect(err).To(BeNil())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+			content, err := os.ReadFile(filepath.Join(dir, secrets.FileName))
+			Expect(err).To(BeNil())
+			Expect(string(content)).NotTo(ContainSubstring("my-pd-key"))
+			// only the secrets file is left in the directory, replaced by rename
+			entries, err := os.ReadDir(dir)
+			Expect(err).To(BeNil())
+			Expect(entries).To(HaveLen(1))
+
+			// another store of the same directory decrypts them with the passphrase
+			value, err = secrets.NewFileStore(dir, passphrase("my-passphrase")).Get("pd-key")
+			Expect(err).To(BeNil())
+			Expect(value).To(Equal("my-pd-key"))
+		})
+
+		It("should delete the secrets", func() {
+			Expect(store.Set("jira-token", "my-token")).To(Succeed())
+			Expect(store.Delete("jira-token")).To(Succeed())
+
+			_, err := store.Get("jira-token")
+			Expect(err).To(Equal(secrets.ErrNotFound))
+			Expect(store.Delete("jira-token")).To(Equal(secrets.ErrNotFound))
+		})
+
+		It("should not find secrets without secrets file", func() {
+			_, err := store.Get("pd-key")
+			Expect(err).To(Equal(secrets.ErrNotFound))
+		})
+
+		It("should fail to decrypt the secrets with another passphrase", func() {
+			Expect(store.Set("pd-key", "my-pd-key")).To(Succeed())
+
+			_, err := secrets.NewFileStore(dir, passphrase("another-passphrase")).Get("pd-key")
+			Expect(err).To(MatchError(ContainSubstring("failed to decrypt the secrets file")))
+		})
+
+		It("should fail without passphrase", func() {
+			Expect(secrets.NewFileStore(dir, passphrase("")).Set("pd-key", "my-pd-key")).To(MatchError(ContainSubstring("empty passphrase")))
+
+			noPassphrase := func() (string, error) { return "", errors.New("not in a terminal") }
+			Expect(secrets.NewFileStore(dir, noPassphrase).Set("pd-key", "my-pd-key")).To(MatchError(ContainSubstring("not in a terminal")))
+			Expect(filepath.Join(dir, secrets.FileName)).NotTo(BeAnExistingFile())
+		})
+
+	})
+
+	Context("keyring store", func() {
+		BeforeEach(func() {
+			keyring.MockInit()
+		})
+
+		It("should keep the secrets in the keyring", func() {
+			store := secrets.NewKeyringStore()
+			Expect(secrets.KeyringAvailable()).To(BeTrue())
+
+			Expect(store.Set("pd-key", "my-pd-key")).To(Succeed())
+			value, err := keyring.Get(secrets.ServiceName, "pd-key")
+			Expect(err).To(BeNil())
+			Expect(value).To(Equal("my-pd-key"))
+
+			Expect(store.Delete("pd-key")).To(Succeed())
+			_, err = store.Get("pd-key")
+			Expect(err).To(Equal(secrets.ErrNotFound))
+		})
+	})
+})
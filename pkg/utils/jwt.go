@@ -3,6 +3,7 @@ package utils
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/golang-jwt/jwt/v4"
 )
@@ -63,3 +64,17 @@ func GetContextNickname(namespace, clusterNick, userNick string) string {
 	tokens := strings.SplitN(userNick, "/", 2)
 	return namespace + "/" + clusterNick + "/" + tokens[0]
 }
+
+// GetExpiryFromJWT returns the expiry of the JWT token, without verifying it
+func GetExpiryFromJWT(token string) (time.Time, error) {
+	parser := new(jwt.Parser)
+	claims := jwt.MapClaims{}
+	if _, _, err := parser.ParseUnverified(token, claims); err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse jwt")
+	}
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return time.Time{}, fmt.Errorf("no expiry on given token")
+	}
+	return time.Unix(int64(exp), 0), nil
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"strings"
 	"text/tabwriter"
@@ -127,3 +128,33 @@ func RenderJSONBytes(i interface{}) error {
 	fmt.Println(string(resString))
 	return nil
 }
+
+// sparklineTicks are the bars used to render a sparkline, from the lowest to the highest
+var sparklineTicks = []rune("▁▂▃▄▅▆▇█")
+
+// RenderSparkline returns the values as a line of bars scaled between their minimum and maximum.
+// NaN and infinite values are rendered as a blank.
+func RenderSparkline(values []float64) string {
+	minValue, maxValue := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		minValue = math.Min(minValue, v)
+		maxValue = math.Max(maxValue, v)
+	}
+
+	var sb strings.Builder
+	for _, v := range values {
+		switch {
+		case math.IsNaN(v) || math.IsInf(v, 0):
+			sb.WriteRune(' ')
+		case maxValue == minValue:
+			sb.WriteRune(sparklineTicks[len(sparklineTicks)/2])
+		default:
+			tick := int((v - minValue) / (maxValue - minValue) * float64(len(sparklineTicks)-1))
+			sb.WriteRune(sparklineTicks[tick])
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRenderSparkline(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		expect string
+	}{
+		{
+			name:   "empty",
+			values: []float64{},
+			expect: "",
+		},
+		{
+			name:   "ascending",
+			values: []float64{0, 1, 2, 3, 4, 5, 6, 7},
+			expect: "▁▂▃▄▅▆▇█",
+		},
+		{
+			name:   "constant",
+			values: []float64{3, 3, 3},
+			expect: "▅▅▅",
+		},
+		{
+			name:   "missing values",
+			values: []float64{0, math.NaN(), 7},
+			expect: "▁ █",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderSparkline(tt.values); got != tt.expect {
+				t.Errorf("RenderSparkline(%v) = %q, expected %q", tt.values, got, tt.expect)
+			}
+		})
+	}
+}
@@ -13,17 +13,13 @@ import (
 
 	netUrl "net/url"
 
-	logger "github.com/sirupsen/logrus"
-
 	BackplaneApi "github.com/openshift/backplane-api/pkg/client"
 
-	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
 
-	"github.com/openshift/backplane-cli/internal/github"
-	"github.com/openshift/backplane-cli/pkg/info"
+	"github.com/openshift/backplane-cli/pkg/backplaneapi"
 )
 
 const (
@@ -145,17 +141,9 @@ func TryRenderErrorRaw(rsp *http.Response) error {
 }
 
 // GetFormattedError parses a Backplane API error and returns it as a formatted error message.
-// It extracts the status code and message from the response and formats them for display.
+// The error is a backplaneapi.APIError, whose kind, e.g. backplaneapi.ErrNotFound, can be checked with errors.Is.
 func GetFormattedError(rsp *http.Response) error {
-	data, err := TryParseBackplaneAPIError(rsp)
-	if err != nil {
-		return err
-	}
-	if data.Message != nil && data.StatusCode != nil {
-		return fmt.Errorf("error from backplane: \n Status Code: %d\n Message: %s", *data.StatusCode, *data.Message)
-	} else {
-		return fmt.Errorf("error from backplane: \n Status Code: %d\n Message: %s", rsp.StatusCode, rsp.Status)
-	}
+	return backplaneapi.ParseAPIError(rsp)
 }
 
 // TryPrintAPIError prints a Backplane API error in either raw JSON or formatted text.
@@ -245,46 +233,6 @@ func RemoveTempKubeConfig() {
 	}
 }
 
-// CheckBackplaneVersion checks the backplane version and aims to only
-// report any errors encountered in the process in order to
-// avoid calling functions act as usual
-func CheckBackplaneVersion(cmd *cobra.Command) {
-	if cmd == nil {
-		logger.Debugln("Command object is nil")
-		return
-	}
-
-	ctx := cmd.Context()
-	if ctx == nil {
-		logger.Debugln("Context object is nil")
-		return
-	}
-
-	git := github.NewClient()
-	if err := git.CheckConnection(); err != nil {
-		logger.WithField("Connection error", err).Warn("Could not connect to GitHub")
-		return
-	}
-
-	// Get the latest version from the GitHub API
-	latestVersionTag, err := git.GetLatestVersion(ctx)
-	if err != nil {
-		logger.WithField("Fetch error", err).Warn("Could not fetch latest version from GitHub")
-		return
-	}
-	// GitHub API keeps the v prefix in front which causes mismatch with info.Version
-	latestVersion := strings.TrimLeft(latestVersionTag.TagName, "v")
-
-	currentVersion := info.DefaultInfoService.GetVersion()
-	// Check if the local version is already up-to-date
-	if latestVersion == currentVersion {
-		logger.WithField("Current version", currentVersion).Info("Already up-to-date")
-		return
-	}
-
-	logger.WithField("Current version", currentVersion).WithField("Latest version", latestVersion).Warn("Your Backplane CLI is not up to date. Please run the command 'ocm backplane upgrade' to upgrade to the latest version")
-}
-
 // CheckValidPrompt checks that the stdin and stderr are valid for prompt
 // and are not provided by a pipe or file
 func CheckValidPrompt() bool {